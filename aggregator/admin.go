@@ -0,0 +1,221 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+)
+
+const (
+	// ProverStatusIdle is the status of a connected prover waiting for work
+	ProverStatusIdle = "idle"
+	// ProverStatusBusy is the status of a connected prover working
+	ProverStatusBusy = "busy"
+	// ProverStatusBackoff is the status of a connected prover not taking work after failing a proof
+	ProverStatusBackoff = "backoff"
+
+	jobBatchProof  = "batch proof"
+	jobAggregation = "aggregation"
+	jobFinalProof  = "final proof"
+)
+
+// ErrProofNotFound is returned when there is no proof with the given batch range
+var ErrProofNotFound = errors.New("proof not found")
+
+// ProverInfo is a prover connected to the aggregator
+type ProverInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Addr        string `json:"addr"`
+	ConnectedAt int64  `json:"connectedAt"`
+	// Tags are the kinds of work the prover takes, any work if empty
+	Tags   []string `json:"tags"`
+	Weight uint64   `json:"weight"`
+	Status string   `json:"status"`
+	// Job is the work the prover is doing
+	Job    string `json:"job,omitempty"`
+	JobAge string `json:"jobAge,omitempty"`
+}
+
+// ProofInfo is a proof of the aggregator, generated or being generated
+type ProofInfo struct {
+	BatchNumber      uint64  `json:"batchNumber"`
+	BatchNumberFinal uint64  `json:"batchNumberFinal"`
+	ProofID          *string `json:"proofId,omitempty"`
+	Prover           *string `json:"prover,omitempty"`
+	ProverID         *string `json:"proverId,omitempty"`
+	// Generating is true while the proof is locked by a prover
+	Generating      bool   `json:"generating"`
+	GeneratingSince int64  `json:"generatingSince,omitempty"`
+	Age             string `json:"age"`
+	CreatedAt       int64  `json:"createdAt"`
+	UpdatedAt       int64  `json:"updatedAt"`
+}
+
+// FinalProofStatus is the state of the final proof pipeline
+type FinalProofStatus struct {
+	LastVerifiedBatch uint64 `json:"lastVerifiedBatch"`
+	// VerifyingProof is true while a final proof is being sent to L1
+	VerifyingProof bool `json:"verifyingProof"`
+	// NextFinalProofAt is the time from which a final proof can be built
+	NextFinalProofAt int64 `json:"nextFinalProofAt"`
+	// FinalProofProvers are the provers building a final proof
+	FinalProofProvers []string `json:"finalProofProvers"`
+}
+
+// Provers returns the provers connected to the aggregator
+func (a *Aggregator) Provers() []ProverInfo {
+	return a.scheduler.connectedProvers()
+}
+
+// Proofs returns the proofs of the aggregator, generated or being generated
+func (a *Aggregator) Proofs(ctx context.Context) ([]ProofInfo, error) {
+	proofs, err := a.State.GetProofs(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proofs: %w", err)
+	}
+	now := time.Now()
+	infos := make([]ProofInfo, 0, len(proofs))
+	for _, proof := range proofs {
+		info := ProofInfo{
+			BatchNumber:      proof.BatchNumber,
+			BatchNumberFinal: proof.BatchNumberFinal,
+			ProofID:          proof.ProofID,
+			Prover:           proof.Prover,
+			ProverID:         proof.ProverID,
+			Age:              now.Sub(proof.CreatedAt).Round(time.Second).String(),
+			CreatedAt:        proof.CreatedAt.Unix(),
+			UpdatedAt:        proof.UpdatedAt.Unix(),
+		}
+		if proof.GeneratingSince != nil {
+			info.Generating = true
+			info.GeneratingSince = proof.GeneratingSince.Unix()
+			info.Age = now.Sub(*proof.GeneratingSince).Round(time.Second).String()
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// FinalProofStatus returns the state of the final proof pipeline
+func (a *Aggregator) FinalProofStatus(ctx context.Context) (*FinalProofStatus, error) {
+	status := &FinalProofStatus{FinalProofProvers: []string{}}
+	lastVerifiedBatch, err := a.State.GetLastVerifiedBatch(ctx, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get last verified batch: %w", err)
+	}
+	if lastVerifiedBatch != nil {
+		status.LastVerifiedBatch = lastVerifiedBatch.BatchNumber
+	}
+
+	a.TimeSendFinalProofMutex.RLock()
+	status.VerifyingProof = a.verifyingProof
+	status.NextFinalProofAt = a.TimeSendFinalProof.Unix()
+	a.TimeSendFinalProofMutex.RUnlock()
+
+	for _, prover := range a.scheduler.connectedProvers() {
+		if strings.HasPrefix(prover.Job, jobFinalProof) {
+			status.FinalProofProvers = append(status.FinalProofProvers, prover.ID)
+		}
+	}
+	return status, nil
+}
+
+// UnlockProof releases the generated proof with the given batch range from the prover
+// aggregating it, so another prover can use it. A proof still being generated has nothing to
+// release and must be deleted with DeleteProof instead
+func (a *Aggregator) UnlockProof(ctx context.Context, batchNumber, batchNumberFinal uint64) error {
+	a.StateDBMutex.Lock()
+	defer a.StateDBMutex.Unlock()
+
+	proof, err := a.getProof(ctx, batchNumber, batchNumberFinal)
+	if err != nil {
+		return err
+	}
+	if proof.GeneratingSince == nil {
+		return fmt.Errorf("proof %d-%d is not locked", batchNumber, batchNumberFinal)
+	}
+	if proof.Proof == "" {
+		return fmt.Errorf("proof %d-%d is still being generated, delete it to prove its batches again", batchNumber, batchNumberFinal)
+	}
+	proof.GeneratingSince = nil
+	if err := a.State.UpdateGeneratedProof(ctx, proof, nil); err != nil {
+		return fmt.Errorf("failed to unlock proof %d-%d: %w", batchNumber, batchNumberFinal, err)
+	}
+	a.logEvent(ctx, event.EventID_AggregatorProofUnlocked, fmt.Sprintf("proof %d-%d unlocked", batchNumber, batchNumberFinal))
+	return nil
+}
+
+// DeleteProof deletes the proof with the given batch range, the batches are proved again
+func (a *Aggregator) DeleteProof(ctx context.Context, batchNumber, batchNumberFinal uint64) error {
+	a.StateDBMutex.Lock()
+	defer a.StateDBMutex.Unlock()
+
+	if _, err := a.getProof(ctx, batchNumber, batchNumberFinal); err != nil {
+		return err
+	}
+	if err := a.State.DeleteGeneratedProofs(ctx, batchNumber, batchNumberFinal, nil); err != nil {
+		return fmt.Errorf("failed to delete proof %d-%d: %w", batchNumber, batchNumberFinal, err)
+	}
+	a.logEvent(ctx, event.EventID_AggregatorProofDeleted, fmt.Sprintf("proof %d-%d deleted", batchNumber, batchNumberFinal))
+	return nil
+}
+
+// CleanupLockedProofs deletes the proofs locked for more than the threshold, GeneratingProofCleanupThreshold
+// if empty, returning the number of proofs deleted
+func (a *Aggregator) CleanupLockedProofs(ctx context.Context, threshold string) (int64, error) {
+	if threshold == "" {
+		threshold = a.cfg.GeneratingProofCleanupThreshold
+	}
+	n, err := a.State.CleanupLockedProofs(ctx, threshold, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup locked proofs: %w", err)
+	}
+	a.logEvent(ctx, event.EventID_AggregatorLockedProofsCleanedUp,
+		fmt.Sprintf("%d proofs locked for more than %s deleted", n, threshold))
+	return n, nil
+}
+
+// getProof returns the proof with the given batch range
+func (a *Aggregator) getProof(ctx context.Context, batchNumber, batchNumberFinal uint64) (*state.Proof, error) {
+	proofs, err := a.State.GetProofs(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proofs: %w", err)
+	}
+	for _, proof := range proofs {
+		if proof.BatchNumber == batchNumber && proof.BatchNumberFinal == batchNumberFinal {
+			return proof, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d-%d", ErrProofNotFound, batchNumber, batchNumberFinal)
+}
+
+// proofJob describes the work of a prover generating a proof
+func proofJob(job string, batchNumber, batchNumberFinal uint64) string {
+	return fmt.Sprintf("%s %d-%d", job, batchNumber, batchNumberFinal)
+}
+
+// logEvent logs a warning and stores it in the event log
+func (a *Aggregator) logEvent(ctx context.Context, eventID event.EventID, description string) {
+	log.Warn(description)
+	if a.eventLog == nil {
+		return
+	}
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Aggregator,
+		Level:       event.Level_Warning,
+		EventID:     eventID,
+		Description: description,
+	}
+	if err := a.eventLog.LogEvent(ctx, ev); err != nil {
+		log.Errorf("error storing aggregator event: %v", err)
+	}
+}
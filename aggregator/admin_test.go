@@ -0,0 +1,109 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator/mocks"
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/event/nileventstorage"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminTestAggregator(t *testing.T, stateMock *mocks.StateMock) Aggregator {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	cfg := Config{GeneratingProofCleanupThreshold: "10m"}
+	a, err := New(cfg, stateMock, mocks.NewEthTxManager(t), mocks.NewEtherman(t), event.NewEventLog(event.Config{}, eventStorage))
+	require.NoError(t, err)
+	return a
+}
+
+func TestAdminProofs(t *testing.T) {
+	ctx := context.Background()
+	stateMock := mocks.NewStateMock(t)
+	a := newAdminTestAggregator(t, stateMock)
+
+	proverName, proverID, proofID := "prover", "prover-1", "proof-1"
+	generatingSince := time.Now().Add(-time.Hour)
+	lockedProof := &state.Proof{BatchNumber: 1, BatchNumberFinal: 2, Prover: &proverName, ProverID: &proverID, ProofID: &proofID, GeneratingSince: &generatingSince}
+	generatedProof := &state.Proof{BatchNumber: 3, BatchNumberFinal: 3, Proof: "proof", CreatedAt: time.Now()}
+	aggregatingProof := &state.Proof{BatchNumber: 4, BatchNumberFinal: 5, Proof: "proof", GeneratingSince: &generatingSince}
+	stateMock.On("GetProofs", ctx, nil).Return(func(context.Context, pgx.Tx) []*state.Proof {
+		return []*state.Proof{lockedProof, generatedProof, aggregatingProof}
+	}, nil)
+
+	proofs, err := a.Proofs(ctx)
+	require.NoError(t, err)
+	require.Len(t, proofs, 3)
+	assert.True(t, proofs[0].Generating)
+	assert.Equal(t, "1h0m0s", proofs[0].Age)
+	assert.Equal(t, &proverID, proofs[0].ProverID)
+	assert.False(t, proofs[1].Generating)
+
+	// Only the generated proofs locked by an aggregation can be unlocked, the ones still being
+	// generated must be deleted
+	stateMock.On("UpdateGeneratedProof", ctx, mock.MatchedBy(func(p *state.Proof) bool {
+		return p.BatchNumber == 4 && p.GeneratingSince == nil
+	}), nil).Return(nil).Once()
+	require.NoError(t, a.UnlockProof(ctx, 4, 5))
+	assert.ErrorContains(t, a.UnlockProof(ctx, 1, 2), "still being generated")
+	assert.NotNil(t, lockedProof.GeneratingSince)
+	assert.Error(t, a.UnlockProof(ctx, 3, 3))
+	assert.ErrorIs(t, a.UnlockProof(ctx, 6, 6), ErrProofNotFound)
+
+	// Delete the generated proof
+	stateMock.On("DeleteGeneratedProofs", ctx, uint64(3), uint64(3), nil).Return(nil).Once()
+	require.NoError(t, a.DeleteProof(ctx, 3, 3))
+	assert.ErrorIs(t, a.DeleteProof(ctx, 2, 3), ErrProofNotFound)
+
+	// Cleanup locked proofs with the configured threshold
+	stateMock.On("CleanupLockedProofs", ctx, "10m", nil).Return(int64(1), nil).Once()
+	n, err := a.CleanupLockedProofs(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	stateMock.On("CleanupLockedProofs", ctx, "1x", nil).Return(int64(0), errors.New("invalid interval")).Once()
+	_, err = a.CleanupLockedProofs(ctx, "1x")
+	assert.Error(t, err)
+}
+
+func TestAdminProversAndFinalProofStatus(t *testing.T) {
+	ctx := context.Background()
+	stateMock := mocks.NewStateMock(t)
+	a := newAdminTestAggregator(t, stateMock)
+
+	fast := newSchedulerTestProver(t, "fast-1", "fast")
+	slow := newSchedulerTestProver(t, "slow-1", "slow")
+	a.scheduler.connect(fast)
+	a.scheduler.connect(slow)
+	a.scheduler.setBusy(fast, true)
+	a.scheduler.setJob(fast, proofJob(jobFinalProof, 1, 10))
+
+	provers := a.Provers()
+	require.Len(t, provers, 2)
+	assert.Equal(t, "fast-1", provers[0].ID)
+	assert.Equal(t, ProverStatusBusy, provers[0].Status)
+	assert.Equal(t, "final proof 1-10", provers[0].Job)
+	assert.Equal(t, ProverStatusIdle, provers[1].Status)
+	assert.Empty(t, provers[1].Job)
+
+	stateMock.On("GetLastVerifiedBatch", ctx, nil).Return(&state.VerifiedBatch{BatchNumber: 0}, nil)
+	a.resetVerifyProofTime()
+	status, err := a.FinalProofStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fast-1"}, status.FinalProofProvers)
+	assert.False(t, status.VerifyingProof)
+	assert.Equal(t, a.TimeSendFinalProof.Unix(), status.NextFinalProofAt)
+
+	a.scheduler.setBusy(fast, false)
+	status, err = a.FinalProofStatus(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, status.FinalProofProvers)
+}
@@ -19,11 +19,13 @@ import (
 	"github.com/0xPolygon/cdk-validium-node/encoding"
 	ethmanTypes "github.com/0xPolygon/cdk-validium-node/etherman/types"
 	"github.com/0xPolygon/cdk-validium-node/ethtxmanager"
+	"github.com/0xPolygon/cdk-validium-node/event"
 	"github.com/0xPolygon/cdk-validium-node/log"
 	"github.com/0xPolygon/cdk-validium-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jackc/pgx/v4"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/peer"
 )
@@ -61,6 +63,9 @@ type Aggregator struct {
 
 	finalProof     chan finalProofMsg
 	verifyingProof bool
+	scheduler      *scheduler
+	proofDeadlines *proofDeadlines
+	eventLog       *event.EventLog
 
 	srv  *grpc.Server
 	ctx  context.Context
@@ -73,6 +78,7 @@ func New(
 	stateInterface stateInterface,
 	ethTxManager ethTxManager,
 	etherman etherman,
+	eventLog *event.EventLog,
 ) (Aggregator, error) {
 	var profitabilityChecker aggregatorTxProfitabilityChecker
 	switch cfg.TxProfitabilityCheckerType {
@@ -82,6 +88,11 @@ func New(
 		profitabilityChecker = NewTxProfitabilityCheckerAcceptAll(stateInterface, cfg.IntervalAfterWhichBatchConsolidateAnyway.Duration)
 	}
 
+	scheduler, err := newScheduler(cfg.Scheduling)
+	if err != nil {
+		return Aggregator{}, err
+	}
+
 	a := Aggregator{
 		cfg: cfg,
 
@@ -94,7 +105,10 @@ func New(
 		TimeCleanupLockedProofs: cfg.CleanupLockedProofsInterval,
 		GenerateProofDelay:      cfg.GenerateProofDelay,
 
-		finalProof: make(chan finalProofMsg),
+		finalProof:     make(chan finalProofMsg),
+		scheduler:      scheduler,
+		proofDeadlines: newProofDeadlines(cfg.ProofDeadlines),
+		eventLog:       eventLog,
 	}
 
 	return a, nil
@@ -123,13 +137,21 @@ func (a *Aggregator) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize proofs cache %w", err)
 	}
 
+	serverOptions, err := a.cfg.ProverAuth.serverOptions()
+	if err != nil {
+		return fmt.Errorf("failed to configure the authentication of the provers: %w", err)
+	}
+	if a.cfg.ProverAuth.ClientCAFile == "" && a.cfg.ProverAuth.Token == "" {
+		log.Warn("Provers are not authenticated, any client reaching the gRPC server can connect as a prover")
+	}
+
 	address := fmt.Sprintf("%s:%d", a.cfg.Host, a.cfg.Port)
 	lis, err := net.Listen("tcp", address)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	a.srv = grpc.NewServer()
+	a.srv = grpc.NewServer(serverOptions...)
 	prover.RegisterAggregatorServiceServer(a.srv, a)
 
 	healthService := newHealthChecker()
@@ -170,6 +192,13 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 	if ok {
 		proverAddr = p.Addr
 	}
+	commonName, err := a.authenticateProver(ctx)
+	if err != nil {
+		return a.rejectProver(ctx, proverAddr, codes.Unauthenticated, err)
+	}
+	if err := a.authorizeProver(commonName); err != nil {
+		return a.rejectProver(ctx, proverAddr, codes.PermissionDenied, err)
+	}
 	prover, err := prover.New(stream, proverAddr, a.cfg.ProofStatePollingInterval)
 	if err != nil {
 		return err
@@ -189,6 +218,9 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 		return err
 	}
 
+	a.scheduler.connect(prover)
+	defer a.scheduler.disconnect(prover)
+
 	for {
 		select {
 		case <-a.ctx.Done():
@@ -197,20 +229,29 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 		case <-ctx.Done():
 			// client disconnected
 			return ctx.Err()
+		case <-prover.Closed():
+			// a call exceeded its deadline, its response can't be told apart from the next ones
+			err := errors.New("prover stream closed after a call exceeded its deadline")
+			log.Warn(FirstToUpper(err.Error()))
+			return err
 
 		default:
 			isIdle, err := prover.IsIdle()
 			if err != nil {
 				log.Errorf("Failed to check if prover is idle: %v", err)
+				// don't let the other provers leave it the final proofs and aggregations while it can't take them
+				a.scheduler.setBusy(prover, true)
 				time.Sleep(a.cfg.RetryTime.Duration)
 				continue
 			}
 			if !isIdle {
 				log.Debug("Prover is not idle")
+				a.scheduler.setBusy(prover, true)
 				time.Sleep(a.cfg.RetryTime.Duration)
 				continue
 			}
 
+			a.scheduler.setBusy(prover, true)
 			_, err = a.tryBuildFinalProof(ctx, prover, nil)
 			if err != nil {
 				log.Errorf("Error checking proofs to verify: %v", err)
@@ -226,6 +267,7 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 					log.Errorf("Error trying to generate proof: %v", err)
 				}
 			}
+			a.scheduler.setBusy(prover, false)
 			if !proofGenerated {
 				// if no proof was generated (aggregated or batch) wait some time before retry
 				time.Sleep(a.cfg.RetryTime.Duration)
@@ -308,8 +350,10 @@ func (a *Aggregator) handleFailureToAddVerifyBatchToBeMonitored(ctx context.Cont
 
 // buildFinalProof builds and return the final proof for an aggregated/batch proof.
 func (a *Aggregator) buildFinalProof(ctx context.Context, prover proverInterface, proof *state.Proof) (*prover.FinalProof, error) {
+	proverName := prover.Name()
+
 	log := log.WithFields(
-		"prover", prover.Name(),
+		"prover", proverName,
 		"proverId", prover.ID(),
 		"proverAddr", prover.Addr(),
 		"recursiveProofId", *proof.ProofID,
@@ -326,10 +370,15 @@ func (a *Aggregator) buildFinalProof(ctx context.Context, prover proverInterface
 	log.Infof("Final proof ID for batches [%d-%d]: %s", proof.BatchNumber, proof.BatchNumberFinal, *proof.ProofID)
 	log = log.WithFields("finalProofId", finalProofID)
 
-	finalProof, err := prover.WaitFinalProof(ctx, *proof.ProofID)
+	waitCtx, cancel := a.proofDeadlines.withDeadline(ctx, jobFinalProof, 0)
+	defer cancel()
+	start := time.Now()
+	finalProof, err := prover.WaitFinalProof(waitCtx, *proof.ProofID)
 	if err != nil {
+		a.proofFailed(ctx, log, prover, proverName, *proof.ProofID, err)
 		return nil, fmt.Errorf("failed to get final proof from prover: %w", err)
 	}
+	a.proofGenerated(proverName, jobFinalProof, 0, time.Since(start))
 
 	log.Info("Final proof generated")
 
@@ -365,6 +414,11 @@ func (a *Aggregator) tryBuildFinalProof(ctx context.Context, prover proverInterf
 	)
 	log.Debug("tryBuildFinalProof start")
 
+	if !a.scheduler.takes(prover, ProverTagFinal) {
+		log.Debug("Final proofs are scheduled to other provers")
+		return false, nil
+	}
+
 	var err error
 	if !a.canVerifyProof() {
 		log.Debug("Time to verify proof not reached or proof verification in progress")
@@ -429,6 +483,7 @@ func (a *Aggregator) tryBuildFinalProof(ctx context.Context, prover proverInterf
 	)
 
 	// at this point we have an eligible proof, build the final one using it
+	a.scheduler.setJob(prover, proofJob(jobFinalProof, proof.BatchNumber, proof.BatchNumberFinal))
 	finalProof, err := a.buildFinalProof(ctx, prover, proof)
 	if err != nil {
 		err = fmt.Errorf("failed to build final proof, %w", err)
@@ -596,6 +651,11 @@ func (a *Aggregator) tryAggregateProofs(ctx context.Context, prover proverInterf
 	)
 	log.Debug("tryAggregateProofs start")
 
+	if !a.scheduler.takes(prover, ProverTagAggregation) {
+		log.Debug("Aggregations are scheduled to other provers")
+		return false, nil
+	}
+
 	proof1, proof2, err0 := a.getAndLockProofsToAggregate(ctx, prover)
 	if errors.Is(err0, state.ErrNotFound) {
 		// nothing to aggregate, swallow the error
@@ -622,6 +682,7 @@ func (a *Aggregator) tryAggregateProofs(ctx context.Context, prover proverInterf
 	}()
 
 	log.Infof("Aggregating proofs: %d-%d and %d-%d", proof1.BatchNumber, proof1.BatchNumberFinal, proof2.BatchNumber, proof2.BatchNumberFinal)
+	a.scheduler.setJob(prover, proofJob(jobAggregation, proof1.BatchNumber, proof2.BatchNumberFinal))
 
 	batches := fmt.Sprintf("%d-%d", proof1.BatchNumber, proof2.BatchNumberFinal)
 	log = log.WithFields("batches", batches)
@@ -657,14 +718,19 @@ func (a *Aggregator) tryAggregateProofs(ctx context.Context, prover proverInterf
 	log.Infof("Proof ID for aggregated proof: %v", *proof.ProofID)
 	log = log.WithFields("proofId", *proof.ProofID)
 
-	recursiveProof, err := prover.WaitRecursiveProof(ctx, *proof.ProofID)
+	waitCtx, cancel := a.proofDeadlines.withDeadline(ctx, jobAggregation, 0)
+	start := time.Now()
+	recursiveProof, err := prover.WaitRecursiveProof(waitCtx, *proof.ProofID)
+	cancel()
 	if err != nil {
+		a.proofFailed(ctx, log, prover, proverName, *proof.ProofID, err)
 		err = fmt.Errorf("failed to get aggregated proof from prover, %w", err)
 		log.Error(FirstToUpper(err.Error()))
 		return false, err
 	}
 
 	log.Info("Aggregated proof generated")
+	a.proofGenerated(proverName, jobAggregation, 0, time.Since(start))
 
 	proof.Proof = recursiveProof
 
@@ -807,13 +873,25 @@ func (a *Aggregator) getAndLockBatchToProve(ctx context.Context, prover proverIn
 }
 
 func (a *Aggregator) tryGenerateBatchProof(ctx context.Context, prover proverInterface) (bool, error) {
+	proverName := prover.Name()
+
 	log := log.WithFields(
-		"prover", prover.Name(),
+		"prover", proverName,
 		"proverId", prover.ID(),
 		"proverAddr", prover.Addr(),
 	)
 	log.Debug("tryGenerateBatchProof start")
 
+	if !a.scheduler.takes(prover, ProverTagBatch) {
+		log.Debug("Batch proofs are scheduled to other provers")
+		return false, nil
+	}
+	if !a.scheduler.startBatchProof() {
+		log.Debug("Max concurrent batch proofs reached")
+		return false, nil
+	}
+	defer a.scheduler.endBatchProof()
+
 	batchToProve, proof, err0 := a.getAndLockBatchToProve(ctx, prover)
 	if errors.Is(err0, state.ErrNotFound) {
 		// nothing to proof, swallow the error
@@ -842,6 +920,7 @@ func (a *Aggregator) tryGenerateBatchProof(ctx context.Context, prover proverInt
 	}()
 
 	log.Info("Generating proof from batch")
+	a.scheduler.setJob(prover, proofJob(jobBatchProof, batchToProve.BatchNumber, batchToProve.BatchNumber))
 
 	log.Infof("Sending zki + batch to the prover, batchNumber [%d]", batchToProve.BatchNumber)
 	inputProver, err := a.buildInputProver(ctx, batchToProve)
@@ -875,14 +954,20 @@ func (a *Aggregator) tryGenerateBatchProof(ctx context.Context, prover proverInt
 	log.Infof("Proof ID %v", *proof.ProofID)
 	log = log.WithFields("proofId", *proof.ProofID)
 
-	resGetProof, err := prover.WaitRecursiveProof(ctx, *proof.ProofID)
+	batchSize := len(batchToProve.BatchL2Data)
+	waitCtx, cancel := a.proofDeadlines.withDeadline(ctx, jobBatchProof, batchSize)
+	start := time.Now()
+	resGetProof, err := prover.WaitRecursiveProof(waitCtx, *proof.ProofID)
+	cancel()
 	if err != nil {
+		a.proofFailed(ctx, log, prover, proverName, *proof.ProofID, err)
 		err = fmt.Errorf("failed to get proof from prover, %w", err)
 		log.Error(FirstToUpper(err.Error()))
 		return false, err
 	}
 
 	log.Info("Batch proof generated")
+	a.proofGenerated(proverName, jobBatchProof, batchSize, time.Since(start))
 
 	proof.Proof = resGetProof
 
@@ -0,0 +1,113 @@
+package aggregator
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ProverAuthMetadataKey is the metadata key of the stream carrying the token of the prover
+	ProverAuthMetadataKey = "authorization"
+	// ProverAuthScheme is the scheme of the token of the prover
+	ProverAuthScheme = "Bearer "
+)
+
+// serverOptions returns the options of the gRPC server enabling TLS, and mutual TLS if the CAs of the
+// provers are configured. The token is only sent over TLS, and AllowedProvers requires mutual TLS: the
+// token is shared by all the provers, so it doesn't authenticate the names they report
+func (c ProverAuthConfig) serverOptions() ([]grpc.ServerOption, error) {
+	if c.Token != "" && c.CertFile == "" {
+		return nil, errors.New("the Token of the provers requires the CertFile and KeyFile of the server, it can't be sent in plaintext")
+	}
+	if len(c.AllowedProvers) > 0 && c.ClientCAFile == "" {
+		return nil, errors.New("the AllowedProvers require the ClientCAFile of the provers, neither the names the provers report nor the shared Token identify them")
+	}
+	if c.CertFile == "" {
+		if c.ClientCAFile != "" {
+			return nil, errors.New("the ClientCAFile of the provers requires the CertFile and KeyFile of the server")
+		}
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.ClientCAFile != "" {
+		pem, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CAs of the provers: %w", err)
+		}
+		cas := x509.NewCertPool()
+		if !cas.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificate found in %s", c.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = cas
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}, nil
+}
+
+// authenticateProver checks the token of the prover opening the stream, before sending it any request.
+// It returns the common name of the client certificate of the prover, empty without mutual TLS
+func (a *Aggregator) authenticateProver(ctx context.Context) (string, error) {
+	if a.cfg.ProverAuth.Token != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(ProverAuthMetadataKey)
+		if len(values) == 0 {
+			return "", errors.New("missing auth token")
+		}
+		token := strings.TrimPrefix(values[0], ProverAuthScheme)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.cfg.ProverAuth.Token)) != 1 {
+			return "", errors.New("invalid auth token")
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", nil
+	}
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName, nil
+}
+
+// authorizeProver checks the prover is one of the AllowedProvers. The prover is identified by the
+// common name of its client certificate, serverOptions requires mutual TLS with AllowedProvers
+func (a *Aggregator) authorizeProver(commonName string) error {
+	if len(a.cfg.ProverAuth.AllowedProvers) == 0 {
+		return nil
+	}
+	for _, allowed := range a.cfg.ProverAuth.AllowedProvers {
+		if commonName == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown prover %q", commonName)
+}
+
+// rejectProver records the rejection of a prover in the event log, returning the error closing its stream
+func (a *Aggregator) rejectProver(ctx context.Context, addr net.Addr, code codes.Code, err error) error {
+	a.logEvent(ctx, event.EventID_AggregatorProverRejected, fmt.Sprintf("prover %v rejected: %v", addr, err))
+	return status.Error(code, err.Error())
+}
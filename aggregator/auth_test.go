@@ -0,0 +1,222 @@
+package aggregator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator/mocks"
+	"github.com/0xPolygon/cdk-validium-node/aggregator/prover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// testCA issues the certificates of the aggregator and the provers
+type testCA struct {
+	dir    string
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	serial int64
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	ca := &testCA{dir: t.TempDir(), cert: cert, key: key, serial: 1}
+	ca.write(t, "ca.pem", "CERTIFICATE", der)
+	return ca
+}
+
+// issue returns the certificate and key files of a server or client certificate with the given common name
+func (ca *testCA) issue(t *testing.T, commonName string, server bool) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return ca.write(t, commonName+".pem", "CERTIFICATE", der), ca.write(t, commonName+"-key.pem", "EC PRIVATE KEY", keyDer)
+}
+
+func (ca *testCA) write(t *testing.T, name, blockType string, der []byte) string {
+	file := filepath.Join(ca.dir, name)
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return file
+}
+
+// clientCredentials returns the credentials of a prover trusting the CA, presenting the client
+// certificate with the given common name if not empty
+func (ca *testCA) clientCredentials(t *testing.T, commonName string) credentials.TransportCredentials {
+	cas := x509.NewCertPool()
+	cas.AddCert(ca.cert)
+	tlsConfig := &tls.Config{RootCAs: cas, MinVersion: tls.VersionTLS12}
+	if commonName != "" {
+		cert, err := tls.LoadX509KeyPair(ca.issue(t, commonName, false))
+		require.NoError(t, err)
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig)
+}
+
+// startAuthTestAggregator serves the aggregator stream with the given prover authentication
+func startAuthTestAggregator(t *testing.T, cfg ProverAuthConfig) string {
+	a, err := New(Config{ProverAuth: cfg}, mocks.NewStateMock(t), mocks.NewEthTxManager(t), mocks.NewEtherman(t), nil)
+	require.NoError(t, err)
+	serverOptions, err := cfg.serverOptions()
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(serverOptions...)
+	prover.RegisterAggregatorServiceServer(server, &a)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+// connectTestProver opens the stream as a prover with the given name, returning nil if the aggregator
+// trusts the prover and sends it a second request
+func connectTestProver(t *testing.T, addr string, creds credentials.TransportCredentials, token, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second) //nolint:gomnd
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ProverAuthMetadataKey, ProverAuthScheme+token)
+	}
+	stream, err := prover.NewAggregatorServiceClient(conn).Channel(ctx)
+	if err != nil {
+		return err
+	}
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	err = stream.Send(&prover.ProverMessage{
+		Id: msg.Id,
+		Response: &prover.ProverMessage_GetStatusResponse{
+			GetStatusResponse: &prover.GetStatusResponse{ProverName: name, ProverId: name + "-1"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = stream.Recv()
+	return err
+}
+
+func TestProverAuthToken(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, "aggregator", true)
+	addr := startAuthTestAggregator(t, ProverAuthConfig{
+		CertFile: certFile,
+		KeyFile:  keyFile,
+		Token:    "secret",
+	})
+	creds := ca.clientCredentials(t, "")
+
+	assert.NoError(t, connectTestProver(t, addr, creds, "secret", "prover"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(connectTestProver(t, addr, creds, "", "prover")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(connectTestProver(t, addr, creds, "banana", "prover")))
+}
+
+func TestProverAuthMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, "aggregator", true)
+	addr := startAuthTestAggregator(t, ProverAuthConfig{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		ClientCAFile:   filepath.Join(ca.dir, "ca.pem"),
+		AllowedProvers: []string{"prover"},
+	})
+
+	// The provers are identified by their client certificate, not the name they report
+	assert.NoError(t, connectTestProver(t, addr, ca.clientCredentials(t, "prover"), "", "unknown"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(connectTestProver(t, addr, ca.clientCredentials(t, "unknown"), "", "prover")))
+
+	// The provers without a client certificate of the CA can't connect
+	assert.Error(t, connectTestProver(t, addr, ca.clientCredentials(t, ""), "", "prover"))
+	other := newTestCA(t)
+	assert.Error(t, connectTestProver(t, addr, other.clientCredentials(t, "prover"), "", "prover"))
+	assert.Error(t, connectTestProver(t, addr, insecure.NewCredentials(), "", "prover"))
+}
+
+func TestProverAuthServerOptions(t *testing.T) {
+	opts, err := ProverAuthConfig{}.serverOptions()
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	_, err = ProverAuthConfig{ClientCAFile: "ca.pem"}.serverOptions()
+	assert.Error(t, err)
+	// The token isn't sent in plaintext and the reported names aren't trusted
+	_, err = ProverAuthConfig{Token: "secret"}.serverOptions()
+	assert.Error(t, err)
+	_, err = ProverAuthConfig{AllowedProvers: []string{"prover"}}.serverOptions()
+	assert.Error(t, err)
+
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, "aggregator", true)
+	_, err = ProverAuthConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile}.serverOptions()
+	assert.Error(t, err)
+	_, err = ProverAuthConfig{CertFile: certFile, KeyFile: filepath.Join(ca.dir, "missing.pem")}.serverOptions()
+	assert.Error(t, err)
+	_, err = ProverAuthConfig{CertFile: certFile, KeyFile: keyFile, AllowedProvers: []string{"prover"}}.serverOptions()
+	assert.Error(t, err)
+	// The shared token doesn't identify the provers
+	_, err = ProverAuthConfig{CertFile: certFile, KeyFile: keyFile, Token: "secret", AllowedProvers: []string{"prover"}}.serverOptions()
+	assert.Error(t, err)
+	opts, err = ProverAuthConfig{CertFile: certFile, KeyFile: keyFile, Token: "secret"}.serverOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+	opts, err = ProverAuthConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: filepath.Join(ca.dir, "ca.pem"), Token: "secret", AllowedProvers: []string{"prover"}}.serverOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+}
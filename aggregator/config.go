@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/cdk-validium-node/config/types"
 	"github.com/0xPolygon/cdk-validium-node/encoding"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
 )
 
 // TokenAmountWithDecimals is a wrapper type that parses token amount with decimals to big int
@@ -76,4 +77,86 @@ type Config struct {
 
 	// GenerateProofDelay is the delay to start generating proof for a batch since the batch's timestamp
 	GenerateProofDelay types.Duration `mapstructure:"GenerateProofDelay"`
+
+	// ProverAuth configures the authentication of the provers connecting to the aggregator
+	ProverAuth ProverAuthConfig `mapstructure:"ProverAuth"`
+
+	// Scheduling configures the work assigned to each prover
+	Scheduling SchedulingConfig `mapstructure:"Scheduling"`
+
+	// ProofDeadlines configures the deadline of the proofs generated by the provers
+	ProofDeadlines ProofDeadlinesConfig `mapstructure:"ProofDeadlines"`
+
+	// API is the configuration of the admin JSON-RPC API of the aggregator
+	API jsonrpc.AdminAPIConfig `mapstructure:"API"`
+}
+
+// ProverAuthConfig configures the authentication of the provers connecting to the gRPC server of
+// the aggregator. Any prover can connect if it's empty
+type ProverAuthConfig struct {
+	// CertFile is the PEM certificate of the gRPC server, TLS is enabled when set
+	CertFile string `mapstructure:"CertFile"`
+	// KeyFile is the PEM private key of the certificate of the gRPC server
+	KeyFile string `mapstructure:"KeyFile"`
+	// ClientCAFile is the PEM bundle of the CAs of the provers. When set, the provers must present a
+	// client certificate signed by one of them (mutual TLS)
+	ClientCAFile string `mapstructure:"ClientCAFile"`
+
+	// Token is the shared secret the provers must send as "Bearer <Token>" in the authorization
+	// metadata of the stream, not required if empty. It requires the CertFile, so it's sent over TLS
+	Token string `mapstructure:"Token"`
+
+	// AllowedProvers are the provers allowed to connect, any if empty. A prover is identified by the
+	// common name of its client certificate, so it requires the ClientCAFile
+	AllowedProvers []string `mapstructure:"AllowedProvers"`
+}
+
+// ProofDeadlinesConfig configures the deadline of the proofs generated by the provers. The deadline
+// of a proof is learned from the time taken by the previous proofs of the same kind and size, a proof
+// exceeding it is canceled and released for other provers. There is no deadline until a proof of the
+// same kind has been generated, the stuck proofs are then released by the GeneratingProofCleanupThreshold
+type ProofDeadlinesConfig struct {
+	// Enabled sets a deadline to the proofs, disabled by default
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Factor is the number of times the expected duration of a proof it can take
+	Factor float64 `mapstructure:"Factor"`
+
+	// MinDeadline is the minimum deadline of a proof
+	MinDeadline types.Duration `mapstructure:"MinDeadline"`
+
+	// HistorySize is the number of previous proofs of the same kind and size used to learn the
+	// expected duration of a proof
+	HistorySize uint64 `mapstructure:"HistorySize"`
+
+	// FailedProverBackoff is the time a prover doesn't take work after exceeding the deadline of a
+	// proof or failing to return it, so the proof is taken by other provers
+	FailedProverBackoff types.Duration `mapstructure:"FailedProverBackoff"`
+}
+
+// SchedulingConfig configures the work assigned to each prover
+type SchedulingConfig struct {
+	// MaxConcurrentBatchProofs is the maximum number of batch proofs generated at the same time
+	// by all the provers, 0 means no limit
+	MaxConcurrentBatchProofs uint64 `mapstructure:"MaxConcurrentBatchProofs"`
+
+	// Provers are the scheduling policies of the provers, the first policy matching a prover is
+	// applied. The provers not matching any policy take any work with weight 1
+	Provers []ProverPolicy `mapstructure:"Provers"`
+}
+
+// ProverPolicy is the scheduling policy of the provers matching its ID and Name
+type ProverPolicy struct {
+	// ID is the id of the prover, any id matches if empty
+	ID string `mapstructure:"ID"`
+	// Name is the name of the prover, any name matches if empty
+	Name string `mapstructure:"Name"`
+
+	// Tags are the kinds of work the prover takes: final, aggregation and batch. The prover takes
+	// any work if empty
+	Tags []string `mapstructure:"Tags"`
+
+	// Weight is the priority of the prover for final proofs and aggregations, a prover doesn't take
+	// them while a prover with a higher weight that can take them is idle. 0 means 1
+	Weight uint64 `mapstructure:"Weight"`
 }
@@ -0,0 +1,147 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator/metrics"
+	"github.com/0xPolygon/cdk-validium-node/log"
+)
+
+// cancelProofTimeout is the time the prover has to answer the cancellation of a proof exceeding
+// its deadline, its stream is closed if it doesn't
+const cancelProofTimeout = 10 * time.Second
+
+// deadlineKey identifies the proofs expected to take the same time, the proofs of a job with a batch
+// size in the same power of two
+type deadlineKey struct {
+	job  string
+	size int
+}
+
+// proofDeadlines learns the time taken by the proofs to set the deadline of the next ones
+type proofDeadlines struct {
+	cfg ProofDeadlinesConfig
+
+	mu        sync.Mutex
+	durations map[deadlineKey][]time.Duration
+}
+
+// newProofDeadlines creates a proofDeadlines without history
+func newProofDeadlines(cfg ProofDeadlinesConfig) *proofDeadlines {
+	return &proofDeadlines{
+		cfg:       cfg,
+		durations: make(map[deadlineKey][]time.Duration),
+	}
+}
+
+// newDeadlineKey returns the key of the proofs of the job with the given batch size
+func newDeadlineKey(job string, batchSize int) deadlineKey {
+	return deadlineKey{job: job, size: bits.Len(uint(batchSize))}
+}
+
+// observe adds the time taken by a proof to the history of its job and batch size
+func (d *proofDeadlines) observe(job string, batchSize int, duration time.Duration) {
+	if d.cfg.HistorySize == 0 {
+		return
+	}
+	key := newDeadlineKey(job, batchSize)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	durations := append(d.durations[key], duration)
+	if uint64(len(durations)) > d.cfg.HistorySize {
+		durations = durations[uint64(len(durations))-d.cfg.HistorySize:]
+	}
+	d.durations[key] = durations
+}
+
+// expected returns the expected time of a proof of the job and batch size, the mean of the previous
+// proofs. Without history for the batch size, the largest mean of the job is used. It returns false if
+// no proof of the job has been observed
+func (d *proofDeadlines) expected(job string, batchSize int) (time.Duration, bool) {
+	key := newDeadlineKey(job, batchSize)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if durations, ok := d.durations[key]; ok {
+		return mean(durations), true
+	}
+	var expected time.Duration
+	found := false
+	for k, durations := range d.durations {
+		if k.job != job {
+			continue
+		}
+		if m := mean(durations); !found || m > expected {
+			expected = m
+			found = true
+		}
+	}
+	return expected, found
+}
+
+// deadline returns the time a proof of the job and batch size can take, false if it has no deadline
+func (d *proofDeadlines) deadline(job string, batchSize int) (time.Duration, bool) {
+	if !d.cfg.Enabled {
+		return 0, false
+	}
+	expected, ok := d.expected(job, batchSize)
+	if !ok {
+		return 0, false
+	}
+	deadline := time.Duration(float64(expected) * d.cfg.Factor)
+	if deadline < d.cfg.MinDeadline.Duration {
+		deadline = d.cfg.MinDeadline.Duration
+	}
+	return deadline, true
+}
+
+// withDeadline returns a copy of the context canceled at the deadline of a proof of the job and batch size
+func (d *proofDeadlines) withDeadline(ctx context.Context, job string, batchSize int) (context.Context, context.CancelFunc) {
+	deadline, ok := d.deadline(job, batchSize)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, deadline)
+}
+
+func mean(durations []time.Duration) time.Duration {
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+	return total / time.Duration(len(durations))
+}
+
+// proofGenerated learns the time taken by the prover to generate a proof of the job and batch size
+func (a *Aggregator) proofGenerated(proverName string, job string, batchSize int, duration time.Duration) {
+	metrics.ProofDuration(proverName, duration)
+	a.proofDeadlines.observe(job, batchSize, duration)
+}
+
+// proofFailed handles a proof the prover failed to return, because it exceeded its deadline or
+// getting it failed. The proof is released by the caller and the prover backs off, so the proof is
+// taken by other provers right away
+func (a *Aggregator) proofFailed(ctx context.Context, log *log.Logger, prover proverInterface, proverName, proofID string, err error) {
+	if ctx.Err() != nil {
+		// the prover disconnected or the aggregator is stopping
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Warn("Proof deadline exceeded, canceling it")
+		metrics.ProofDeadlineExceeded(proverName)
+		cancelCtx, cancel := context.WithTimeout(ctx, cancelProofTimeout)
+		defer cancel()
+		if err := prover.CancelProofRequest(cancelCtx, proofID); err != nil {
+			log.Errorf("Failed to cancel proof: %v", err)
+		}
+	} else {
+		metrics.ProofPollingFailed(proverName)
+	}
+	log.Warnf("Prover backing off for %v", a.cfg.ProofDeadlines.FailedProverBackoff.Duration)
+	a.scheduler.backoff(prover, a.cfg.ProofDeadlines.FailedProverBackoff.Duration)
+}
@@ -0,0 +1,108 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator/mocks"
+	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofDeadlines(t *testing.T) {
+	d := newProofDeadlines(ProofDeadlinesConfig{
+		Enabled:     true,
+		Factor:      2,
+		MinDeadline: types.NewDuration(time.Minute),
+		HistorySize: 2,
+	})
+
+	// No deadline until a proof of the job is generated
+	_, ok := d.deadline(jobBatchProof, 1000)
+	assert.False(t, ok)
+
+	d.observe(jobBatchProof, 1000, 10*time.Minute)
+	d.observe(jobBatchProof, 1000, 20*time.Minute)
+	deadline, ok := d.deadline(jobBatchProof, 1000)
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Minute, deadline)
+
+	// Only the last proofs are used
+	d.observe(jobBatchProof, 1000, 40*time.Minute)
+	deadline, _ = d.deadline(jobBatchProof, 1000)
+	assert.Equal(t, 60*time.Minute, deadline)
+
+	// Batches of a similar size share the history, the largest one is used for unknown sizes
+	d.observe(jobBatchProof, 10, 5*time.Minute)
+	deadline, _ = d.deadline(jobBatchProof, 900)
+	assert.Equal(t, 60*time.Minute, deadline)
+	deadline, _ = d.deadline(jobBatchProof, 100000)
+	assert.Equal(t, 60*time.Minute, deadline)
+	deadline, _ = d.deadline(jobBatchProof, 12)
+	assert.Equal(t, 10*time.Minute, deadline)
+
+	// The deadline is never below the minimum
+	d.observe(jobAggregation, 0, time.Second)
+	deadline, _ = d.deadline(jobAggregation, 0)
+	assert.Equal(t, time.Minute, deadline)
+	_, ok = d.deadline(jobFinalProof, 0)
+	assert.False(t, ok)
+
+	d.cfg.Enabled = false
+	_, ok = d.deadline(jobBatchProof, 1000)
+	assert.False(t, ok)
+}
+
+func TestProofDeadlinesWithDeadline(t *testing.T) {
+	d := newProofDeadlines(ProofDeadlinesConfig{Enabled: true, Factor: 1, HistorySize: 1})
+
+	ctx, cancel := d.withDeadline(context.Background(), jobAggregation, 0)
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	d.observe(jobAggregation, 0, time.Millisecond)
+	ctx, cancel = d.withDeadline(context.Background(), jobAggregation, 0)
+	defer cancel()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestProofFailed(t *testing.T) {
+	a, err := New(Config{ProofDeadlines: ProofDeadlinesConfig{FailedProverBackoff: types.NewDuration(time.Hour)}},
+		mocks.NewStateMock(t), mocks.NewEthTxManager(t), mocks.NewEtherman(t), nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// The proof exceeding its deadline is canceled without waiting for the prover longer than the
+	// cancellation timeout, and the prover backs off
+	slow := newSchedulerTestProver(t, "slow-1", "slow")
+	slow.On("CancelProofRequest", mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && time.Until(deadline) <= cancelProofTimeout
+	}), "proof-1").Return(errors.New("banana")).Once()
+	a.scheduler.connect(slow)
+	a.proofFailed(ctx, log.WithFields(), slow, "slow", "proof-1", context.DeadlineExceeded)
+	assert.False(t, a.scheduler.takes(slow, ProverTagBatch))
+	assert.Equal(t, ProverStatusBackoff, a.Provers()[0].Status)
+
+	// The prover failing to return a proof backs off
+	broken := newSchedulerTestProver(t, "broken-1", "broken")
+	a.scheduler.connect(broken)
+	a.proofFailed(ctx, log.WithFields(), broken, "broken", "proof-2", errors.New("banana"))
+	assert.False(t, a.scheduler.takes(broken, ProverTagBatch))
+
+	// Nothing to do if the prover disconnected
+	gone := newSchedulerTestProver(t, "gone-1", "gone")
+	a.scheduler.connect(gone)
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	a.proofFailed(canceledCtx, log.WithFields(), gone, "gone", "proof-3", context.Canceled)
+	assert.True(t, a.scheduler.takes(gone, ProverTagBatch))
+}
@@ -0,0 +1,84 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+)
+
+// APIAggregator is the namespace of the aggregator endpoints
+const APIAggregator = "aggregator"
+
+// Endpoints contains the implementation of the "aggregator" endpoints
+type Endpoints struct {
+	a *Aggregator
+}
+
+// Services returns the JSON-RPC services exposed by the aggregator
+func (a *Aggregator) Services() []jsonrpc.Service {
+	return []jsonrpc.Service{
+		{
+			Name:    APIAggregator,
+			Service: &Endpoints{a: a},
+		},
+	}
+}
+
+// GetProvers returns the provers connected to the aggregator, with their status and current job
+func (e *Endpoints) GetProvers() (interface{}, types.Error) {
+	return e.a.Provers(), nil
+}
+
+// GetProofs returns the proofs generated or being generated, with their batch range, age and prover
+func (e *Endpoints) GetProofs() (interface{}, types.Error) {
+	proofs, err := e.a.Proofs(context.Background())
+	if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return proofs, nil
+}
+
+// GetFinalProofStatus returns the state of the final proof pipeline
+func (e *Endpoints) GetFinalProofStatus() (interface{}, types.Error) {
+	status, err := e.a.FinalProofStatus(context.Background())
+	if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return status, nil
+}
+
+// UnlockProof releases a generated proof stuck in an aggregation, so another prover can use it
+func (e *Endpoints) UnlockProof(batchNumber, batchNumberFinal types.ArgUint64) (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.a.cfg.API); err != nil {
+		return nil, err
+	}
+	if err := e.a.UnlockProof(context.Background(), uint64(batchNumber), uint64(batchNumberFinal)); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return nil, nil
+}
+
+// DeleteProof deletes a proof, the batches it proves are proved again
+func (e *Endpoints) DeleteProof(batchNumber, batchNumberFinal types.ArgUint64) (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.a.cfg.API); err != nil {
+		return nil, err
+	}
+	if err := e.a.DeleteProof(context.Background(), uint64(batchNumber), uint64(batchNumberFinal)); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return nil, nil
+}
+
+// CleanupLockedProofs deletes the proofs locked for more than the threshold, like "10m", returning
+// the number of proofs deleted. The GeneratingProofCleanupThreshold is used if the threshold is empty
+func (e *Endpoints) CleanupLockedProofs(threshold string) (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.a.cfg.API); err != nil {
+		return nil, err
+	}
+	n, err := e.a.CleanupLockedProofs(context.Background(), threshold)
+	if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return n, nil
+}
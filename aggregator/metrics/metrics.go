@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/0xPolygon/cdk-validium-node/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -9,6 +11,11 @@ const (
 	prefix                      = "aggregator_"
 	currentConnectedProversName = prefix + "current_connected_provers"
 	currentWorkingProversName   = prefix + "current_working_provers"
+	proofDeadlinesExceededName  = prefix + "proof_deadlines_exceeded"
+	proofPollingFailuresName    = prefix + "proof_polling_failures"
+	proofDurationName           = prefix + "proof_duration"
+
+	proverLabelName = "prover"
 )
 
 // Register the metrics for the sequencer package.
@@ -24,7 +31,36 @@ func Register() {
 		},
 	}
 
+	counterVecs := []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: proofDeadlinesExceededName,
+				Help: "[AGGREGATOR] proofs released for exceeding their deadline",
+			},
+			Labels: []string{proverLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: proofPollingFailuresName,
+				Help: "[AGGREGATOR] proofs released for failing to get them from the prover",
+			},
+			Labels: []string{proverLabelName},
+		},
+	}
+
+	histogramVecs := []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name: proofDurationName,
+				Help: "[AGGREGATOR] time taken by the prover to generate a proof, in seconds",
+			},
+			Labels: []string{proverLabelName},
+		},
+	}
+
 	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounterVecs(counterVecs...)
+	metrics.RegisterHistogramVecs(histogramVecs...)
 }
 
 // ConnectedProver increments the gauge for the current number of connected
@@ -49,3 +85,21 @@ func WorkingProver() {
 func IdlingProver() {
 	metrics.GaugeDec(currentWorkingProversName)
 }
+
+// ProofDeadlineExceeded increments the counter of proofs released for
+// exceeding their deadline for the given prover.
+func ProofDeadlineExceeded(prover string) {
+	metrics.CounterVecInc(proofDeadlinesExceededName, prover)
+}
+
+// ProofPollingFailed increments the counter of proofs released for failing to
+// get them from the given prover.
+func ProofPollingFailed(prover string) {
+	metrics.CounterVecInc(proofPollingFailuresName, prover)
+}
+
+// ProofDuration observes the time taken by the given prover to generate a
+// proof.
+func ProofDuration(prover string, duration time.Duration) {
+	metrics.HistogramVecObserve(proofDurationName, prover, duration.Seconds())
+}
@@ -0,0 +1,353 @@
+package mockprover
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator"
+	"github.com/0xPolygon/cdk-validium-node/aggregator/prover"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// mockedStateRoot and mockedLocalExitRoot are the roots returned in the final proofs of the
+	// zkProver mock, the aggregator replaces them with the ones computed by the executor
+	mockedStateRoot     = "0x090bcaf734c4f06c93954a827b45a6e8c67b8e0fd1e0a35a1c5982d6961828f9"
+	mockedLocalExitRoot = "0x17c04c3760510b48c6012742c540a81aba4bca2f78b9d14bfd2f123e2e53ea3e"
+
+	// finalProofWords is the number of 32 bytes words of the final proof sent to the verifier contract
+	finalProofWords = 24
+)
+
+// Config represents the configuration of the mock prover
+type Config struct {
+	// Name is the prover name reported to the aggregator
+	Name string
+	// ID is the prover id reported to the aggregator
+	ID string
+	// ForkID is the fork id the prover reports to support, it must match the one of the aggregator
+	ForkID uint64
+
+	// BatchProofDelay is the time it takes to generate a batch proof
+	BatchProofDelay time.Duration
+	// AggregatedProofDelay is the time it takes to aggregate two proofs
+	AggregatedProofDelay time.Duration
+	// FinalProofDelay is the time it takes to generate a final proof
+	FinalProofDelay time.Duration
+
+	// RequestFailureRate is the probability, between 0 and 1, of rejecting a proof request with an internal error
+	RequestFailureRate float64
+	// ProofFailureRate is the probability, between 0 and 1, of a proof being completed with an error
+	ProofFailureRate float64
+	// Seed is the seed of the failures, the same seed fails the same requests
+	Seed int64
+
+	// RetryInterval is the time to wait before connecting again to the aggregator when the stream is closed
+	RetryInterval time.Duration
+
+	// CAFile is the PEM bundle of the CAs of the aggregator, the connection uses TLS when set
+	CAFile string
+	// CertFile is the PEM client certificate presented to the aggregator requiring mutual TLS
+	CertFile string
+	// KeyFile is the PEM private key of the client certificate
+	KeyFile string
+	// Token is the auth token sent to the aggregator when opening the stream
+	Token string
+}
+
+// mockProof is a proof being generated by the mock prover
+type mockProof struct {
+	readyAt  time.Time
+	failed   bool
+	canceled bool
+	// recursive is the batch or aggregated proof, final is the final proof
+	recursive string
+	final     *prover.FinalProof
+}
+
+// MockProver is a prover client that connects to the aggregator and answers its requests with
+// deterministic fake proofs, so the aggregator can be run end to end without real provers
+type MockProver struct {
+	cfg Config
+
+	mu             sync.Mutex
+	rand           *rand.Rand
+	proofs         map[string]*mockProof
+	order          []string
+	requests       uint64
+	lastComputedID string
+	lastComputedAt time.Time
+}
+
+// New creates a MockProver
+func New(cfg Config) (*MockProver, error) {
+	if cfg.RequestFailureRate < 0 || cfg.RequestFailureRate > 1 {
+		return nil, fmt.Errorf("invalid request failure rate %v, it must be between 0 and 1", cfg.RequestFailureRate)
+	}
+	if cfg.ProofFailureRate < 0 || cfg.ProofFailureRate > 1 {
+		return nil, fmt.Errorf("invalid proof failure rate %v, it must be between 0 and 1", cfg.ProofFailureRate)
+	}
+	return &MockProver{
+		cfg:    cfg,
+		rand:   rand.New(rand.NewSource(cfg.Seed)), //nolint:gosec
+		proofs: make(map[string]*mockProof),
+	}, nil
+}
+
+// Run connects to the aggregator and answers its requests until the context is done,
+// connecting again when the stream is closed
+func (p *MockProver) Run(ctx context.Context, aggregatorURI string) error {
+	for {
+		err := p.serve(ctx, aggregatorURI)
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Warnf("mock prover disconnected from the aggregator %s: %v. Connecting again in %v", aggregatorURI, err, p.cfg.RetryInterval)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(p.cfg.RetryInterval):
+		}
+	}
+}
+
+// serve answers the requests of the aggregator over a single stream
+func (p *MockProver) serve(ctx context.Context, aggregatorURI string) error {
+	creds, err := p.transportCredentials()
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.DialContext(ctx, aggregatorURI, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if p.cfg.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, aggregator.ProverAuthMetadataKey, aggregator.ProverAuthScheme+p.cfg.Token)
+	}
+	stream, err := prover.NewAggregatorServiceClient(conn).Channel(ctx)
+	if err != nil {
+		return err
+	}
+	log.Infof("mock prover %s connected to the aggregator %s", p.cfg.ID, aggregatorURI)
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(p.handle(msg)); err != nil {
+			return err
+		}
+	}
+}
+
+// transportCredentials returns the credentials of the connection to the aggregator, TLS if the CAs of
+// the aggregator are configured
+func (p *MockProver) transportCredentials() (credentials.TransportCredentials, error) {
+	if p.cfg.CAFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+	pem, err := os.ReadFile(p.cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CAs of the aggregator: %w", err)
+	}
+	cas := x509.NewCertPool()
+	if !cas.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no CA certificate found in %s", p.cfg.CAFile)
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    cas,
+		MinVersion: tls.VersionTLS12,
+	}
+	if p.cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(p.cfg.CertFile, p.cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// handle answers a request of the aggregator
+func (p *MockProver) handle(msg *prover.AggregatorMessage) *prover.ProverMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res := &prover.ProverMessage{Id: msg.Id}
+	switch req := msg.Request.(type) {
+	case *prover.AggregatorMessage_GetStatusRequest:
+		res.Response = &prover.ProverMessage_GetStatusResponse{GetStatusResponse: p.status()}
+	case *prover.AggregatorMessage_GenBatchProofRequest:
+		id, result := p.startProof("batch", p.cfg.BatchProofDelay, &mockProof{
+			recursive: batchProof(req.GenBatchProofRequest.Input),
+		})
+		res.Response = &prover.ProverMessage_GenBatchProofResponse{
+			GenBatchProofResponse: &prover.GenBatchProofResponse{Id: id, Result: result},
+		}
+	case *prover.AggregatorMessage_GenAggregatedProofRequest:
+		id, result := p.startProof("aggregated", p.cfg.AggregatedProofDelay, &mockProof{
+			recursive: hashProof([]byte(req.GenAggregatedProofRequest.RecursiveProof_1), []byte(req.GenAggregatedProofRequest.RecursiveProof_2)),
+		})
+		res.Response = &prover.ProverMessage_GenAggregatedProofResponse{
+			GenAggregatedProofResponse: &prover.GenAggregatedProofResponse{Id: id, Result: result},
+		}
+	case *prover.AggregatorMessage_GenFinalProofRequest:
+		id, result := p.startProof("final", p.cfg.FinalProofDelay, &mockProof{
+			final: finalProof(req.GenFinalProofRequest.RecursiveProof),
+		})
+		res.Response = &prover.ProverMessage_GenFinalProofResponse{
+			GenFinalProofResponse: &prover.GenFinalProofResponse{Id: id, Result: result},
+		}
+	case *prover.AggregatorMessage_CancelRequest:
+		result := prover.Result_RESULT_ERROR
+		if proof, ok := p.proofs[req.CancelRequest.Id]; ok {
+			proof.canceled = true
+			result = prover.Result_RESULT_OK
+		}
+		res.Response = &prover.ProverMessage_CancelResponse{CancelResponse: &prover.CancelResponse{Result: result}}
+	case *prover.AggregatorMessage_GetProofRequest:
+		res.Response = &prover.ProverMessage_GetProofResponse{GetProofResponse: p.getProof(req.GetProofRequest.Id)}
+	default:
+		log.Warnf("mock prover received an unknown request %T", msg.Request)
+	}
+	return res
+}
+
+// status returns the status of the prover, which is computing while there are proofs not ready
+func (p *MockProver) status() *prover.GetStatusResponse {
+	status := &prover.GetStatusResponse{
+		Status:                prover.GetStatusResponse_STATUS_IDLE,
+		LastComputedRequestId: p.lastComputedID,
+		ProverName:            p.cfg.Name,
+		ProverId:              p.cfg.ID,
+		ForkId:                p.cfg.ForkID,
+		NumberOfCores:         1,
+	}
+	if !p.lastComputedAt.IsZero() {
+		status.LastComputedEndTime = uint64(p.lastComputedAt.Unix())
+	}
+	now := time.Now()
+	for _, id := range p.order {
+		proof := p.proofs[id]
+		if proof.canceled || !now.Before(proof.readyAt) {
+			continue
+		}
+		if status.Status == prover.GetStatusResponse_STATUS_IDLE {
+			status.Status = prover.GetStatusResponse_STATUS_COMPUTING
+			status.CurrentComputingRequestId = id
+		} else {
+			status.PendingRequestQueueIds = append(status.PendingRequestQueueIds, id)
+		}
+	}
+	return status
+}
+
+// startProof registers a new proof, or rejects the request depending on the request failure rate
+func (p *MockProver) startProof(kind string, delay time.Duration, proof *mockProof) (string, prover.Result) {
+	p.requests++
+	id := fmt.Sprintf("%s-%s-%d", p.cfg.ID, kind, p.requests)
+	if p.rand.Float64() < p.cfg.RequestFailureRate {
+		log.Infof("mock prover rejects the %s proof request %s", kind, id)
+		return id, prover.Result_RESULT_INTERNAL_ERROR
+	}
+	proof.readyAt = time.Now().Add(delay)
+	proof.failed = p.rand.Float64() < p.cfg.ProofFailureRate
+	p.proofs[id] = proof
+	p.order = append(p.order, id)
+	log.Infof("mock prover generating the %s proof %s, ready in %v", kind, id, delay)
+	return id, prover.Result_RESULT_OK
+}
+
+// getProof returns the proof if it's ready, removing it once it's completed
+func (p *MockProver) getProof(id string) *prover.GetProofResponse {
+	res := &prover.GetProofResponse{Id: id}
+	proof, ok := p.proofs[id]
+	switch {
+	case !ok:
+		res.Result = prover.GetProofResponse_RESULT_ERROR
+		res.ResultString = "unknown proof id"
+		return res
+	case proof.canceled:
+		res.Result = prover.GetProofResponse_RESULT_CANCEL
+	case time.Now().Before(proof.readyAt):
+		res.Result = prover.GetProofResponse_RESULT_PENDING
+		return res
+	case proof.failed:
+		res.Result = prover.GetProofResponse_RESULT_COMPLETED_ERROR
+		res.ResultString = "mock prover failure"
+	case proof.final != nil:
+		res.Result = prover.GetProofResponse_RESULT_COMPLETED_OK
+		res.Proof = &prover.GetProofResponse_FinalProof{FinalProof: proof.final}
+	default:
+		res.Result = prover.GetProofResponse_RESULT_COMPLETED_OK
+		res.Proof = &prover.GetProofResponse_RecursiveProof{RecursiveProof: proof.recursive}
+	}
+	p.removeProof(id)
+	return res
+}
+
+func (p *MockProver) removeProof(id string) {
+	delete(p.proofs, id)
+	for i, orderedID := range p.order {
+		if orderedID == id {
+			p.order = append(p.order[:i], p.order[i+1:]...)
+			break
+		}
+	}
+	p.lastComputedID = id
+	p.lastComputedAt = time.Now()
+}
+
+// batchProof returns the fake proof of a batch, derived from its public inputs
+func batchProof(input *prover.InputProver) string {
+	publicInputs := input.GetPublicInputs()
+	if publicInputs == nil {
+		return hashProof(nil)
+	}
+	oldBatchNum := make([]byte, 8) //nolint:gomnd
+	binary.BigEndian.PutUint64(oldBatchNum, publicInputs.OldBatchNum)
+	return hashProof(
+		publicInputs.OldStateRoot,
+		publicInputs.OldAccInputHash,
+		oldBatchNum,
+		publicInputs.BatchL2Data,
+		publicInputs.GlobalExitRoot,
+		[]byte(publicInputs.SequencerAddr),
+	)
+}
+
+// finalProof returns the fake final proof of a recursive proof, which has the size the verifier
+// contract expects. Only a verifier contract stand-in accepting any proof, like the MockVerifier
+// contract, accepts it
+func finalProof(recursiveProof string) *prover.FinalProof {
+	proof := make([]byte, 0, finalProofWords*32) //nolint:gomnd
+	for i := 0; i < finalProofWords; i++ {
+		proof = append(proof, crypto.Keccak256([]byte(recursiveProof), []byte{byte(i)})...)
+	}
+	return &prover.FinalProof{
+		Proof: hexutil.Encode(proof),
+		Public: &prover.PublicInputsExtended{
+			NewStateRoot:     []byte(mockedStateRoot),
+			NewLocalExitRoot: []byte(mockedLocalExitRoot),
+		},
+	}
+}
+
+func hashProof(data ...[]byte) string {
+	return crypto.Keccak256Hash(data...).Hex()
+}
@@ -0,0 +1,182 @@
+package mockprover
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator"
+	"github.com/0xPolygon/cdk-validium-node/aggregator/prover"
+	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func init() {
+	log.Init(log.Config{
+		Level:   "debug",
+		Outputs: []string{"stderr"},
+	})
+}
+
+// testAggregator is an aggregator service that runs the given function with the prover connected
+type testAggregator struct {
+	prover.UnimplementedAggregatorServiceServer
+	run func(p *prover.Prover) error
+}
+
+func (a *testAggregator) Channel(stream prover.AggregatorService_ChannelServer) error {
+	p, err := prover.New(stream, nil, types.NewDuration(10*time.Millisecond))
+	if err != nil {
+		return err
+	}
+	return a.run(p)
+}
+
+func TestMockProverEndToEnd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type result struct {
+		batchProof, aggregatedProof string
+		finalProof                  *prover.FinalProof
+		supportsForkID              bool
+		err                         error
+	}
+	results := make(chan result, 1)
+	generateProofs := func(p *prover.Prover) (res result) {
+		res.supportsForkID = p.SupportsForkID(6) //nolint:gomnd
+		input := &prover.InputProver{PublicInputs: &prover.PublicInputs{OldBatchNum: 1, BatchL2Data: []byte{0x01}}}
+		var id *string
+		if id, res.err = p.BatchProof(input); res.err != nil {
+			return res
+		}
+		if res.batchProof, res.err = p.WaitRecursiveProof(ctx, *id); res.err != nil {
+			return res
+		}
+		if id, res.err = p.AggregatedProof(res.batchProof, res.batchProof); res.err != nil {
+			return res
+		}
+		if res.aggregatedProof, res.err = p.WaitRecursiveProof(ctx, *id); res.err != nil {
+			return res
+		}
+		if id, res.err = p.FinalProof(res.aggregatedProof, "0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D"); res.err != nil {
+			return res
+		}
+		res.finalProof, res.err = p.WaitFinalProof(ctx, *id)
+		return res
+	}
+	aggregator := &testAggregator{run: func(p *prover.Prover) error {
+		results <- generateProofs(p)
+		<-ctx.Done()
+		return nil
+	}}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	prover.RegisterAggregatorServiceServer(server, aggregator)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	mockProver, err := New(Config{Name: "mock", ID: "mock-1", ForkID: 6, BatchProofDelay: 30 * time.Millisecond, RetryInterval: time.Second})
+	require.NoError(t, err)
+	go func() { _ = mockProver.Run(ctx, listener.Addr().String()) }()
+
+	var res result
+	select {
+	case res = <-results:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the mock prover didn't generate the proofs")
+	}
+	require.NoError(t, res.err)
+	assert.True(t, res.supportsForkID)
+	assert.Equal(t, batchProof(&prover.InputProver{PublicInputs: &prover.PublicInputs{OldBatchNum: 1, BatchL2Data: []byte{0x01}}}), res.batchProof)
+	assert.Equal(t, hashProof([]byte(res.batchProof), []byte(res.batchProof)), res.aggregatedProof)
+	assert.Len(t, res.finalProof.Proof, finalProofWords*32*2+2)
+	assert.Equal(t, mockedStateRoot, string(res.finalProof.Public.NewStateRoot))
+	assert.Equal(t, mockedLocalExitRoot, string(res.finalProof.Public.NewLocalExitRoot))
+}
+
+func TestMockProverFailures(t *testing.T) {
+	getProof := func(p *MockProver, id string) *prover.GetProofResponse {
+		res := p.handle(&prover.AggregatorMessage{Request: &prover.AggregatorMessage_GetProofRequest{GetProofRequest: &prover.GetProofRequest{Id: id}}})
+		return res.Response.(*prover.ProverMessage_GetProofResponse).GetProofResponse
+	}
+	genBatchProof := func(p *MockProver) *prover.GenBatchProofResponse {
+		res := p.handle(&prover.AggregatorMessage{Request: &prover.AggregatorMessage_GenBatchProofRequest{GenBatchProofRequest: &prover.GenBatchProofRequest{}}})
+		return res.Response.(*prover.ProverMessage_GenBatchProofResponse).GenBatchProofResponse
+	}
+
+	p, err := New(Config{RequestFailureRate: 1})
+	require.NoError(t, err)
+	assert.Equal(t, prover.Result_RESULT_INTERNAL_ERROR, genBatchProof(p).Result)
+
+	p, err = New(Config{ProofFailureRate: 1})
+	require.NoError(t, err)
+	res := genBatchProof(p)
+	require.Equal(t, prover.Result_RESULT_OK, res.Result)
+	assert.Equal(t, prover.GetProofResponse_RESULT_COMPLETED_ERROR, getProof(p, res.Id).Result)
+	assert.Equal(t, prover.GetProofResponse_RESULT_ERROR, getProof(p, res.Id).Result)
+
+	p, err = New(Config{ID: "mock-1", BatchProofDelay: time.Hour})
+	require.NoError(t, err)
+	res = genBatchProof(p)
+	assert.Equal(t, prover.GetProofResponse_RESULT_PENDING, getProof(p, res.Id).Result)
+	assert.Equal(t, prover.GetStatusResponse_STATUS_COMPUTING, p.status().Status)
+	assert.Equal(t, res.Id, p.status().CurrentComputingRequestId)
+
+	cancelRes := p.handle(&prover.AggregatorMessage{Request: &prover.AggregatorMessage_CancelRequest{CancelRequest: &prover.CancelRequest{Id: res.Id}}})
+	assert.Equal(t, prover.Result_RESULT_OK, cancelRes.Response.(*prover.ProverMessage_CancelResponse).CancelResponse.Result)
+	assert.Equal(t, prover.GetStatusResponse_STATUS_IDLE, p.status().Status)
+	assert.Equal(t, prover.GetProofResponse_RESULT_CANCEL, getProof(p, res.Id).Result)
+
+	_, err = New(Config{ProofFailureRate: 1.5})
+	assert.Error(t, err)
+}
+
+// tokenAggregator is an aggregator service that returns the auth token of the connected prover
+type tokenAggregator struct {
+	prover.UnimplementedAggregatorServiceServer
+	tokens chan []string
+}
+
+func (a *tokenAggregator) Channel(stream prover.AggregatorService_ChannelServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	a.tokens <- md.Get(aggregator.ProverAuthMetadataKey)
+	<-stream.Context().Done()
+	return nil
+}
+
+func TestMockProverToken(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tokens := make(chan []string, 1)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	prover.RegisterAggregatorServiceServer(server, &tokenAggregator{tokens: tokens})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	mockProver, err := New(Config{Token: "secret", RetryInterval: time.Second})
+	require.NoError(t, err)
+	go func() { _ = mockProver.Run(ctx, listener.Addr().String()) }()
+
+	select {
+	case token := <-tokens:
+		assert.Equal(t, []string{"Bearer secret"}, token)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the mock prover didn't connect")
+	}
+
+	mockProver, err = New(Config{CAFile: "missing.pem"})
+	require.NoError(t, err)
+	_, err = mockProver.transportCredentials()
+	assert.Error(t, err)
+}
@@ -80,6 +80,20 @@ func (_m *ProverMock) BatchProof(input *prover.InputProver) (*string, error) {
 	return r0, r1
 }
 
+// CancelProofRequest provides a mock function with given fields: ctx, proofID
+func (_m *ProverMock) CancelProofRequest(ctx context.Context, proofID string) error {
+	ret := _m.Called(ctx, proofID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, proofID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FinalProof provides a mock function with given fields: inputProof, aggregatorAddr
 func (_m *ProverMock) FinalProof(inputProof string, aggregatorAddr string) (*string, error) {
 	ret := _m.Called(inputProof, aggregatorAddr)
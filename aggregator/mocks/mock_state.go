@@ -224,6 +224,32 @@ func (_m *StateMock) GetProofReadyToVerify(ctx context.Context, lastVerfiedBatch
 	return r0, r1
 }
 
+// GetProofs provides a mock function with given fields: ctx, dbTx
+func (_m *StateMock) GetProofs(ctx context.Context, dbTx pgx.Tx) ([]*state.Proof, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	var r0 []*state.Proof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) ([]*state.Proof, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) []*state.Proof); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*state.Proof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProofsToAggregate provides a mock function with given fields: ctx, dbTx
 func (_m *StateMock) GetProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (*state.Proof, *state.Proof, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/0xPolygon/cdk-validium-node/aggregator/metrics"
@@ -20,6 +21,7 @@ var (
 	ErrUnspecified          = errors.New("Prover returned an UNSPECIFIED response")  //nolint:revive
 	ErrUnknown              = errors.New("Prover returned an unknown response")      //nolint:revive
 	ErrProofCanceled        = errors.New("Proof has been canceled")                  //nolint:revive
+	ErrProverClosed         = errors.New("Prover connection has been closed")        //nolint:revive
 )
 
 // Prover abstraction of the grpc prover client.
@@ -29,6 +31,19 @@ type Prover struct {
 	address                   net.Addr
 	proofStatePollingInterval types.Duration
 	stream                    AggregatorService_ChannelServer
+
+	// pending is the response of a proof poll abandoned at its deadline, dropped by the next call
+	pending <-chan callResult
+	// closed is closed when a call is abandoned with its response pending, the stream can't be
+	// used anymore and must be closed
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// callResult is the response of a call to the prover
+type callResult struct {
+	res *ProverMessage
+	err error
 }
 
 // New returns a new Prover instance.
@@ -37,6 +52,7 @@ func New(stream AggregatorService_ChannelServer, addr net.Addr, proofStatePollin
 		stream:                    stream,
 		address:                   addr,
 		proofStatePollingInterval: proofStatePollingInterval,
+		closed:                    make(chan struct{}),
 	}
 	status, err := p.Status()
 	if err != nil {
@@ -61,6 +77,15 @@ func (p *Prover) Addr() string {
 	return p.address.String()
 }
 
+// Closed returns a channel closed when the prover stream can't be used anymore, the stream must
+// be closed by returning from the Channel handler.
+func (p *Prover) Closed() <-chan struct{} { return p.closed }
+
+// close marks the prover stream as unusable.
+func (p *Prover) close() {
+	p.closeOnce.Do(func() { close(p.closed) })
+}
+
 // Status gets the prover status.
 func (p *Prover) Status() (*GetStatusResponse, error) {
 	req := &AggregatorMessage{
@@ -68,7 +93,7 @@ func (p *Prover) Status() (*GetStatusResponse, error) {
 			GetStatusRequest: &GetStatusRequest{},
 		},
 	}
-	res, err := p.call(req)
+	res, err := p.call(context.Background(), req)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +135,7 @@ func (p *Prover) BatchProof(input *InputProver) (*string, error) {
 			GenBatchProofRequest: &GenBatchProofRequest{Input: input},
 		},
 	}
-	res, err := p.call(req)
+	res, err := p.call(context.Background(), req)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +171,7 @@ func (p *Prover) AggregatedProof(inputProof1, inputProof2 string) (*string, erro
 			},
 		},
 	}
-	res, err := p.call(req)
+	res, err := p.call(context.Background(), req)
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +211,7 @@ func (p *Prover) FinalProof(inputProof string, aggregatorAddr string) (*string,
 			},
 		},
 	}
-	res, err := p.call(req)
+	res, err := p.call(context.Background(), req)
 	if err != nil {
 		return nil, err
 	}
@@ -214,13 +239,13 @@ func (p *Prover) FinalProof(inputProof string, aggregatorAddr string) (*string,
 
 // CancelProofRequest asks the prover to stop the generation of the proof
 // matching the provided proofID.
-func (p *Prover) CancelProofRequest(proofID string) error {
+func (p *Prover) CancelProofRequest(ctx context.Context, proofID string) error {
 	req := &AggregatorMessage{
 		Request: &AggregatorMessage_CancelRequest{
 			CancelRequest: &CancelRequest{Id: proofID},
 		},
 	}
-	res, err := p.call(req)
+	res, err := p.call(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -286,14 +311,18 @@ func (p *Prover) waitProof(ctx context.Context, proofID string) (*GetProofRespon
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
-			res, err := p.call(req)
+			res, err := p.poll(ctx, req)
 			if err != nil {
 				return nil, err
 			}
 			if msg, ok := res.Response.(*ProverMessage_GetProofResponse); ok {
 				switch msg.GetProofResponse.Result {
 				case GetProofResponse_RESULT_PENDING:
-					time.Sleep(p.proofStatePollingInterval.Duration)
+					select {
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					case <-time.After(p.proofStatePollingInterval.Duration):
+					}
 					continue
 				case GetProofResponse_RESULT_UNSPECIFIED:
 					return nil, fmt.Errorf("failed to get proof ID: %s, %w, prover response: %s",
@@ -323,14 +352,66 @@ func (p *Prover) waitProof(ctx context.Context, proofID string) (*GetProofRespon
 }
 
 // call sends a message to the prover and waits to receive the response over
-// the connection stream.
-func (p *Prover) call(req *AggregatorMessage) (*ProverMessage, error) {
-	if err := p.stream.Send(req); err != nil {
+// the connection stream. If the context is done before the response is received,
+// the prover is closed, as the pending response would be received by the next call.
+func (p *Prover) call(ctx context.Context, req *AggregatorMessage) (*ProverMessage, error) {
+	results, err := p.send(ctx, req)
+	if err != nil {
 		return nil, err
 	}
-	res, err := p.stream.Recv()
+	select {
+	case r := <-results:
+		return r.res, r.err
+	case <-ctx.Done():
+		p.close()
+		return nil, ctx.Err()
+	}
+}
+
+// poll is like call, but if the context is done before the response is received the
+// response is left pending instead of closing the prover, so the proof can still be
+// canceled by the next call.
+func (p *Prover) poll(ctx context.Context, req *AggregatorMessage) (*ProverMessage, error) {
+	results, err := p.send(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	select {
+	case r := <-results:
+		return r.res, r.err
+	case <-ctx.Done():
+		p.pending = results
+		return nil, ctx.Err()
+	}
+}
+
+// send drops the pending response of an abandoned poll and sends a message to the prover,
+// returning the channel receiving its response. If the context is done before the pending
+// response is received, the prover is closed.
+func (p *Prover) send(ctx context.Context, req *AggregatorMessage) (<-chan callResult, error) {
+	select {
+	case <-p.closed:
+		return nil, ErrProverClosed
+	default:
+	}
+	if p.pending != nil {
+		select {
+		case <-p.pending:
+			p.pending = nil
+		case <-ctx.Done():
+			p.close()
+			return nil, ctx.Err()
+		}
+	}
+
+	results := make(chan callResult, 1)
+	go func() {
+		if err := p.stream.Send(req); err != nil {
+			results <- callResult{err: err}
+			return
+		}
+		res, err := p.stream.Recv()
+		results <- callResult{res: res, err: err}
+	}()
+	return results, nil
 }
@@ -0,0 +1,96 @@
+package prover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// testStream is a prover stream answering with the responses pushed by the test
+type testStream struct {
+	grpc.ServerStream
+	sent      chan *AggregatorMessage
+	responses chan *ProverMessage
+}
+
+func newTestStream() *testStream {
+	return &testStream{sent: make(chan *AggregatorMessage, 1), responses: make(chan *ProverMessage, 1)}
+}
+
+func (s *testStream) Send(msg *AggregatorMessage) error {
+	s.sent <- msg
+	return nil
+}
+
+func (s *testStream) Recv() (*ProverMessage, error) {
+	return <-s.responses, nil
+}
+
+func waitProofDeadline(t *testing.T, p *Prover) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.WaitRecursiveProof(ctx, "proof-1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the poll didn't return at its deadline")
+	}
+}
+
+func TestPollDeadlineCancel(t *testing.T) {
+	stream := newTestStream()
+	p := &Prover{stream: stream, closed: make(chan struct{})}
+
+	// The proof poll is abandoned at its deadline, the prover is still usable
+	waitProofDeadline(t, p)
+	require.Equal(t, "proof-1", (<-stream.sent).GetGetProofRequest().Id)
+	select {
+	case <-p.Closed():
+		t.Fatal("the prover was closed")
+	default:
+	}
+
+	// The cancellation is sent once the late response of the poll is received
+	done := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		done <- p.CancelProofRequest(ctx, "proof-1")
+	}()
+	stream.responses <- &ProverMessage{Response: &ProverMessage_GetProofResponse{
+		GetProofResponse: &GetProofResponse{Id: "proof-1", Result: GetProofResponse_RESULT_PENDING},
+	}}
+	require.Equal(t, "proof-1", (<-stream.sent).GetCancelRequest().Id)
+	stream.responses <- &ProverMessage{Response: &ProverMessage_CancelResponse{
+		CancelResponse: &CancelResponse{Result: Result_RESULT_OK},
+	}}
+	assert.NoError(t, <-done)
+}
+
+func TestPollDeadlineClose(t *testing.T) {
+	stream := newTestStream()
+	p := &Prover{stream: stream, closed: make(chan struct{})}
+	waitProofDeadline(t, p)
+	<-stream.sent
+
+	// The prover never answers the poll, it's closed when the cancellation times out
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.CancelProofRequest(ctx, "proof-1"), context.DeadlineExceeded)
+	select {
+	case <-p.Closed():
+	default:
+		t.Fatal("the prover wasn't closed")
+	}
+	_, err := p.IsIdle()
+	assert.ErrorIs(t, err, ErrProverClosed)
+}
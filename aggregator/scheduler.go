@@ -0,0 +1,215 @@
+package aggregator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// ProverTagFinal is the tag of the provers generating final proofs
+	ProverTagFinal = "final"
+	// ProverTagAggregation is the tag of the provers aggregating proofs
+	ProverTagAggregation = "aggregation"
+	// ProverTagBatch is the tag of the provers generating batch proofs
+	ProverTagBatch = "batch"
+)
+
+// scheduledProver is a prover connected to the aggregator and its scheduling policy
+type scheduledProver struct {
+	id          string
+	name        string
+	addr        string
+	connectedAt time.Time
+	tags        map[string]bool
+	weight      uint64
+	busy        bool
+	job         string
+	jobSince    time.Time
+	// backoffUntil is the time until the prover doesn't take work after failing a proof
+	backoffUntil time.Time
+}
+
+// takes returns true if the prover takes the given kind of work
+func (p *scheduledProver) takes(tag string) bool {
+	return len(p.tags) == 0 || p.tags[tag]
+}
+
+// backingOff returns true if the prover doesn't take work because it failed a proof
+func (p *scheduledProver) backingOff(now time.Time) bool {
+	return now.Before(p.backoffUntil)
+}
+
+// scheduler decides which work is assigned to each prover connected to the aggregator
+type scheduler struct {
+	cfg SchedulingConfig
+
+	mu          sync.Mutex
+	provers     map[proverInterface]*scheduledProver
+	batchProofs uint64
+}
+
+// newScheduler creates a scheduler, checking the tags of the policies
+func newScheduler(cfg SchedulingConfig) (*scheduler, error) {
+	for _, policy := range cfg.Provers {
+		for _, tag := range policy.Tags {
+			if tag != ProverTagFinal && tag != ProverTagAggregation && tag != ProverTagBatch {
+				return nil, fmt.Errorf("invalid tag %q in the scheduling policy of prover %q %q, valid tags are %q, %q and %q",
+					tag, policy.ID, policy.Name, ProverTagFinal, ProverTagAggregation, ProverTagBatch)
+			}
+		}
+	}
+	return &scheduler{
+		cfg:     cfg,
+		provers: make(map[proverInterface]*scheduledProver),
+	}, nil
+}
+
+// connect registers a prover with the first policy matching its id and name
+func (s *scheduler) connect(prover proverInterface) {
+	sp := &scheduledProver{id: prover.ID(), name: prover.Name(), addr: prover.Addr(), connectedAt: time.Now(), weight: 1}
+	for _, policy := range s.cfg.Provers {
+		if (policy.ID != "" && policy.ID != prover.ID()) || (policy.Name != "" && policy.Name != prover.Name()) {
+			continue
+		}
+		if len(policy.Tags) > 0 {
+			sp.tags = make(map[string]bool, len(policy.Tags))
+			for _, tag := range policy.Tags {
+				sp.tags[tag] = true
+			}
+		}
+		if policy.Weight > 0 {
+			sp.weight = policy.Weight
+		}
+		break
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provers[prover] = sp
+}
+
+// disconnect removes a prover
+func (s *scheduler) disconnect(prover proverInterface) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.provers, prover)
+}
+
+// setBusy sets if the prover is working, the idle provers are preferred for the work they take
+func (s *scheduler) setBusy(prover proverInterface, busy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sp, ok := s.provers[prover]; ok {
+		sp.busy = busy
+		if !busy {
+			sp.job = ""
+		}
+	}
+}
+
+// setJob sets the work the prover is doing, until it's idle again
+func (s *scheduler) setJob(prover proverInterface, job string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sp, ok := s.provers[prover]; ok {
+		sp.job = job
+		sp.jobSince = time.Now()
+	}
+}
+
+// backoff stops assigning work to the prover for the given time, so the work it failed is taken by
+// other provers
+func (s *scheduler) backoff(prover proverInterface, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sp, ok := s.provers[prover]; ok {
+		sp.backoffUntil = time.Now().Add(d)
+	}
+}
+
+// connectedProvers returns the provers connected to the aggregator, ordered by connection time
+func (s *scheduler) connectedProvers() []ProverInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sps := make([]*scheduledProver, 0, len(s.provers))
+	for _, sp := range s.provers {
+		sps = append(sps, sp)
+	}
+	sort.Slice(sps, func(i, j int) bool { return sps[i].connectedAt.Before(sps[j].connectedAt) })
+
+	now := time.Now()
+	provers := make([]ProverInfo, 0, len(sps))
+	for _, sp := range sps {
+		info := ProverInfo{
+			ID:          sp.id,
+			Name:        sp.name,
+			Addr:        sp.addr,
+			ConnectedAt: sp.connectedAt.Unix(),
+			Tags:        []string{},
+			Weight:      sp.weight,
+			Status:      ProverStatusIdle,
+			Job:         sp.job,
+		}
+		for tag := range sp.tags {
+			info.Tags = append(info.Tags, tag)
+		}
+		sort.Strings(info.Tags)
+		if sp.busy {
+			info.Status = ProverStatusBusy
+		}
+		if sp.backingOff(now) {
+			info.Status = ProverStatusBackoff
+		}
+		if sp.job != "" {
+			info.JobAge = now.Sub(sp.jobSince).Round(time.Second).String()
+		}
+		provers = append(provers, info)
+	}
+	return provers
+}
+
+// takes returns true if the prover must take the given kind of work. The final proofs and
+// aggregations are left to the idle provers with a higher weight, and the provers backing off
+// don't take any work
+func (s *scheduler) takes(prover proverInterface, tag string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, ok := s.provers[prover]
+	if !ok {
+		return true
+	}
+	now := time.Now()
+	if !sp.takes(tag) || sp.backingOff(now) {
+		return false
+	}
+	if tag == ProverTagBatch {
+		return true
+	}
+	for other, otherSp := range s.provers {
+		if other != prover && !otherSp.busy && !otherSp.backingOff(now) && otherSp.takes(tag) && otherSp.weight > sp.weight {
+			return false
+		}
+	}
+	return true
+}
+
+// startBatchProof reserves one of the batch proofs that can be generated at the same time,
+// returning false if all of them are being generated
+func (s *scheduler) startBatchProof() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg.MaxConcurrentBatchProofs > 0 && s.batchProofs >= s.cfg.MaxConcurrentBatchProofs {
+		return false
+	}
+	s.batchProofs++
+	return true
+}
+
+// endBatchProof releases a batch proof reserved by startBatchProof
+func (s *scheduler) endBatchProof() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batchProofs--
+}
@@ -0,0 +1,97 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchedulerTestProver(t *testing.T, id, name string) *mocks.ProverMock {
+	p := mocks.NewProverMock(t)
+	p.On("ID").Return(id).Maybe()
+	p.On("Name").Return(name).Maybe()
+	p.On("Addr").Return("").Maybe()
+	return p
+}
+
+func TestSchedulerPolicies(t *testing.T) {
+	s, err := newScheduler(SchedulingConfig{
+		Provers: []ProverPolicy{
+			{Name: "fast", Tags: []string{ProverTagFinal, ProverTagAggregation}, Weight: 10},
+			{ID: "slow-2", Tags: []string{ProverTagBatch}},
+		},
+	})
+	require.NoError(t, err)
+
+	fast := newSchedulerTestProver(t, "fast-1", "fast")
+	slow1 := newSchedulerTestProver(t, "slow-1", "slow")
+	slow2 := newSchedulerTestProver(t, "slow-2", "slow")
+	s.connect(fast)
+	s.connect(slow1)
+	s.connect(slow2)
+
+	// The fast prover is dedicated to final proofs and aggregations
+	assert.True(t, s.takes(fast, ProverTagFinal))
+	assert.True(t, s.takes(fast, ProverTagAggregation))
+	assert.False(t, s.takes(fast, ProverTagBatch))
+
+	// The provers without a policy take any work, but leave final proofs and aggregations to the
+	// fast prover while it's idle
+	assert.True(t, s.takes(slow1, ProverTagBatch))
+	assert.False(t, s.takes(slow1, ProverTagFinal))
+	assert.False(t, s.takes(slow1, ProverTagAggregation))
+	s.setBusy(fast, true)
+	assert.True(t, s.takes(slow1, ProverTagFinal))
+	assert.True(t, s.takes(slow1, ProverTagAggregation))
+
+	assert.True(t, s.takes(slow2, ProverTagBatch))
+	assert.False(t, s.takes(slow2, ProverTagFinal))
+
+	s.setBusy(fast, false)
+	s.disconnect(fast)
+	assert.True(t, s.takes(slow1, ProverTagFinal))
+
+	_, err = newScheduler(SchedulingConfig{Provers: []ProverPolicy{{Name: "fast", Tags: []string{"fast"}}}})
+	assert.Error(t, err)
+}
+
+func TestSchedulerMaxConcurrentBatchProofs(t *testing.T) {
+	s, err := newScheduler(SchedulingConfig{MaxConcurrentBatchProofs: 2})
+	require.NoError(t, err)
+
+	assert.True(t, s.startBatchProof())
+	assert.True(t, s.startBatchProof())
+	assert.False(t, s.startBatchProof())
+	s.endBatchProof()
+	assert.True(t, s.startBatchProof())
+
+	s, err = newScheduler(SchedulingConfig{})
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		assert.True(t, s.startBatchProof())
+	}
+}
+
+func TestSchedulerBackoff(t *testing.T) {
+	s, err := newScheduler(SchedulingConfig{Provers: []ProverPolicy{{Name: "fast", Weight: 10}}})
+	require.NoError(t, err)
+
+	fast := newSchedulerTestProver(t, "fast-1", "fast")
+	slow := newSchedulerTestProver(t, "slow-1", "slow")
+	s.connect(fast)
+	s.connect(slow)
+	assert.False(t, s.takes(slow, ProverTagFinal))
+
+	// The provers backing off don't take any work, and leave the final proofs to the other provers
+	s.backoff(fast, time.Hour)
+	assert.False(t, s.takes(fast, ProverTagBatch))
+	assert.False(t, s.takes(fast, ProverTagFinal))
+	assert.True(t, s.takes(slow, ProverTagFinal))
+
+	s.backoff(fast, 0)
+	assert.True(t, s.takes(fast, ProverTagFinal))
+	assert.False(t, s.takes(slow, ProverTagFinal))
+}
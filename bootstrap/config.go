@@ -0,0 +1,11 @@
+package bootstrap
+
+// Config represents the configuration of the check of the state against L1, done when bootstrapping
+// a node from a snapshot
+type Config struct {
+	// VerifyOnStart checks the state against L1 when the node starts, refusing to start if it doesn't match
+	VerifyOnStart bool `mapstructure:"VerifyOnStart"`
+	// RehashBatches is the number of batches, up to the last virtual batch, whose accumulated input hash
+	// is computed again from their data to check it against L1
+	RehashBatches uint64 `mapstructure:"RehashBatches"`
+}
@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// ethermanInterface contains the methods required to read the L1 data the state is checked against
+type ethermanInterface interface {
+	EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error)
+	GetBatchEvents(ctx context.Context, fromBlock, toBlock uint64) ([]etherman.BatchEvent, []etherman.BatchEvent, error)
+	GetLatestBatchNumber() (uint64, error)
+	GetLatestVerifiedBatchNum() (uint64, error)
+	GetSequencedBatchAccInputHash(batchNumber uint64) (common.Hash, error)
+	GetBatchStateRoot(batchNumber uint64) (common.Hash, error)
+}
+
+// stateInterface gathers the methods required to read the state being checked
+type stateInterface interface {
+	GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Block, error)
+	GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*state.VerifiedBatch, error)
+	GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error)
+	GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error)
+}
@@ -0,0 +1,275 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrMismatch is returned when the state doesn't match the data in L1
+var ErrMismatch = errors.New("the state doesn't match L1")
+
+// Report is the summary of a successful check of the state against L1
+type Report struct {
+	// LastBlock is the last L1 block synced in the state
+	LastBlock uint64
+	// LastVirtualBatch is the last virtual batch, whose accumulated input hash matches L1
+	LastVirtualBatch uint64
+	// RehashedBatches is the number of batches whose accumulated input hash was computed again from their data
+	RehashedBatches uint64
+	// LastVerifiedBatch is the last verified batch, whose state root matches L1
+	LastVerifiedBatch uint64
+}
+
+// Verifier checks a state, like one restored from a snapshot, against the data in L1, so the node
+// doesn't have to trust whoever published the snapshot. The last virtual and verified batches are
+// checked against the sequence and verification events up to the last block synced, which must have
+// been emitted in the blocks and txs the state recorded. The SequenceBatches events only carry the
+// batch number, so the accumulated input hash is checked against the one stored by the contract for
+// the sequence, like the state root of a consolidated verification
+type Verifier struct {
+	state    stateInterface
+	etherMan ethermanInterface
+	// batches is the number of batches before the last virtual batch whose accumulated input hash is computed again
+	batches uint64
+}
+
+// NewVerifier creates a Verifier. The accumulated input hashes of the last batches are computed again from
+// their data, which checks the data of those batches against the accumulated input hash in L1
+func NewVerifier(st stateInterface, etherMan ethermanInterface, batches uint64) *Verifier {
+	return &Verifier{state: st, etherMan: etherMan, batches: batches}
+}
+
+// Verify checks the state against L1, returning an error wrapping ErrMismatch if it doesn't match
+func (v *Verifier) Verify(ctx context.Context) (*Report, error) {
+	report := &Report{}
+	lastBlock, err := v.state.GetLastBlock(ctx, nil)
+	if errors.Is(err, state.ErrStateNotSynchronized) {
+		log.Info("the state is empty, there is nothing to check against L1")
+		return report, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get the last block synced: %w", err)
+	}
+	if err := v.verifyBlock(ctx, lastBlock); err != nil {
+		return nil, err
+	}
+	report.LastBlock = lastBlock.BlockNumber
+
+	if report.LastVirtualBatch, report.RehashedBatches, err = v.verifyVirtualBatches(ctx); err != nil {
+		return nil, err
+	}
+	if report.LastVerifiedBatch, err = v.verifyVerifiedBatch(ctx); err != nil {
+		return nil, err
+	}
+	if err := v.verifyEvents(ctx, report.LastBlock, report.LastVirtualBatch); err != nil {
+		return nil, err
+	}
+	log.Infof("the state matches L1. Last block: %d, last virtual batch: %d, rehashed batches: %d, last verified batch: %d",
+		report.LastBlock, report.LastVirtualBatch, report.RehashedBatches, report.LastVerifiedBatch)
+	return report, nil
+}
+
+// verifyBlock checks the last block synced is in the L1 chain
+func (v *Verifier) verifyBlock(ctx context.Context, lastBlock *state.Block) error {
+	l1Block, err := v.etherMan.EthBlockByNumber(ctx, lastBlock.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get the L1 block %d: %w", lastBlock.BlockNumber, err)
+	}
+	if l1Block.Hash() != lastBlock.BlockHash {
+		return fmt.Errorf("%w: the hash of the last block synced %d is %s, but it's %s in L1",
+			ErrMismatch, lastBlock.BlockNumber, lastBlock.BlockHash, l1Block.Hash())
+	}
+	return nil
+}
+
+// verifyVirtualBatches checks the accumulated input hash of the last virtual batch against L1, and the
+// data of the batches before it against their accumulated input hashes
+func (v *Verifier) verifyVirtualBatches(ctx context.Context) (uint64, uint64, error) {
+	lastVirtualBatchNum, err := v.state.GetLastVirtualBatchNum(ctx, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return 0, 0, fmt.Errorf("failed to get the last virtual batch: %w", err)
+	}
+	if lastVirtualBatchNum == 0 {
+		return 0, 0, nil
+	}
+	lastBatchSequenced, err := v.etherMan.GetLatestBatchNumber()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the last batch sequenced in L1: %w", err)
+	}
+	if lastVirtualBatchNum > lastBatchSequenced {
+		return 0, 0, fmt.Errorf("%w: the last virtual batch is %d, but the last batch sequenced in L1 is %d",
+			ErrMismatch, lastVirtualBatchNum, lastBatchSequenced)
+	}
+	lastVirtualBatch, err := v.state.GetBatchByNumber(ctx, lastVirtualBatchNum, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the virtual batch %d: %w", lastVirtualBatchNum, err)
+	}
+	if err := v.verifyAccInputHash(lastVirtualBatch); err != nil {
+		return 0, 0, err
+	}
+
+	if v.batches == 0 {
+		return lastVirtualBatchNum, 0, nil
+	}
+	fromBatchNum := uint64(1)
+	if v.batches < lastVirtualBatchNum {
+		fromBatchNum = lastVirtualBatchNum - v.batches + 1
+	}
+	previousBatch, err := v.state.GetBatchByNumber(ctx, fromBatchNum-1, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the batch %d: %w", fromBatchNum-1, err)
+	}
+	accInputHash := previousBatch.AccInputHash
+	for batchNum := fromBatchNum; batchNum <= lastVirtualBatchNum; batchNum++ {
+		batch := lastVirtualBatch
+		if batchNum != lastVirtualBatchNum {
+			if batch, err = v.state.GetBatchByNumber(ctx, batchNum, nil); err != nil {
+				return 0, 0, fmt.Errorf("failed to get the batch %d: %w", batchNum, err)
+			}
+		}
+		accInputHash = calculateAccInputHash(accInputHash, batch)
+		if accInputHash != batch.AccInputHash {
+			return 0, 0, fmt.Errorf("%w: the accumulated input hash of the data of batch %d is %s, but the stored one is %s",
+				ErrMismatch, batchNum, accInputHash, batch.AccInputHash)
+		}
+	}
+	return lastVirtualBatchNum, lastVirtualBatchNum - fromBatchNum + 1, nil
+}
+
+// verifyAccInputHash checks the accumulated input hash of the batch, which must be the last one of a sequence in L1
+func (v *Verifier) verifyAccInputHash(batch *state.Batch) error {
+	l1AccInputHash, err := v.etherMan.GetSequencedBatchAccInputHash(batch.BatchNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get the accumulated input hash of batch %d from L1: %w", batch.BatchNumber, err)
+	}
+	if l1AccInputHash == (common.Hash{}) {
+		return fmt.Errorf("%w: the batch %d isn't the last batch of a sequence in L1", ErrMismatch, batch.BatchNumber)
+	}
+	if l1AccInputHash != batch.AccInputHash {
+		return fmt.Errorf("%w: the accumulated input hash of batch %d is %s, but it's %s in L1",
+			ErrMismatch, batch.BatchNumber, batch.AccInputHash, l1AccInputHash)
+	}
+	return nil
+}
+
+// verifyVerifiedBatch checks the state root of the last verified batch against L1
+func (v *Verifier) verifyVerifiedBatch(ctx context.Context) (uint64, error) {
+	lastVerifiedBatch, err := v.state.GetLastVerifiedBatch(ctx, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to get the last verified batch: %w", err)
+	}
+	l1LastVerifiedBatchNum, err := v.etherMan.GetLatestVerifiedBatchNum()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the last batch verified in L1: %w", err)
+	}
+	if lastVerifiedBatch.BatchNumber > l1LastVerifiedBatchNum {
+		return 0, fmt.Errorf("%w: the last verified batch is %d, but the last batch verified in L1 is %d",
+			ErrMismatch, lastVerifiedBatch.BatchNumber, l1LastVerifiedBatchNum)
+	}
+	batch, err := v.state.GetBatchByNumber(ctx, lastVerifiedBatch.BatchNumber, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the verified batch %d: %w", lastVerifiedBatch.BatchNumber, err)
+	}
+	l1StateRoot, err := v.etherMan.GetBatchStateRoot(lastVerifiedBatch.BatchNumber)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the state root of batch %d from L1: %w", lastVerifiedBatch.BatchNumber, err)
+	}
+	if l1StateRoot == (common.Hash{}) {
+		// The batch was verified by a non trusted aggregator and its pending state isn't consolidated yet
+		log.Warnf("the state root of the verified batch %d isn't consolidated in L1 yet, it can't be checked", lastVerifiedBatch.BatchNumber)
+	} else if l1StateRoot != batch.StateRoot {
+		return 0, fmt.Errorf("%w: the state root of the verified batch %d is %s, but it's %s in L1",
+			ErrMismatch, lastVerifiedBatch.BatchNumber, batch.StateRoot, l1StateRoot)
+	}
+	return lastVerifiedBatch.BatchNumber, nil
+}
+
+// verifyEvents checks the last virtual and verified batches against the last sequence and verification
+// events up to the last block synced. An event missing from the state, or recorded in another block
+// or tx, means the state wasn't synced from L1 over the block range it claims
+func (v *Verifier) verifyEvents(ctx context.Context, lastBlockNum, lastVirtualBatchNum uint64) error {
+	if lastVirtualBatchNum == 0 {
+		return nil
+	}
+	virtualBatch, err := v.state.GetVirtualBatch(ctx, lastVirtualBatchNum, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the virtual batch %d: %w", lastVirtualBatchNum, err)
+	}
+	fromBlock := virtualBatch.BlockNumber
+	lastVerifiedBatch, err := v.state.GetLastVerifiedBatch(ctx, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		lastVerifiedBatch = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get the last verified batch: %w", err)
+	} else if lastVerifiedBatch.BlockNumber < fromBlock {
+		fromBlock = lastVerifiedBatch.BlockNumber
+	}
+	if fromBlock > lastBlockNum {
+		return fmt.Errorf("%w: the last virtual or verified batch was synced in block %d, after the last block synced %d",
+			ErrMismatch, fromBlock, lastBlockNum)
+	}
+
+	sequenced, verified, err := v.etherMan.GetBatchEvents(ctx, fromBlock, lastBlockNum)
+	if err != nil {
+		return fmt.Errorf("failed to get the batch events of the blocks %d-%d from L1: %w", fromBlock, lastBlockNum, err)
+	}
+	if len(sequenced) == 0 {
+		return fmt.Errorf("%w: the virtual batch %d was sequenced in block %d, but there is no sequence in L1 in the blocks %d-%d",
+			ErrMismatch, lastVirtualBatchNum, virtualBatch.BlockNumber, fromBlock, lastBlockNum)
+	}
+	lastSequenced := sequenced[len(sequenced)-1]
+	if lastSequenced.BatchNumber != lastVirtualBatchNum || lastSequenced.BlockNumber != virtualBatch.BlockNumber || lastSequenced.TxHash != virtualBatch.TxHash {
+		return fmt.Errorf("%w: the last virtual batch is %d, sequenced in block %d by tx %s, but up to block %d it's %d in L1, sequenced in block %d by tx %s",
+			ErrMismatch, lastVirtualBatchNum, virtualBatch.BlockNumber, virtualBatch.TxHash,
+			lastBlockNum, lastSequenced.BatchNumber, lastSequenced.BlockNumber, lastSequenced.TxHash)
+	}
+
+	if lastVerifiedBatch == nil {
+		if len(verified) > 0 {
+			lastVerified := verified[len(verified)-1]
+			return fmt.Errorf("%w: there is no verified batch, but the batch %d was verified in L1 in block %d",
+				ErrMismatch, lastVerified.BatchNumber, lastVerified.BlockNumber)
+		}
+		return nil
+	}
+	if len(verified) == 0 {
+		return fmt.Errorf("%w: the batch %d was verified in block %d, but there is no verification in L1 in the blocks %d-%d",
+			ErrMismatch, lastVerifiedBatch.BatchNumber, lastVerifiedBatch.BlockNumber, fromBlock, lastBlockNum)
+	}
+	lastVerified := verified[len(verified)-1]
+	if lastVerified.BatchNumber != lastVerifiedBatch.BatchNumber || lastVerified.BlockNumber != lastVerifiedBatch.BlockNumber {
+		return fmt.Errorf("%w: the last verified batch is %d, verified in block %d, but up to block %d it's %d in L1, verified in block %d",
+			ErrMismatch, lastVerifiedBatch.BatchNumber, lastVerifiedBatch.BlockNumber, lastBlockNum, lastVerified.BatchNumber, lastVerified.BlockNumber)
+	}
+	batch, err := v.state.GetBatchByNumber(ctx, lastVerifiedBatch.BatchNumber, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the verified batch %d: %w", lastVerifiedBatch.BatchNumber, err)
+	}
+	if lastVerified.StateRoot != batch.StateRoot {
+		return fmt.Errorf("%w: the state root of the verified batch %d is %s, but it's %s in its verification event",
+			ErrMismatch, batch.BatchNumber, batch.StateRoot, lastVerified.StateRoot)
+	}
+	return nil
+}
+
+// calculateAccInputHash computes the accumulated input hash of the batch the same way the smart contract
+// does when the batch is sequenced
+func calculateAccInputHash(oldAccInputHash common.Hash, batch *state.Batch) common.Hash {
+	timestamp := make([]byte, 8) //nolint:gomnd
+	binary.BigEndian.PutUint64(timestamp, uint64(batch.Timestamp.Unix()))
+	return crypto.Keccak256Hash(
+		oldAccInputHash.Bytes(),
+		crypto.Keccak256(batch.BatchL2Data),
+		batch.GlobalExitRoot.Bytes(),
+		timestamp,
+		batch.Coinbase.Bytes(),
+	)
+}
@@ -0,0 +1,187 @@
+package bootstrap
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lastBatchNum     = uint64(5)
+	verifiedBatchNum = uint64(4)
+	l1BlockNum       = uint64(100)
+	sequenceBlockNum = uint64(90)
+	verifyBlockNum   = uint64(95)
+)
+
+// newTestBatches returns the batches up to lastBatchNum with their accumulated input hashes chained
+func newTestBatches() []*state.Batch {
+	batches := []*state.Batch{{BatchNumber: 0}}
+	for i := uint64(1); i <= lastBatchNum; i++ {
+		batch := &state.Batch{
+			BatchNumber:    i,
+			Coinbase:       common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D"),
+			BatchL2Data:    []byte{byte(i), 0xee},
+			GlobalExitRoot: common.BigToHash(big.NewInt(int64(i))),
+			Timestamp:      time.Unix(int64(1690000000+i), 0),
+			StateRoot:      common.BigToHash(big.NewInt(int64(1000 + i))),
+		}
+		batch.AccInputHash = calculateAccInputHash(batches[i-1].AccInputHash, batch)
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+type verifierTestCase struct {
+	name          string
+	batches       uint64
+	setup         func(batches []*state.Batch, lastBlock *state.Block)
+	setupEvents   func(sequenced, verified *[]etherman.BatchEvent)
+	expectedError bool
+}
+
+func TestVerify(t *testing.T) {
+	testCases := []verifierTestCase{
+		{
+			name:    "the state matches L1",
+			batches: 3,
+		},
+		{
+			name:    "all the batches are rehashed",
+			batches: 1000,
+		},
+		{
+			name: "the last block synced isn't in L1",
+			setup: func(_ []*state.Batch, lastBlock *state.Block) {
+				lastBlock.BlockHash = common.HexToHash("0xdead")
+			},
+			expectedError: true,
+		},
+		{
+			name: "the accumulated input hash of the last virtual batch doesn't match L1",
+			setup: func(batches []*state.Batch, _ *state.Block) {
+				batches[lastBatchNum].AccInputHash = common.HexToHash("0xdead")
+			},
+			expectedError: true,
+		},
+		{
+			name:    "the data of a batch was tampered",
+			batches: 3,
+			setup: func(batches []*state.Batch, _ *state.Block) {
+				batches[lastBatchNum-1].BatchL2Data = []byte{0xbe, 0xef}
+			},
+			expectedError: true,
+		},
+		{
+			name: "the state root of the last verified batch doesn't match L1",
+			setup: func(batches []*state.Batch, _ *state.Block) {
+				batches[verifiedBatchNum].StateRoot = common.HexToHash("0xdead")
+			},
+			expectedError: true,
+		},
+		{
+			name: "a sequence up to the last block synced is missing from the state",
+			setupEvents: func(sequenced, _ *[]etherman.BatchEvent) {
+				*sequenced = append(*sequenced, etherman.BatchEvent{BlockNumber: l1BlockNum, BatchNumber: lastBatchNum + 1})
+			},
+			expectedError: true,
+		},
+		{
+			name: "the last virtual batch was sequenced in another block",
+			setupEvents: func(sequenced, _ *[]etherman.BatchEvent) {
+				(*sequenced)[0].BlockNumber = sequenceBlockNum + 1
+			},
+			expectedError: true,
+		},
+		{
+			name: "the last verified batch has no verification event",
+			setupEvents: func(_, verified *[]etherman.BatchEvent) {
+				*verified = nil
+			},
+			expectedError: true,
+		},
+		{
+			name: "the state root of the last verified batch doesn't match its verification event",
+			setupEvents: func(_, verified *[]etherman.BatchEvent) {
+				(*verified)[0].StateRoot = common.HexToHash("0xdead")
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := newStateMock(t)
+			e := newEthermanMock(t)
+
+			batches := newTestBatches()
+			l1Block := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: new(big.Int).SetUint64(l1BlockNum), Difficulty: big.NewInt(0)})
+			lastBlock := &state.Block{BlockNumber: l1BlockNum, BlockHash: l1Block.Hash()}
+			l1AccInputHash := batches[lastBatchNum].AccInputHash
+			l1StateRoot := batches[verifiedBatchNum].StateRoot
+			if tc.setup != nil {
+				tc.setup(batches, lastBlock)
+			}
+			sequenceTxHash := common.HexToHash("0x5e9")
+			sequenced := []etherman.BatchEvent{{BlockNumber: sequenceBlockNum, TxHash: sequenceTxHash, BatchNumber: lastBatchNum}}
+			verified := []etherman.BatchEvent{{BlockNumber: verifyBlockNum, BatchNumber: verifiedBatchNum, StateRoot: l1StateRoot}}
+			if tc.setupEvents != nil {
+				tc.setupEvents(&sequenced, &verified)
+			}
+
+			m.On("GetLastBlock", ctx, nil).Return(lastBlock, nil)
+			e.On("EthBlockByNumber", ctx, l1BlockNum).Return(l1Block, nil)
+			m.On("GetLastVirtualBatchNum", ctx, nil).Return(lastBatchNum, nil).Maybe()
+			m.On("GetBatchByNumber", ctx, mock.AnythingOfType("uint64"), nil).Return(
+				func(_ context.Context, batchNumber uint64, _ pgx.Tx) *state.Batch { return batches[batchNumber] },
+				nil,
+			).Maybe()
+			m.On("GetLastVerifiedBatch", ctx, nil).Return(&state.VerifiedBatch{BatchNumber: verifiedBatchNum, BlockNumber: verifyBlockNum}, nil).Maybe()
+			m.On("GetVirtualBatch", ctx, lastBatchNum, nil).Return(&state.VirtualBatch{BatchNumber: lastBatchNum, BlockNumber: sequenceBlockNum, TxHash: sequenceTxHash}, nil).Maybe()
+			e.On("GetBatchEvents", ctx, sequenceBlockNum, l1BlockNum).Return(sequenced, verified, nil).Maybe()
+			e.On("GetLatestBatchNumber").Return(lastBatchNum, nil).Maybe()
+			e.On("GetSequencedBatchAccInputHash", lastBatchNum).Return(l1AccInputHash, nil).Maybe()
+			e.On("GetLatestVerifiedBatchNum").Return(verifiedBatchNum, nil).Maybe()
+			e.On("GetBatchStateRoot", verifiedBatchNum).Return(l1StateRoot, nil).Maybe()
+
+			report, err := NewVerifier(m, e, tc.batches).Verify(ctx)
+			if tc.expectedError {
+				assert.ErrorIs(t, err, ErrMismatch)
+				return
+			}
+			require.NoError(t, err)
+			rehashed := tc.batches
+			if rehashed > lastBatchNum {
+				rehashed = lastBatchNum
+			}
+			assert.Equal(t, &Report{
+				LastBlock:         l1BlockNum,
+				LastVirtualBatch:  lastBatchNum,
+				RehashedBatches:   rehashed,
+				LastVerifiedBatch: verifiedBatchNum,
+			}, report)
+		})
+	}
+}
+
+func TestVerifyEmptyState(t *testing.T) {
+	ctx := context.Background()
+	m := newStateMock(t)
+	e := newEthermanMock(t)
+	m.On("GetLastBlock", ctx, nil).Return(nil, state.ErrStateNotSynchronized)
+
+	report, err := NewVerifier(m, e, 10).Verify(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{}, report)
+}
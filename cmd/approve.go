@@ -56,6 +56,7 @@ func approveTokens(ctx *cli.Context) error {
 		log.Fatal(err)
 		return err
 	}
+	defer etherman.Close()
 
 	// load auth from keystore file
 	auth, _, err := etherman.LoadAuthFromKeyStore(addrKeyStorePath, addrPassword)
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-validium-node/bootstrap"
+	"github.com/0xPolygon/cdk-validium-node/config"
+	"github.com/0xPolygon/cdk-validium-node/db"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/urfave/cli/v2"
+)
+
+// bootstrapPendingTable marks a state DB whose bootstrap didn't complete. It is kept out of the state
+// schema, which is dropped when restoring the snapshot, so it marks the DB from before the restore until
+// the restored state is checked against L1
+const bootstrapPendingTable = "public.bootstrap_pending"
+
+// errBootstrapPending is returned when the state DB is from a bootstrap that failed or didn't finish
+var errBootstrapPending = errors.New("the state DB is from a bootstrap that didn't complete, run the bootstrap command again")
+
+const bootstrapRehashBatchesFlag = "rehash-batches"
+
+var bootstrapFlags = append(restoreFlags,
+	&networkFlag,
+	&customNetworkFlag,
+	&cli.Uint64Flag{
+		Name:  bootstrapRehashBatchesFlag,
+		Usage: "Number of batches whose accumulated input hash is computed again from their data, Bootstrap.RehashBatches if not set",
+	},
+)
+
+// bootstrapFromSnapshot restores the snapshot of the state and hash DBs and checks the restored state
+// against L1, so the node doesn't have to trust whoever published the snapshot
+func bootstrapFromSnapshot(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx, true)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	stateSqlDB, err := db.NewSQLDB(c.StateDB)
+	if err != nil {
+		return err
+	}
+	defer stateSqlDB.Close()
+	// The node refuses to start until the restored state matches L1, so a failed bootstrap doesn't
+	// leave a DB the node runs with
+	if err := setBootstrapPending(cliCtx.Context, stateSqlDB); err != nil {
+		return err
+	}
+
+	if err := restore(cliCtx); err != nil {
+		return err
+	}
+	runStateMigrations(c.StateDB)
+
+	etherman, err := newEtherman(*c)
+	if err != nil {
+		return err
+	}
+	defer etherman.Close()
+
+	rehashBatches := c.Bootstrap.RehashBatches
+	if cliCtx.IsSet(bootstrapRehashBatchesFlag) {
+		rehashBatches = cliCtx.Uint64(bootstrapRehashBatchesFlag)
+	}
+	log.Info("Checking the restored state against L1, please wait...")
+	report, err := bootstrap.NewVerifier(state.NewPostgresStorage(stateSqlDB), etherman, rehashBatches).Verify(cliCtx.Context)
+	if err != nil {
+		return fmt.Errorf("the restored snapshot can't be used, don't start the node with it: %w", err)
+	}
+	if err := clearBootstrapPending(cliCtx.Context, stateSqlDB); err != nil {
+		return err
+	}
+	log.Infof("Restored snapshot matches L1 up to block %d, virtual batch %d and verified batch %d. The node can be started",
+		report.LastBlock, report.LastVirtualBatch, report.LastVerifiedBatch)
+	return nil
+}
+
+// setBootstrapPending marks the state DB as being bootstrapped
+func setBootstrapPending(ctx context.Context, stateSqlDB *pgxpool.Pool) error {
+	const createSQL = "CREATE TABLE IF NOT EXISTS " + bootstrapPendingTable + " (started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW())"
+	const insertSQL = "INSERT INTO " + bootstrapPendingTable + " DEFAULT VALUES"
+	if _, err := stateSqlDB.Exec(ctx, createSQL); err != nil {
+		return fmt.Errorf("failed to mark the state DB as being bootstrapped: %w", err)
+	}
+	if _, err := stateSqlDB.Exec(ctx, insertSQL); err != nil {
+		return fmt.Errorf("failed to mark the state DB as being bootstrapped: %w", err)
+	}
+	return nil
+}
+
+// clearBootstrapPending marks the bootstrap of the state DB as completed
+func clearBootstrapPending(ctx context.Context, stateSqlDB *pgxpool.Pool) error {
+	const dropSQL = "DROP TABLE IF EXISTS " + bootstrapPendingTable
+	if _, err := stateSqlDB.Exec(ctx, dropSQL); err != nil {
+		return fmt.Errorf("failed to mark the bootstrap of the state DB as completed: %w", err)
+	}
+	return nil
+}
+
+// checkBootstrapCompleted returns errBootstrapPending if the state DB is from a bootstrap that failed
+// or didn't finish
+func checkBootstrapCompleted(ctx context.Context, stateSqlDB *pgxpool.Pool) error {
+	const checkSQL = "SELECT to_regclass($1) IS NOT NULL"
+	var pending bool
+	if err := stateSqlDB.QueryRow(ctx, checkSQL, bootstrapPendingTable).Scan(&pending); err != nil {
+		return fmt.Errorf("failed to check the bootstrap of the state DB: %w", err)
+	}
+	if pending {
+		return errBootstrapPending
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/cdk-validium-node/config"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/dacstub"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/local"
+	"github.com/0xPolygon/cdk-validium-node/db"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	dacStubFlagHost          = "host"
+	dacStubFlagPort          = "port"
+	dacStubFlagSequencerAddr = "sequencer-address"
+)
+
+var dacStubFlags = []cli.Flag{
+	&configFileFlag,
+	&cli.StringFlag{
+		Name:     config.FlagKeyStorePath,
+		Usage:    "the path of the key store file, as created by the encryptKey command, containing the private key of the committee member",
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     config.FlagPassword,
+		Aliases:  []string{"pw"},
+		Usage:    "the password do decrypt the key store file",
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:  dacStubFlagHost,
+		Usage: "Host to listen for JSON-RPC requests",
+		Value: "0.0.0.0",
+	},
+	&cli.IntFlag{
+		Name:  dacStubFlagPort,
+		Usage: "Port to listen for JSON-RPC requests",
+		Value: 8444, //nolint:gomnd
+	},
+	&cli.StringFlag{
+		Name:  dacStubFlagSequencerAddr,
+		Usage: "Address of the trusted sequencer. If set, only the sequences signed by it are accepted",
+	},
+}
+
+// dacStub runs a data committee member that signs every sequence it receives and stores
+// the data in the state DB, so validium flows can be tested without external DAC nodes
+func dacStub(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx, false)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	keystoreEncrypted, err := os.ReadFile(filepath.Clean(cliCtx.String(config.FlagKeyStorePath)))
+	if err != nil {
+		return err
+	}
+	key, err := keystore.DecryptKey(keystoreEncrypted, cliCtx.String(config.FlagPassword))
+	if err != nil {
+		return err
+	}
+
+	runStateMigrations(c.StateDB)
+	stateSqlDB, err := db.NewSQLDB(c.StateDB)
+	if err != nil {
+		return err
+	}
+	storage, err := local.NewStorage(dataavailability.LocalConfig{Storage: dataavailability.LocalStoragePostgres}, stateSqlDB)
+	if err != nil {
+		return err
+	}
+
+	var sequencerAddr common.Address
+	if addr := cliCtx.String(dacStubFlagSequencerAddr); addr != "" {
+		sequencerAddr = common.HexToAddress(addr)
+	}
+
+	rpcCfg := c.RPC
+	rpcCfg.Host = cliCtx.String(dacStubFlagHost)
+	rpcCfg.Port = cliCtx.Int(dacStubFlagPort)
+	rpcCfg.WebSockets.Enabled = false
+
+	server := jsonrpc.NewServer(rpcCfg, 0, nil, nil, nil, dacstub.Services(storage, key.PrivateKey, sequencerAddr))
+	log.Infof("running DAC stub with address %s", key.Address.Hex())
+	go func() {
+		if err := server.Start(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	waitSignal([]context.CancelFunc{func() {
+		if err := server.Stop(); err != nil {
+			log.Error(err)
+		}
+		stateSqlDB.Close()
+	}})
+	return nil
+}
@@ -186,6 +186,41 @@ func main() {
 			Action:  restore,
 			Flags:   restoreFlags,
 		},
+		{
+			Name:    "bootstrap",
+			Aliases: []string{},
+			Usage:   "Restore snapshot of the state db and check it against L1",
+			Action:  bootstrapFromSnapshot,
+			Flags:   bootstrapFlags,
+		},
+		{
+			Name:    "dac-stub",
+			Aliases: []string{},
+			Usage:   "Run a data availability committee member that signs every sequence, for local devnets and tests",
+			Action:  dacStub,
+			Flags:   dacStubFlags,
+		},
+		{
+			Name:    "mock-prover",
+			Aliases: []string{},
+			Usage:   "Run a prover that answers the aggregator with fake proofs, for local devnets and tests",
+			Action:  mockProver,
+			Flags:   mockProverFlags,
+		},
+		{
+			Name:    "verify-da",
+			Aliases: []string{},
+			Usage:   "Checks that every data committee member holds the data of the virtual batches",
+			Action:  verifyDA,
+			Flags:   verifyDAFlags,
+		},
+		{
+			Name:    "trusted-reorgs",
+			Aliases: []string{},
+			Usage:   "Reports the divergences detected in the trusted state and the transactions they reorged",
+			Action:  trustedReorgs,
+			Flags:   trustedReorgsFlags,
+		},
 		&policyCommands,
 	}
 
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/aggregator/mockprover"
+	"github.com/0xPolygon/cdk-validium-node/config"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	mockProverFlagAggregatorURL        = "aggregator-url"
+	mockProverFlagName                 = "name"
+	mockProverFlagID                   = "id"
+	mockProverFlagForkID               = "fork-id"
+	mockProverFlagBatchProofDelay      = "batch-proof-delay"
+	mockProverFlagAggregatedProofDelay = "aggregated-proof-delay"
+	mockProverFlagFinalProofDelay      = "final-proof-delay"
+	mockProverFlagRequestFailureRate   = "request-failure-rate"
+	mockProverFlagProofFailureRate     = "proof-failure-rate"
+	mockProverFlagSeed                 = "seed"
+	mockProverFlagTLSCA                = "tls-ca"
+	mockProverFlagTLSCert              = "tls-cert"
+	mockProverFlagTLSKey               = "tls-key"
+	mockProverFlagToken                = "token"
+)
+
+var mockProverFlags = []cli.Flag{
+	&configFileFlag,
+	&cli.StringFlag{
+		Name:  mockProverFlagAggregatorURL,
+		Usage: "Address of the aggregator gRPC server, 127.0.0.1 and the Aggregator.Port of the config file if not set",
+	},
+	&cli.StringFlag{
+		Name:  mockProverFlagName,
+		Usage: "Prover name reported to the aggregator",
+		Value: "mock-prover",
+	},
+	&cli.StringFlag{
+		Name:  mockProverFlagID,
+		Usage: "Prover id reported to the aggregator",
+		Value: "mock-prover-1",
+	},
+	&cli.Uint64Flag{
+		Name:     mockProverFlagForkID,
+		Usage:    "Fork id the prover supports, it must be the current fork id of the network",
+		Required: true,
+	},
+	&cli.DurationFlag{
+		Name:  mockProverFlagBatchProofDelay,
+		Usage: "Time it takes to generate a batch proof",
+		Value: 5 * time.Second, //nolint:gomnd
+	},
+	&cli.DurationFlag{
+		Name:  mockProverFlagAggregatedProofDelay,
+		Usage: "Time it takes to aggregate two proofs",
+		Value: 5 * time.Second, //nolint:gomnd
+	},
+	&cli.DurationFlag{
+		Name:  mockProverFlagFinalProofDelay,
+		Usage: "Time it takes to generate a final proof",
+		Value: 10 * time.Second, //nolint:gomnd
+	},
+	&cli.Float64Flag{
+		Name:  mockProverFlagRequestFailureRate,
+		Usage: "Probability, between 0 and 1, of rejecting a proof request with an internal error",
+	},
+	&cli.Float64Flag{
+		Name:  mockProverFlagProofFailureRate,
+		Usage: "Probability, between 0 and 1, of a proof being completed with an error",
+	},
+	&cli.Int64Flag{
+		Name:  mockProverFlagSeed,
+		Usage: "Seed of the failures, the same seed fails the same requests",
+	},
+	&cli.StringFlag{
+		Name:  mockProverFlagTLSCA,
+		Usage: "PEM bundle of the CAs of the aggregator, the connection uses TLS when set",
+	},
+	&cli.StringFlag{
+		Name:  mockProverFlagTLSCert,
+		Usage: "PEM client certificate presented to an aggregator requiring mutual TLS",
+	},
+	&cli.StringFlag{
+		Name:  mockProverFlagTLSKey,
+		Usage: "PEM private key of the client certificate",
+	},
+	&cli.StringFlag{
+		Name:  mockProverFlagToken,
+		Usage: "Auth token sent to the aggregator, the Aggregator.ProverAuth.Token of the config file if not set",
+	},
+}
+
+// mockProver runs a prover client that answers the aggregator with fake proofs, so the proof
+// locking, cleanup and final proof sending can be tested without real provers. The final proofs
+// are only accepted by L1 if the rollup verifier is the MockVerifier contract
+func mockProver(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx, false)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	aggregatorURL := cliCtx.String(mockProverFlagAggregatorURL)
+	if aggregatorURL == "" {
+		aggregatorURL = fmt.Sprintf("127.0.0.1:%d", c.Aggregator.Port)
+	}
+	token := cliCtx.String(mockProverFlagToken)
+	if token == "" {
+		token = c.Aggregator.ProverAuth.Token
+	}
+	p, err := mockprover.New(mockprover.Config{
+		Name:                 cliCtx.String(mockProverFlagName),
+		ID:                   cliCtx.String(mockProverFlagID),
+		ForkID:               cliCtx.Uint64(mockProverFlagForkID),
+		BatchProofDelay:      cliCtx.Duration(mockProverFlagBatchProofDelay),
+		AggregatedProofDelay: cliCtx.Duration(mockProverFlagAggregatedProofDelay),
+		FinalProofDelay:      cliCtx.Duration(mockProverFlagFinalProofDelay),
+		RequestFailureRate:   cliCtx.Float64(mockProverFlagRequestFailureRate),
+		ProofFailureRate:     cliCtx.Float64(mockProverFlagProofFailureRate),
+		Seed:                 cliCtx.Int64(mockProverFlagSeed),
+		RetryInterval:        c.Aggregator.RetryTime.Duration,
+		CAFile:               cliCtx.String(mockProverFlagTLSCA),
+		CertFile:             cliCtx.String(mockProverFlagTLSCert),
+		KeyFile:              cliCtx.String(mockProverFlagTLSKey),
+		Token:                token,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(cliCtx.Context)
+	go func() {
+		if err := p.Run(ctx, aggregatorURL); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	waitSignal([]context.CancelFunc{cancel})
+	return nil
+}
@@ -82,7 +82,7 @@ func restore(ctx *cli.Context) error {
 	if restoreExec.Error != nil {
 		log.Error("error restoring stateDB snapshot. Error: ", restoreExec.Error.Err)
 		log.Debug("restoreExec.Output: ", restoreExec.Output)
-		return err
+		return restoreExec.Error.Err
 	}
 	log.Info("Restore stateDB snapshot success")
 
@@ -122,7 +122,7 @@ func restore(ctx *cli.Context) error {
 	if restoreExec.Error != nil {
 		log.Error("error restoring hashDB snapshot. Error: ", restoreExec.Error.Err)
 		log.Debug("restoreExec.Output: ", restoreExec.Output)
-		return err
+		return restoreExec.Error.Err
 	}
 
 	log.Info("Restore HashDB snapshot success")
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"net"
@@ -12,10 +13,13 @@ import (
 	"runtime"
 	"time"
 
-	dataCommitteeClient "github.com/0xPolygon/cdk-data-availability/client"
 	zkevm "github.com/0xPolygon/cdk-validium-node"
 	"github.com/0xPolygon/cdk-validium-node/aggregator"
+	"github.com/0xPolygon/cdk-validium-node/bootstrap"
 	"github.com/0xPolygon/cdk-validium-node/config"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/datacommittee"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/local"
 	"github.com/0xPolygon/cdk-validium-node/db"
 	"github.com/0xPolygon/cdk-validium-node/etherman"
 	"github.com/0xPolygon/cdk-validium-node/ethtxmanager"
@@ -35,6 +39,7 @@ import (
 	"github.com/0xPolygon/cdk-validium-node/state"
 	"github.com/0xPolygon/cdk-validium-node/state/runtime/executor"
 	"github.com/0xPolygon/cdk-validium-node/synchronizer"
+	"github.com/0xPolygon/cdk-validium-node/synchronizer/l1replay"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
@@ -59,6 +64,15 @@ func start(cliCtx *cli.Context) error {
 	}
 	components := cliCtx.StringSlice(config.FlagComponents)
 
+	// Core State DB
+	stateSqlDB, err := db.NewSQLDB(c.StateDB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := checkBootstrapCompleted(cliCtx.Context, stateSqlDB); err != nil {
+		log.Fatal("refusing to start: ", err)
+	}
+
 	// Only runs migration if the component is the synchronizer and if the flag is deactivated
 	if !cliCtx.Bool(config.FlagMigrations) {
 		for _, comp := range components {
@@ -98,25 +112,26 @@ func start(cliCtx *cli.Context) error {
 	}
 	eventLog = event.NewEventLog(c.EventLog, eventStorage)
 
-	// Core State DB
-	stateSqlDB, err := db.NewSQLDB(c.StateDB)
+	etherman, l1, err := newL1(*c, components)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	etherman, err := newEtherman(*c)
-	if err != nil {
-		log.Fatal(err)
+	if c.Bootstrap.VerifyOnStart {
+		verifier := bootstrap.NewVerifier(state.NewPostgresStorage(stateSqlDB), etherman, c.Bootstrap.RehashBatches)
+		if _, err := verifier.Verify(cliCtx.Context); err != nil {
+			log.Fatal("refusing to start, the state can't be checked against L1. Error: ", err)
+		}
 	}
 
 	// READ CHAIN ID FROM POE SC
-	l2ChainID, err := etherman.GetL2ChainID()
+	l2ChainID, err := l1.GetL2ChainID()
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	st := newState(cliCtx.Context, c, l2ChainID, []state.ForkIDInterval{}, stateSqlDB, eventLog, needsExecutor, needsStateTree)
-	forkIDIntervals, err := forkIDIntervals(cliCtx.Context, st, etherman, c.NetworkConfig.Genesis.GenesisBlockNum)
+	forkIDIntervals, err := forkIDIntervals(cliCtx.Context, st, l1, c.NetworkConfig.Genesis.GenesisBlockNum)
 	if err != nil {
 		log.Fatal("error getting forkIDs. Error: ", err)
 	}
@@ -134,6 +149,7 @@ func start(cliCtx *cli.Context) error {
 		log.Fatal(err)
 	}
 
+	// When replaying L1 the etherman is nil, the synchronizer only uses the eth tx manager to handle the reorgs of its storage
 	etm := ethtxmanager.New(c.EthTxManager, etherman, ethTxManagerStorage, st)
 
 	ev := &event.Event{
@@ -157,7 +173,7 @@ func start(cliCtx *cli.Context) error {
 			if err != nil {
 				log.Fatal(err)
 			}
-			go runAggregator(cliCtx.Context, c.Aggregator, etherman, etm, st)
+			go runAggregator(cliCtx.Context, *c, etherman, etm, st, eventLog)
 		case SEQUENCER:
 			ev.Component = event.Component_Sequencer
 			ev.Description = "Running sequencer"
@@ -169,6 +185,9 @@ func start(cliCtx *cli.Context) error {
 				poolInstance = createPool(c.Pool, l2ChainID, st, eventLog)
 			}
 			seq := createSequencer(*c, poolInstance, ethTxManagerStorage, st, eventLog)
+			if c.Sequencer.API.Enabled {
+				go runAdminAPI(*c, c.Sequencer.API, seq.Services())
+			}
 			go seq.Start(cliCtx.Context)
 		case SEQUENCE_SENDER:
 			ev.Component = event.Component_Sequence_Sender
@@ -180,7 +199,7 @@ func start(cliCtx *cli.Context) error {
 			if poolInstance == nil {
 				poolInstance = createPool(c.Pool, l2ChainID, st, eventLog)
 			}
-			seqSender := createSequenceSender(*c, poolInstance, ethTxManagerStorage, st, eventLog)
+			seqSender := createSequenceSender(*c, poolInstance, ethTxManagerStorage, st, stateSqlDB, eventLog)
 			go seqSender.Start(cliCtx.Context)
 		case RPC:
 			ev.Component = event.Component_RPC
@@ -211,7 +230,7 @@ func start(cliCtx *cli.Context) error {
 			if poolInstance == nil {
 				poolInstance = createPool(c.Pool, l2ChainID, st, eventLog)
 			}
-			go runSynchronizer(*c, etherman, etm, st, poolInstance, eventLog)
+			go runSynchronizer(*c, l1, etm, st, stateSqlDB, poolInstance, eventLog)
 		case ETHTXMANAGER:
 			ev.Component = event.Component_EthTxManager
 			ev.Description = "Running eth tx manager service"
@@ -279,7 +298,43 @@ func newEtherman(c config.Config) (*etherman.Client, error) {
 	return etherman, nil
 }
 
-func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManager *ethtxmanager.Client, st *state.State, pool *pool.Pool, eventLog *event.EventLog) {
+// newL1 returns the etherman and the L1 the node reads its chain settings from and synchronizes with, which
+// records its answers when Synchronizer.RecordL1Path is set. When Synchronizer.ReplayL1Path is set, the L1
+// is the recording and the L1 node isn't dialed, so the etherman is nil and only the synchronizer can run
+func newL1(c config.Config, components []string) (*etherman.Client, l1replay.Etherman, error) {
+	if c.Synchronizer.ReplayL1Path != "" {
+		for _, component := range components {
+			if component != SYNCHRONIZER {
+				return nil, nil, fmt.Errorf("the %s component can't run while replaying the L1 data recorded in %s", component, c.Synchronizer.ReplayL1Path)
+			}
+		}
+		if c.Bootstrap.VerifyOnStart {
+			return nil, nil, fmt.Errorf("the state can't be verified against L1 while replaying the L1 data recorded in %s", c.Synchronizer.ReplayL1Path)
+		}
+		log.Infof("replaying the L1 data recorded in %s", c.Synchronizer.ReplayL1Path)
+		replayer, err := l1replay.NewReplayer(c.Synchronizer.ReplayL1Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, replayer, nil
+	}
+
+	etherman, err := newEtherman(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.Synchronizer.RecordL1Path != "" {
+		log.Infof("recording the L1 data to %s", c.Synchronizer.RecordL1Path)
+		recorder, err := l1replay.NewRecorder(c.Synchronizer.RecordL1Path, etherman)
+		if err != nil {
+			return nil, nil, err
+		}
+		return etherman, recorder, nil
+	}
+	return etherman, etherman, nil
+}
+
+func runSynchronizer(cfg config.Config, l1 l1replay.Etherman, ethTxManager *ethtxmanager.Client, st *state.State, stateSqlDB *pgxpool.Pool, pool *pool.Pool, eventLog *event.EventLog) {
 	var trustedSequencerURL string
 	var err error
 	if !cfg.IsTrustedSequencer {
@@ -287,7 +342,7 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManager
 			trustedSequencerURL = cfg.Synchronizer.TrustedSequencerURL
 		} else {
 			log.Debug("getting trusted sequencer URL from smc")
-			trustedSequencerURL, err = etherman.GetTrustedSequencerURL()
+			trustedSequencerURL, err = l1.GetTrustedSequencerURL()
 			if err != nil {
 				log.Fatal("error getting trusted sequencer URI. Error: %v", err)
 			}
@@ -296,14 +351,22 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManager
 	}
 	zkEVMClient := client.NewClient(trustedSequencerURL)
 
+	daBackend, err := newDataAvailabilityBackend(cfg, l1, st, stateSqlDB, nil, eventLog)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	sy, err := synchronizer.NewSynchronizer(
-		cfg.IsTrustedSequencer, etherman, st, pool, ethTxManager,
+		cfg.IsTrustedSequencer, l1, st, pool, ethTxManager,
 		zkEVMClient, eventLog, cfg.NetworkConfig.Genesis, cfg.Synchronizer,
-		&dataCommitteeClient.ClientFactory{},
+		daBackend,
 	)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if cfg.Synchronizer.API.Enabled {
+		go runAdminAPI(cfg, cfg.Synchronizer.API, sy.Services())
+	}
 	if err := sy.Sync(); err != nil {
 		log.Fatal(err)
 	}
@@ -387,7 +450,7 @@ func createSequencer(cfg config.Config, pool *pool.Pool, etmStorage *ethtxmanage
 	return seq
 }
 
-func createSequenceSender(cfg config.Config, pool *pool.Pool, etmStorage *ethtxmanager.PostgresStorage, st *state.State, eventLog *event.EventLog) *sequencesender.SequenceSender {
+func createSequenceSender(cfg config.Config, pool *pool.Pool, etmStorage *ethtxmanager.PostgresStorage, st *state.State, stateSqlDB *pgxpool.Pool, eventLog *event.EventLog) *sequencesender.SequenceSender {
 	etherman, err := newEtherman(cfg)
 	if err != nil {
 		log.Fatal(err)
@@ -403,19 +466,72 @@ func createSequenceSender(cfg config.Config, pool *pool.Pool, etmStorage *ethtxm
 
 	ethTxManager := ethtxmanager.New(cfg.EthTxManager, etherman, etmStorage, st)
 
-	seqSender, err := sequencesender.New(cfg.SequenceSender, st, etherman, ethTxManager, eventLog, pk)
+	daBackend, err := newDataAvailabilityBackend(cfg, etherman, st, stateSqlDB, pk, eventLog)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	seqSender, err := sequencesender.New(cfg.SequenceSender, st, etherman, ethTxManager, eventLog, daBackend)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if cfg.SequenceSender.API.Enabled {
+		go runAdminAPI(cfg, cfg.SequenceSender.API, seqSender.Services())
+	}
+
 	return seqSender
 }
 
-func runAggregator(ctx context.Context, c aggregator.Config, etherman *etherman.Client, ethTxManager *ethtxmanager.Client, st *state.State) {
-	agg, err := aggregator.New(c, st, ethTxManager, etherman)
+// runAdminAPI serves the admin endpoints of a component using the JSON-RPC server configuration
+// with the host, port and auth token of the component API
+func runAdminAPI(cfg config.Config, apiCfg jsonrpc.AdminAPIConfig, services []jsonrpc.Service) {
+	rpcCfg := cfg.RPC
+	rpcCfg.Host = apiCfg.Host
+	rpcCfg.Port = apiCfg.Port
+	rpcCfg.AuthToken = apiCfg.AuthToken
+	rpcCfg.WebSockets.Enabled = false
+
+	if err := jsonrpc.NewServer(rpcCfg, 0, nil, nil, nil, services).Start(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// dataCommitteeReader reads the data committee from L1, or from the recording when replaying L1
+type dataCommitteeReader interface {
+	GetCurrentDataCommittee() (*etherman.DataCommittee, error)
+	GetLatestDataCommittee() (*etherman.DataCommittee, error)
+}
+
+// newDataAvailabilityBackend creates the configured data availability backend. The private key
+// is only needed by the data committee backend to post sequences, so it can be nil otherwise
+func newDataAvailabilityBackend(cfg config.Config, etherman dataCommitteeReader, st *state.State, stateSqlDB *pgxpool.Pool, pk *ecdsa.PrivateKey, eventLog *event.EventLog) (dataavailability.DataAvailabilityBackend, error) {
+	switch cfg.DataAvailability.Backend {
+	case dataavailability.DataCommitteeBackend:
+		dacCfg := cfg.DataAvailability.DataCommittee
+		return datacommittee.New(datacommittee.Config{
+			L2Coinbase:                cfg.SequenceSender.L2Coinbase,
+			RequestTimeout:            dacCfg.RequestTimeout,
+			RequestRetries:            dacCfg.RequestRetries,
+			RetryBackoff:              dacCfg.RetryBackoff,
+			MinHealthScore:            dacCfg.MinHealthScore,
+			RetrievalParallelRequests: dacCfg.RetrievalParallelRequests,
+		}, pk, etherman, st, eventLog, &datacommittee.ClientFactory{})
+	case dataavailability.LocalBackend:
+		return local.New(cfg.DataAvailability.Local, stateSqlDB)
+	default:
+		return nil, fmt.Errorf("unknown data availability backend: %s", cfg.DataAvailability.Backend)
+	}
+}
+
+func runAggregator(ctx context.Context, c config.Config, etherman *etherman.Client, ethTxManager *ethtxmanager.Client, st *state.State, eventLog *event.EventLog) {
+	agg, err := aggregator.New(c.Aggregator, st, ethTxManager, etherman, eventLog)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if c.Aggregator.API.Enabled {
+		go runAdminAPI(c, c.Aggregator.API, agg.Services())
+	}
 	err = agg.Start(ctx)
 	if err != nil {
 		log.Fatal(err)
@@ -570,7 +686,7 @@ func logVersion() {
 	)
 }
 
-func forkIDIntervals(ctx context.Context, st *state.State, etherman *etherman.Client, genesisBlockNumber uint64) ([]state.ForkIDInterval, error) {
+func forkIDIntervals(ctx context.Context, st *state.State, l1 l1replay.Etherman, genesisBlockNumber uint64) ([]state.ForkIDInterval, error) {
 	log.Debug("getting forkIDs from db")
 	forkIDIntervals, err := st.GetForkIDs(ctx, nil)
 	if err != nil && !errors.Is(err, state.ErrStateNotSynchronized) {
@@ -588,7 +704,7 @@ func forkIDIntervals(ctx context.Context, st *state.State, etherman *etherman.Cl
 		if lastBlock != nil {
 			log.Info("Getting forkIDs intervals. Please wait...")
 			// Read Fork ID FROM POE SC
-			forkIntervals, err := etherman.GetForks(ctx, genesisBlockNumber, lastBlock.BlockNumber)
+			forkIntervals, err := l1.GetForks(ctx, genesisBlockNumber, lastBlock.BlockNumber)
 			if err != nil {
 				return []state.ForkIDInterval{}, fmt.Errorf("error getting forks. Please check the configuration. Error: %v", err)
 			} else if len(forkIntervals) == 0 {
@@ -626,7 +742,7 @@ func forkIDIntervals(ctx context.Context, st *state.State, etherman *etherman.Cl
 			forkIDIntervals = forkIntervals
 		} else {
 			log.Debug("Getting initial forkID")
-			forkIntervals, err := etherman.GetForks(ctx, genesisBlockNumber, genesisBlockNumber)
+			forkIntervals, err := l1.GetForks(ctx, genesisBlockNumber, genesisBlockNumber)
 			if err != nil {
 				return []state.ForkIDInterval{}, fmt.Errorf("error getting forks. Please check the configuration. Error: %v", err)
 			} else if len(forkIntervals) == 0 {
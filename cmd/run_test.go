@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0xPolygon/cdk-validium-node/config"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/0xPolygon/cdk-validium-node/synchronizer/l1replay"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedL1 answers with the chain settings read by the node when it starts
+type recordedL1 struct {
+	l1replay.Etherman
+}
+
+func (l *recordedL1) GetL2ChainID() (uint64, error) {
+	return 1001, nil //nolint:gomnd
+}
+
+func (l *recordedL1) GetForks(ctx context.Context, genBlockNumber uint64, lastL1BlockSynced uint64) ([]state.ForkIDInterval, error) {
+	return []state.ForkIDInterval{{FromBatchNumber: 0, ToBatchNumber: 100, ForkId: 5, BlockNumber: genBlockNumber}}, nil //nolint:gomnd
+}
+
+func (l *recordedL1) GetCurrentDataCommittee() (*etherman.DataCommittee, error) {
+	return &etherman.DataCommittee{
+		AddressesHash:      common.HexToHash("0x1"),
+		Members:            []etherman.DataCommitteeMember{{Addr: common.HexToAddress("0x2"), URL: "http://member"}},
+		RequiredSignatures: 1,
+	}, nil
+}
+
+func (l *recordedL1) GetTrustedSequencerURL() (string, error) {
+	return "http://sequencer", nil
+}
+
+func TestNewL1Replay(t *testing.T) {
+	var l1Requests int32
+	l1Node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&l1Requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer l1Node.Close()
+
+	path := filepath.Join(t.TempDir(), "l1.jsonl")
+	recorder, err := l1replay.NewRecorder(path, &recordedL1{})
+	require.NoError(t, err)
+	_, err = recorder.GetL2ChainID()
+	require.NoError(t, err)
+	_, err = recorder.GetForks(context.Background(), 100, 100)
+	require.NoError(t, err)
+	_, err = recorder.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	_, err = recorder.GetTrustedSequencerURL()
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	cfg, err := config.Default()
+	require.NoError(t, err)
+	cfg.Etherman.URL = l1Node.URL
+	cfg.Synchronizer.ReplayL1Path = path
+	cfg.DataAvailability.Backend = dataavailability.DataCommitteeBackend
+
+	ethMan, l1, err := newL1(*cfg, []string{SYNCHRONIZER})
+	require.NoError(t, err)
+	assert.Nil(t, ethMan)
+
+	// The chain settings read when starting come from the recording
+	chainID, err := l1.GetL2ChainID()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1001), chainID)
+	forks, err := l1.GetForks(context.Background(), 100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), forks[0].ForkId)
+	url, err := l1.GetTrustedSequencerURL()
+	require.NoError(t, err)
+	assert.Equal(t, "http://sequencer", url)
+
+	// The data committee backend of the synchronizer reads the recorded committee
+	_, err = newDataAvailabilityBackend(*cfg, l1, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	// Only the synchronizer runs while replaying
+	_, _, err = newL1(*cfg, []string{SYNCHRONIZER, RPC})
+	assert.ErrorContains(t, err, "the rpc component can't run while replaying")
+	cfg.Bootstrap.VerifyOnStart = true
+	_, _, err = newL1(*cfg, []string{SYNCHRONIZER})
+	assert.Error(t, err)
+
+	assert.Zero(t, atomic.LoadInt32(&l1Requests))
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/config"
+	"github.com/0xPolygon/cdk-validium-node/db"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	trustedReorgsFlagFrom   = "from"
+	trustedReorgsFlagTo     = "to"
+	trustedReorgsFlagFormat = "format"
+
+	trustedReorgsFormatText = "text"
+	trustedReorgsFormatJSON = "json"
+)
+
+var trustedReorgsFlags = []cli.Flag{
+	&configFileFlag,
+	&networkFlag,
+	&customNetworkFlag,
+	&cli.Uint64Flag{
+		Name:  trustedReorgsFlagFrom,
+		Usage: "First batch to report",
+	},
+	&cli.Uint64Flag{
+		Name:  trustedReorgsFlagTo,
+		Usage: "Last batch to report, all the batches if not set",
+	},
+	&cli.StringFlag{
+		Name:  trustedReorgsFlagFormat,
+		Usage: fmt.Sprintf("Format of the report: [`%s`, `%s`]", trustedReorgsFormatText, trustedReorgsFormatJSON),
+		Value: trustedReorgsFormatText,
+	},
+}
+
+// trustedReorgs reports the divergences detected by the synchronizer in the trusted state,
+// explaining why the transactions of the discarded trusted batches vanished
+func trustedReorgs(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx, true)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	format := cliCtx.String(trustedReorgsFlagFormat)
+	if format != trustedReorgsFormatText && format != trustedReorgsFormatJSON {
+		return fmt.Errorf("unknown report format: %s", format)
+	}
+	fromBatch := cliCtx.Uint64(trustedReorgsFlagFrom)
+	toBatch := cliCtx.Uint64(trustedReorgsFlagTo)
+	if toBatch == 0 || toBatch > math.MaxInt64 {
+		toBatch = math.MaxInt64
+	}
+	if fromBatch > toBatch {
+		return fmt.Errorf("first batch %d is greater than last batch %d", fromBatch, toBatch)
+	}
+
+	stateSqlDB, err := db.NewSQLDB(c.StateDB)
+	if err != nil {
+		return err
+	}
+	defer stateSqlDB.Close()
+	stateDB := state.NewPostgresStorage(stateSqlDB)
+
+	divergences, err := stateDB.GetTrustedDivergences(context.Background(), fromBatch, toBatch, nil)
+	if err != nil {
+		return err
+	}
+
+	if format == trustedReorgsFormatJSON {
+		reorgs := make([]types.TrustedReorg, 0, len(divergences))
+		for _, divergence := range divergences {
+			reorgs = append(reorgs, types.NewTrustedReorg(divergence))
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(reorgs)
+	}
+	return writeTrustedReorgs(os.Stdout, divergences)
+}
+
+func writeTrustedReorgs(w io.Writer, divergences []state.TrustedDivergence) error {
+	if len(divergences) == 0 {
+		_, err := fmt.Fprintln(w, "No trusted reorgs found")
+		return err
+	}
+	for _, d := range divergences {
+		outcome := "trusted state reset from this batch"
+		if d.Halted {
+			outcome = "synchronizer halted"
+		}
+		_, err := fmt.Fprintf(w, "Batch %d, detected at %s: %s\n"+
+			"  kind:                 %s\n"+
+			"  state root:           expected %s, actual %s\n",
+			d.BatchNumber, d.Timestamp.UTC().Format(time.RFC3339), outcome,
+			d.Kind,
+			d.ExpectedStateRoot, d.ActualStateRoot)
+		if err != nil {
+			return err
+		}
+		// Only the state roots are compared for the other kinds
+		if d.Kind == state.TrustedDivergenceL1 {
+			_, err := fmt.Fprintf(w, "  global exit root:     expected %s, actual %s\n"+
+				"  timestamp:            expected %d, actual %d\n"+
+				"  transactions hash:    expected %s, actual %s\n",
+				d.ExpectedGER, d.ActualGER,
+				d.ExpectedTimestamp.Unix(), d.ActualTimestamp.Unix(),
+				d.ExpectedTxsHash, d.ActualTxsHash)
+			if err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "  reorged transactions: %d\n", len(d.ReorgedTxs)); err != nil {
+			return err
+		}
+		for _, txHash := range d.ReorgedTxs {
+			if _, err := fmt.Fprintf(w, "    %s\n", txHash); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, "  reason:"); err != nil {
+			return err
+		}
+		for _, line := range strings.Split(strings.TrimSpace(d.Reason), "\n") {
+			if _, err := fmt.Fprintf(w, "    %s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/cdk-validium-node/config"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/datacommittee"
+	"github.com/0xPolygon/cdk-validium-node/db"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	verifyDAFlagFrom   = "from"
+	verifyDAFlagTo     = "to"
+	verifyDAFlagFormat = "format"
+
+	verifyDAFormatText = "text"
+	verifyDAFormatJSON = "json"
+)
+
+var verifyDAFlags = []cli.Flag{
+	&configFileFlag,
+	&networkFlag,
+	&customNetworkFlag,
+	&cli.Uint64Flag{
+		Name:  verifyDAFlagFrom,
+		Usage: "First batch to audit",
+		Value: 1,
+	},
+	&cli.Uint64Flag{
+		Name:  verifyDAFlagTo,
+		Usage: "Last batch to audit, the last virtual batch if not set",
+	},
+	&cli.StringFlag{
+		Name:  verifyDAFlagFormat,
+		Usage: fmt.Sprintf("Format of the report: [`%s`, `%s`]", verifyDAFormatText, verifyDAFormatJSON),
+		Value: verifyDAFormatText,
+	},
+}
+
+// verifyDA requests the data of the virtual batches to every member of the current data
+// committee, reporting which members are missing data or serving wrong data
+func verifyDA(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx, true)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	format := cliCtx.String(verifyDAFlagFormat)
+	if format != verifyDAFormatText && format != verifyDAFormatJSON {
+		return fmt.Errorf("unknown report format: %s", format)
+	}
+
+	stateSqlDB, err := db.NewSQLDB(c.StateDB)
+	if err != nil {
+		return err
+	}
+	defer stateSqlDB.Close()
+	stateDB := state.NewPostgresStorage(stateSqlDB)
+
+	etherman, err := newEtherman(*c)
+	if err != nil {
+		return err
+	}
+	defer etherman.Close()
+	members, err := etherman.GetCurrentDataCommitteeMembers()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	fromBatch := cliCtx.Uint64(verifyDAFlagFrom)
+	toBatch := cliCtx.Uint64(verifyDAFlagTo)
+	if toBatch == 0 {
+		toBatch, err = stateDB.GetLastVirtualBatchNum(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := datacommittee.Audit(ctx, stateDB, &datacommittee.ClientFactory{}, members, fromBatch, toBatch,
+		c.DataAvailability.DataCommittee.RequestTimeout.Duration)
+	if err != nil {
+		return err
+	}
+
+	if format == verifyDAFormatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !report.Healthy() {
+		return errors.New("some data committee members are missing data or serving wrong data")
+	}
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/cdk-validium-node/aggregator"
+	"github.com/0xPolygon/cdk-validium-node/bootstrap"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
 	"github.com/0xPolygon/cdk-validium-node/db"
 	"github.com/0xPolygon/cdk-validium-node/etherman"
 	"github.com/0xPolygon/cdk-validium-node/ethtxmanager"
@@ -100,6 +102,8 @@ type Config struct {
 	SequenceSender sequencesender.Config
 	// Configuration of the aggregator service
 	Aggregator aggregator.Config
+	// Configuration of the data availability backend used to post and retrieve the batches data
+	DataAvailability dataavailability.Config
 	// Configuration of the genesis of the network. This is used to known the initial state of the network
 	NetworkConfig NetworkConfig
 	// Configuration of the gas price suggester service
@@ -116,6 +120,8 @@ type Config struct {
 	EventLog event.Config
 	// Configuration of the hash database connection
 	HashDB db.Config
+	// Configuration of the check of the state against L1, used to bootstrap a node from a snapshot
+	Bootstrap bootstrap.Config
 }
 
 // Default parses the default configuration values.
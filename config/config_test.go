@@ -40,6 +40,58 @@ func Test_Defaults(t *testing.T) {
 			path:          "Synchronizer.SyncChunkSize",
 			expectedValue: uint64(100),
 		},
+		{
+			path:          "Synchronizer.L1ParallelWorkers",
+			expectedValue: uint64(4),
+		},
+		{
+			path:          "Synchronizer.L1MaxPrefetchedRanges",
+			expectedValue: uint64(8),
+		},
+		{
+			path:          "Synchronizer.L1SyncBlock",
+			expectedValue: "latest",
+		},
+		{
+			path:          "Synchronizer.L1TentativeSync",
+			expectedValue: false,
+		},
+		{
+			path:          "Synchronizer.RecordL1Path",
+			expectedValue: "",
+		},
+		{
+			path:          "Synchronizer.ReplayL1Path",
+			expectedValue: "",
+		},
+		{
+			path:          "Synchronizer.StopOnBatchNum",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Synchronizer.StopOnBatchState",
+			expectedValue: "virtual",
+		},
+		{
+			path:          "Synchronizer.StopOnL1Block",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Synchronizer.API.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Synchronizer.API.Host",
+			expectedValue: "0.0.0.0",
+		},
+		{
+			path:          "Synchronizer.API.Port",
+			expectedValue: int(8127),
+		},
+		{
+			path:          "Synchronizer.API.AuthToken",
+			expectedValue: "",
+		},
 		{
 			path:          "Sequencer.WaitPeriodPoolIsEmpty",
 			expectedValue: types.NewDuration(1 * time.Second),
@@ -164,6 +216,22 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.EffectiveGasPrice.Enabled",
 			expectedValue: false,
 		},
+		{
+			path:          "Sequencer.API.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.API.Host",
+			expectedValue: "0.0.0.0",
+		},
+		{
+			path:          "Sequencer.API.Port",
+			expectedValue: int(8128),
+		},
+		{
+			path:          "Sequencer.API.AuthToken",
+			expectedValue: "",
+		},
 		{
 			path:          "Sequencer.DBManager.PoolRetrievalInterval",
 			expectedValue: types.NewDuration(500 * time.Millisecond),
@@ -184,10 +252,102 @@ func Test_Defaults(t *testing.T) {
 			path:          "SequenceSender.MaxBatchesForL1",
 			expectedValue: uint64(1000),
 		},
+		{
+			path:          "SequenceSender.MaxL1CostPerBatch",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "SequenceSender.MaxSequenceFeePerBatch",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "SequenceSender.DryRun",
+			expectedValue: false,
+		},
+		{
+			path:          "SequenceSender.API.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "SequenceSender.API.Host",
+			expectedValue: "0.0.0.0",
+		},
+		{
+			path:          "SequenceSender.API.Port",
+			expectedValue: int(8126),
+		},
+		{
+			path:          "SequenceSender.API.AuthToken",
+			expectedValue: "",
+		},
+		{
+			path:          "Bootstrap.VerifyOnStart",
+			expectedValue: false,
+		},
+		{
+			path:          "Bootstrap.RehashBatches",
+			expectedValue: uint64(1000),
+		},
+		{
+			path:          "DataAvailability.Backend",
+			expectedValue: "DataCommittee",
+		},
+		{
+			path:          "DataAvailability.DataCommittee.RequestTimeout",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "DataAvailability.DataCommittee.RequestRetries",
+			expectedValue: uint64(2),
+		},
+		{
+			path:          "DataAvailability.DataCommittee.RetryBackoff",
+			expectedValue: types.NewDuration(1 * time.Second),
+		},
+		{
+			path:          "DataAvailability.DataCommittee.MinHealthScore",
+			expectedValue: float64(0.5),
+		},
+		{
+			path:          "DataAvailability.DataCommittee.RetrievalParallelRequests",
+			expectedValue: uint64(3),
+		},
+		{
+			path:          "DataAvailability.Local.Storage",
+			expectedValue: "Postgres",
+		},
+		{
+			path:          "DataAvailability.Local.Path",
+			expectedValue: "/tmp/cdk-validium-da",
+		},
 		{
 			path:          "Etherman.URL",
 			expectedValue: "http://localhost:8545",
 		},
+		{
+			path:          "Etherman.FallbackURLs",
+			expectedValue: []string{},
+		},
+		{
+			path:          "Etherman.HealthCheckInterval",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "Etherman.MaxBlockLag",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Etherman.QuorumSize",
+			expectedValue: int(0),
+		},
+		{
+			path:          "Etherman.RequestTimeout",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "Etherman.DataCommitteeCacheMaxAge",
+			expectedValue: types.NewDuration(10 * time.Minute),
+		},
 		{
 			path:          "NetworkConfig.L1Config.L1ChainID",
 			expectedValue: uint64(1337),
@@ -365,6 +525,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.EnableL2SuggestedGasPricePolling",
 			expectedValue: true,
 		},
+		{
+			path:          "RPC.AuthToken",
+			expectedValue: "",
+		},
 		{
 			path:          "RPC.WebSockets.Enabled",
 			expectedValue: true,
@@ -441,6 +605,70 @@ func Test_Defaults(t *testing.T) {
 			path:          "Aggregator.GeneratingProofCleanupThreshold",
 			expectedValue: "10m",
 		},
+		{
+			path:          "Aggregator.ProverAuth.CertFile",
+			expectedValue: "",
+		},
+		{
+			path:          "Aggregator.ProverAuth.KeyFile",
+			expectedValue: "",
+		},
+		{
+			path:          "Aggregator.ProverAuth.ClientCAFile",
+			expectedValue: "",
+		},
+		{
+			path:          "Aggregator.ProverAuth.Token",
+			expectedValue: "",
+		},
+		{
+			path:          "Aggregator.ProverAuth.AllowedProvers",
+			expectedValue: []string{},
+		},
+		{
+			path:          "Aggregator.Scheduling.MaxConcurrentBatchProofs",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Aggregator.Scheduling.Provers",
+			expectedValue: []aggregator.ProverPolicy{},
+		},
+		{
+			path:          "Aggregator.ProofDeadlines.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Aggregator.ProofDeadlines.Factor",
+			expectedValue: float64(3),
+		},
+		{
+			path:          "Aggregator.ProofDeadlines.MinDeadline",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
+		{
+			path:          "Aggregator.ProofDeadlines.HistorySize",
+			expectedValue: uint64(20),
+		},
+		{
+			path:          "Aggregator.ProofDeadlines.FailedProverBackoff",
+			expectedValue: types.NewDuration(1 * time.Minute),
+		},
+		{
+			path:          "Aggregator.API.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Aggregator.API.Host",
+			expectedValue: "0.0.0.0",
+		},
+		{
+			path:          "Aggregator.API.Port",
+			expectedValue: int(8129),
+		},
+		{
+			path:          "Aggregator.API.AuthToken",
+			expectedValue: "",
+		},
 	}
 	file, err := os.CreateTemp("", "genesisConfig")
 	require.NoError(t, err)
@@ -41,8 +41,14 @@ GlobalQueue = 1024
 
 [Etherman]
 URL = "http://localhost:8545"
+FallbackURLs = []
+HealthCheckInterval = "10s"
+MaxBlockLag = 0
+QuorumSize = 0
+RequestTimeout = "10s"
 ForkIDChunkSize = 20000
 MultiGasProvider = false
+DataCommitteeCacheMaxAge = "10m"
 	[Etherman.Etherscan]
 		ApiKey = ""
 
@@ -62,6 +68,7 @@ MaxRequestsPerIPAndSecond = 500
 SequencerNodeURI = ""
 EnableL2SuggestedGasPricePolling = true
 TraceBatchUseHTTPS = true
+AuthToken = ""
 	[RPC.WebSockets]
 		Enabled = true
 		Host = "0.0.0.0"
@@ -72,6 +79,20 @@ SyncInterval = "1s"
 SyncChunkSize = 100
 SyncOnlyTrusted = false
 TrustedSequencerURL = "" # If it is empty or not specified, then the value is read from the smc
+L1ParallelWorkers = 4
+L1MaxPrefetchedRanges = 8
+L1SyncBlock = "latest"
+L1TentativeSync = false
+RecordL1Path = ""
+ReplayL1Path = ""
+StopOnBatchNum = 0
+StopOnBatchState = "virtual"
+StopOnL1Block = 0
+	[Synchronizer.API]
+	Enabled = false
+	Host = "0.0.0.0"
+	Port = 8127
+	AuthToken = ""
 
 [Sequencer]
 WaitPeriodPoolIsEmpty = "1s"
@@ -111,6 +132,11 @@ MaxTxLifetime = "3h"
 		ByteGasCost = 16
 		MarginFactor = 1
 		Enabled = false
+	[Sequencer.API]
+		Enabled = false
+		Host = "0.0.0.0"
+		Port = 8128
+		AuthToken = ""
 
 [SequenceSender]
 WaitPeriodSendSequence = "5s"
@@ -118,6 +144,26 @@ LastBatchVirtualizationTimeMaxWaitPeriod = "5s"
 MaxBatchesForL1 = 1000
 L2Coinbase = "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
 PrivateKey = {Path = "/pk/sequencer.keystore", Password = "testonly"}
+MaxL1CostPerBatch = 0
+MaxSequenceFeePerBatch = 0
+DryRun = false
+	[SequenceSender.API]
+	Enabled = false
+	Host = "0.0.0.0"
+	Port = 8126
+	AuthToken = ""
+
+[DataAvailability]
+Backend = "DataCommittee"
+	[DataAvailability.DataCommittee]
+	RequestTimeout = "10s"
+	RequestRetries = 2
+	RetryBackoff = "1s"
+	MinHealthScore = 0.5
+	RetrievalParallelRequests = 3
+	[DataAvailability.Local]
+	Storage = "Postgres"
+	Path = "/tmp/cdk-validium-da"
 
 [Aggregator]
 Host = "0.0.0.0"
@@ -129,6 +175,26 @@ TxProfitabilityMinReward = "1.1"
 ProofStatePollingInterval = "5s"
 CleanupLockedProofsInterval = "2m"
 GeneratingProofCleanupThreshold = "10m"
+	[Aggregator.ProverAuth]
+	CertFile = ""
+	KeyFile = ""
+	ClientCAFile = ""
+	Token = ""
+	AllowedProvers = []
+	[Aggregator.Scheduling]
+	MaxConcurrentBatchProofs = 0
+	Provers = []
+	[Aggregator.ProofDeadlines]
+	Enabled = false
+	Factor = 3.0
+	MinDeadline = "5m"
+	HistorySize = 20
+	FailedProverBackoff = "1m"
+	[Aggregator.API]
+	Enabled = false
+	Host = "0.0.0.0"
+	Port = 8129
+	AuthToken = ""
 
 [L2GasPriceSuggester]
 Type = "follower"
@@ -161,4 +227,8 @@ Host = "cdk-validium-state-db"
 Port = "5432"
 EnableLog = false
 MaxConns = 200
+
+[Bootstrap]
+VerifyOnStart = false
+RehashBatches = 1000
 `
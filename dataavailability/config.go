@@ -0,0 +1,52 @@
+package dataavailability
+
+import "github.com/0xPolygon/cdk-validium-node/config/types"
+
+const (
+	// DataCommitteeBackend is the backend that relies on the data availability committee
+	DataCommitteeBackend = "DataCommittee"
+	// LocalBackend is the backend that stores the data locally, intended for devnets and tests
+	LocalBackend = "Local"
+
+	// LocalStoragePostgres stores the data of the local backend in the state DB
+	LocalStoragePostgres = "Postgres"
+	// LocalStorageFilesystem stores the data of the local backend as files in a directory
+	LocalStorageFilesystem = "Filesystem"
+)
+
+// Config represents the configuration of the data availability backend
+type Config struct {
+	// Backend is the data availability backend used by the sequence sender and the synchronizer.
+	// Valid values: "DataCommittee", "Local"
+	Backend string `mapstructure:"Backend"`
+	// DataCommittee is the configuration of the data committee backend
+	DataCommittee DataCommitteeConfig `mapstructure:"DataCommittee"`
+	// Local is the configuration of the local backend
+	Local LocalConfig `mapstructure:"Local"`
+}
+
+// DataCommitteeConfig represents the configuration of the data committee backend, used by the
+// sequence sender to collect the signatures of the sequences and by the synchronizer to retrieve
+// the data of the batches
+type DataCommitteeConfig struct {
+	// RequestTimeout is the maximum time to wait for a member to answer a single signature or data request. 0 means no timeout
+	RequestTimeout types.Duration `mapstructure:"RequestTimeout"`
+	// RequestRetries is the amount of times a failed signature request is retried against the same member
+	RequestRetries uint64 `mapstructure:"RequestRetries"`
+	// RetryBackoff is the time to wait before the first retry to a member, it is doubled after each failed attempt
+	RetryBackoff types.Duration `mapstructure:"RetryBackoff"`
+	// MinHealthScore is the health score (between 0 and 1) under which a member is not asked for its
+	// signature, as long as the required signatures can be collected without it
+	MinHealthScore float64 `mapstructure:"MinHealthScore"`
+	// RetrievalParallelRequests is the amount of members requested at once for the data of a batch. The
+	// first valid answer is used and the rest of the requests are cancelled. 0 means all of them
+	RetrievalParallelRequests uint64 `mapstructure:"RetrievalParallelRequests"`
+}
+
+// LocalConfig represents the configuration of the local data availability backend
+type LocalConfig struct {
+	// Storage is where the data is stored. Valid values: "Postgres", "Filesystem"
+	Storage string `mapstructure:"Storage"`
+	// Path is the directory where the data is stored when using the filesystem storage
+	Path string `mapstructure:"Path"`
+}
@@ -0,0 +1,110 @@
+package dacstub
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/0xPolygon/cdk-data-availability/sequence"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/local"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// APIDATACOM is the namespace of the endpoints used by the sequence sender to get the signatures
+	APIDATACOM = "datacom"
+	// APISYNC is the namespace of the endpoints used by the synchronizer to get the batches data
+	APISYNC = "sync"
+)
+
+// Services returns the JSON-RPC services of a data committee member that signs with the given key
+// and stores the data in the given storage. If the sequencer address is not the zero address, only
+// the sequences signed by the sequencer are accepted
+func Services(storage local.Storage, privateKey *ecdsa.PrivateKey, sequencerAddr common.Address) []jsonrpc.Service {
+	return []jsonrpc.Service{
+		{
+			Name:    APIDATACOM,
+			Service: NewDataComEndpoints(storage, privateKey, sequencerAddr),
+		},
+		{
+			Name:    APISYNC,
+			Service: NewSyncEndpoints(storage),
+		},
+	}
+}
+
+// DataComEndpoints contains the implementation of the "datacom" endpoints
+type DataComEndpoints struct {
+	storage       local.Storage
+	privateKey    *ecdsa.PrivateKey
+	sequencerAddr common.Address
+}
+
+// NewDataComEndpoints returns DataComEndpoints
+func NewDataComEndpoints(storage local.Storage, privateKey *ecdsa.PrivateKey, sequencerAddr common.Address) *DataComEndpoints {
+	return &DataComEndpoints{
+		storage:       storage,
+		privateKey:    privateKey,
+		sequencerAddr: sequencerAddr,
+	}
+}
+
+// SignSequence stores the data of the batches of the sequence and returns the signature of its
+// accumulated input hash, as a data committee member does
+func (d *DataComEndpoints) SignSequence(signedSequence sequence.SignedSequence) (interface{}, types.Error) {
+	sender, err := signedSequence.Signer()
+	if err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to verify sender")
+	}
+	if d.sequencerAddr != (common.Address{}) && sender != d.sequencerAddr {
+		log.Warnf("rejected sequence signed by %s, expected sequencer %s", sender.Hex(), d.sequencerAddr.Hex())
+		return nil, types.NewRPCError(types.DefaultErrorCode, "unauthorized")
+	}
+
+	ctx := context.Background()
+	for _, od := range signedSequence.Sequence.OffChainData() {
+		if err := d.storage.Store(ctx, od.Key, od.Value); err != nil {
+			log.Errorf("failed to store off chain data %s: %v", od.Key.String(), err)
+			return nil, types.NewRPCError(types.DefaultErrorCode, "failed to store offchain data")
+		}
+	}
+
+	signedSequenceByMe, err := signedSequence.Sequence.Sign(d.privateKey)
+	if err != nil {
+		log.Errorf("failed to sign sequence: %v", err)
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to sign")
+	}
+	log.Infof("signed sequence of %d batches sent by %s, hash %s",
+		len(signedSequence.Sequence.Batches), sender.Hex(), common.BytesToHash(signedSequence.Sequence.HashToSign()).String())
+	return signedSequenceByMe.Signature, nil
+}
+
+// SyncEndpoints contains the implementation of the "sync" endpoints
+type SyncEndpoints struct {
+	storage local.Storage
+}
+
+// NewSyncEndpoints returns SyncEndpoints
+func NewSyncEndpoints(storage local.Storage) *SyncEndpoints {
+	return &SyncEndpoints{storage: storage}
+}
+
+// GetOffChainData returns the data whose hash is the given one
+func (s *SyncEndpoints) GetOffChainData(hash types.ArgHash) (interface{}, types.Error) {
+	data, err := s.storage.Get(context.Background(), hash.Hash())
+	if errors.Is(err, local.ErrNotFound) {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "data not found")
+	} else if err != nil {
+		log.Errorf("failed to get off chain data %s: %v", hash.Hash().String(), err)
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the requested data")
+	}
+	if crypto.Keccak256Hash(data) != hash.Hash() {
+		log.Errorf("stored off chain data doesn't match its hash %s", hash.Hash().String())
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the requested data")
+	}
+	return types.ArgBytes(data), nil
+}
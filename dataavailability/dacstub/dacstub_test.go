@@ -0,0 +1,97 @@
+package dacstub
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/batch"
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-data-availability/sequence"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/local"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDACStub(t *testing.T) {
+	storage, err := local.NewStorage(dataavailability.LocalConfig{
+		Storage: dataavailability.LocalStorageFilesystem,
+		Path:    t.TempDir(),
+	}, nil)
+	require.NoError(t, err)
+
+	memberKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, lis.Close())
+
+	server := jsonrpc.NewServer(jsonrpc.Config{
+		Host:                      "127.0.0.1",
+		Port:                      port,
+		MaxRequestsPerIPAndSecond: 1000, //nolint:gomnd
+	}, 0, nil, nil, nil, Services(storage, memberKey, crypto.PubkeyToAddress(sequencerKey.PublicKey)))
+	go func() {
+		_ = server.Start()
+	}()
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	require.Eventually(t, func() bool {
+		res, err := http.Get(url) //nolint:gosec
+		if err != nil {
+			return false
+		}
+		return res.Body.Close() == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	l2Data := []byte("i poli tis Kerkyras einai omorfi")
+	seq := sequence.Sequence{
+		Batches: []batch.Batch{{
+			L2Data:    l2Data,
+			Coinbase:  common.HexToAddress("0x1"),
+			Timestamp: 1,
+		}},
+		OldAccInputHash: common.HexToHash("0x2"),
+	}
+	c := client.New(url)
+
+	// Sequences not signed by the sequencer are rejected
+	signedByOther, err := seq.Sign(otherKey)
+	require.NoError(t, err)
+	_, err = c.SignSequence(*signedByOther)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unauthorized")
+
+	// Sequences signed by the sequencer are signed by the member and their data stored
+	signedBySequencer, err := seq.Sign(sequencerKey)
+	require.NoError(t, err)
+	signature, err := c.SignSequence(*signedBySequencer)
+	require.NoError(t, err)
+	signedByMember := sequence.SignedSequence{Sequence: seq, Signature: signature}
+	signer, err := signedByMember.Signer()
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(memberKey.PublicKey), signer)
+
+	data, err := c.GetOffChainData(context.Background(), crypto.Keccak256Hash(l2Data))
+	require.NoError(t, err)
+	assert.Equal(t, l2Data, data)
+
+	_, err = c.GetOffChainData(context.Background(), common.HexToHash("0x3"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "data not found")
+}
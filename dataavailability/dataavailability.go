@@ -0,0 +1,28 @@
+package dataavailability
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DataAvailabilityBackend is a layer that keeps the data of the batches available off-chain,
+// so only its hash needs to be sent to L1
+type DataAvailabilityBackend interface {
+	SequencePoster
+	BatchDataGetter
+}
+
+// SequencePoster posts the data of the sequences to be sent to L1
+type SequencePoster interface {
+	// PostSequence makes the data of the sequences available and returns the attestation
+	// that is sent to L1 along with them, so the contract can check it
+	PostSequence(ctx context.Context, sequences []types.Sequence) ([]byte, error)
+}
+
+// BatchDataGetter retrieves the data of the batches sequenced on L1
+type BatchDataGetter interface {
+	// GetBatchL2Data returns the data of a batch, which is checked against its expected hash
+	GetBatchL2Data(ctx context.Context, batchNum uint64, hash common.Hash) ([]byte, error)
+}
@@ -0,0 +1,194 @@
+package datacommittee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrNoVirtualBatches is returned by Audit when the first batch to audit is not virtual yet
+var ErrNoVirtualBatches = errors.New("no virtual batches to audit")
+
+// BatchRange is an inclusive range of batch numbers
+type BatchRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+func (r BatchRange) String() string {
+	if r.From == r.To {
+		return fmt.Sprintf("%d", r.From)
+	}
+	return fmt.Sprintf("%d-%d", r.From, r.To)
+}
+
+// appendBatch adds a batch to the ranges, extending the last one if the batch follows it
+func appendBatch(ranges []BatchRange, batchNumber uint64) []BatchRange {
+	if len(ranges) > 0 && ranges[len(ranges)-1].To+1 == batchNumber {
+		ranges[len(ranges)-1].To = batchNumber
+		return ranges
+	}
+	return append(ranges, BatchRange{From: batchNumber, To: batchNumber})
+}
+
+// MemberAudit is the result of auditing the data held by a committee member
+type MemberAudit struct {
+	Addr common.Address `json:"address"`
+	URL  string         `json:"url"`
+	// Served is the amount of batches whose data was returned by the member
+	Served uint64 `json:"served"`
+	// Missing are the batches whose data couldn't be retrieved from the member
+	Missing []BatchRange `json:"missing"`
+	// WrongData are the batches whose data returned by the member doesn't match the transactions hash
+	WrongData []BatchRange `json:"wrongData"`
+	// LastError is the last error returned by the member, to help diagnosing the missing batches
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Healthy returns true if the member served the data of every audited batch
+func (m *MemberAudit) Healthy() bool {
+	return len(m.Missing) == 0 && len(m.WrongData) == 0
+}
+
+// AuditReport is the result of auditing the data held by the committee members
+type AuditReport struct {
+	FromBatch uint64 `json:"fromBatch"`
+	ToBatch   uint64 `json:"toBatch"`
+	// Audited is the amount of batches whose data was requested to the members
+	Audited uint64 `json:"audited"`
+	// Forced are the batches skipped because they are forced batches, whose data is on L1
+	Forced  []BatchRange  `json:"forced"`
+	Members []MemberAudit `json:"members"`
+}
+
+// Healthy returns true if every member served the data of every audited batch
+func (r *AuditReport) Healthy() bool {
+	for i := range r.Members {
+		if !r.Members[i].Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteText writes the report in a human readable form
+func (r *AuditReport) WriteText(w io.Writer) error {
+	lines := []string{
+		fmt.Sprintf("audited %d virtual batches from %d to %d against %d committee members", r.Audited, r.FromBatch, r.ToBatch, len(r.Members)),
+	}
+	if len(r.Forced) > 0 {
+		lines = append(lines, fmt.Sprintf("skipped forced batches: %s", rangesToString(r.Forced)))
+	}
+	for _, m := range r.Members {
+		status := "OK"
+		if !m.Healthy() {
+			status = "FAIL"
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s (%s): served %d/%d batches", status, m.Addr.Hex(), m.URL, m.Served, r.Audited))
+		if len(m.Missing) > 0 {
+			lines = append(lines, fmt.Sprintf("    missing: %s", rangesToString(m.Missing)))
+		}
+		if len(m.WrongData) > 0 {
+			lines = append(lines, fmt.Sprintf("    wrong data: %s", rangesToString(m.WrongData)))
+		}
+		if m.LastError != "" {
+			lines = append(lines, fmt.Sprintf("    last error: %s", m.LastError))
+		}
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rangesToString(ranges []BatchRange) string {
+	s := ""
+	for i, r := range ranges {
+		if i > 0 {
+			s += ", "
+		}
+		s += r.String()
+	}
+	return s
+}
+
+// Audit requests the data of the virtual batches from fromBatch to toBatch to every committee member,
+// checking it against the transactions hash of the batches stored in the state. Forced batches are
+// skipped, as their data is posted on L1 instead of being held by the committee. If requestTimeout is
+// greater than 0, a member not answering a request within it is reported as missing the batch
+func Audit(ctx context.Context, st auditStateInterface, clientFactory ClientFactoryInterface,
+	members []etherman.DataCommitteeMember, fromBatch, toBatch uint64, requestTimeout time.Duration) (*AuditReport, error) {
+	report := &AuditReport{
+		FromBatch: fromBatch,
+		ToBatch:   toBatch,
+		Members:   make([]MemberAudit, len(members)),
+	}
+	clients := make([]ClientInterface, len(members))
+	for i, member := range members {
+		report.Members[i] = MemberAudit{Addr: member.Addr, URL: member.URL, Missing: []BatchRange{}, WrongData: []BatchRange{}}
+		clients[i] = clientFactory.New(member.URL)
+	}
+	report.Forced = []BatchRange{}
+
+	for batchNumber := fromBatch; batchNumber <= toBatch; batchNumber++ {
+		if _, err := st.GetVirtualBatch(ctx, batchNumber, nil); errors.Is(err, state.ErrNotFound) {
+			if batchNumber == fromBatch {
+				return nil, fmt.Errorf("batch %d is not virtual yet: %w", batchNumber, ErrNoVirtualBatches)
+			}
+			log.Infof("batch %d is not virtual yet, stopping the audit", batchNumber)
+			report.ToBatch = batchNumber - 1
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get virtual batch %d: %w", batchNumber, err)
+		}
+		batch, err := st.GetBatchByNumber(ctx, batchNumber, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get batch %d: %w", batchNumber, err)
+		}
+		if batch.ForcedBatchNum != nil {
+			report.Forced = appendBatch(report.Forced, batchNumber)
+			continue
+		}
+		transactionsHash := crypto.Keccak256Hash(batch.BatchL2Data)
+
+		var wg sync.WaitGroup
+		for i := range clients {
+			wg.Add(1)
+			go func(m *MemberAudit, c ClientInterface) {
+				defer wg.Done()
+				requestCtx := ctx
+				if requestTimeout > 0 {
+					var cancel context.CancelFunc
+					requestCtx, cancel = context.WithTimeout(ctx, requestTimeout)
+					defer cancel()
+				}
+				data, err := c.GetOffChainData(requestCtx, transactionsHash)
+				if err != nil {
+					m.Missing = appendBatch(m.Missing, batchNumber)
+					m.LastError = err.Error()
+				} else if crypto.Keccak256Hash(data) != transactionsHash {
+					m.WrongData = appendBatch(m.WrongData, batchNumber)
+				} else {
+					m.Served++
+				}
+			}(&report.Members[i], clients[i])
+		}
+		wg.Wait()
+		report.Audited++
+		if report.Audited%100 == 0 { //nolint:gomnd
+			log.Infof("audited data of %d batches, last batch %d", report.Audited, batchNumber)
+		}
+	}
+	return report, nil
+}
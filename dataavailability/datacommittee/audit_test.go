@@ -0,0 +1,130 @@
+package datacommittee
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	ctx := context.Background()
+	st := newAuditStateMock(t)
+	factory := newDataCommitteeClientFactoryMock(t)
+
+	members := []etherman.DataCommitteeMember{
+		{URL: "0", Addr: common.HexToAddress("0x0")},
+		{URL: "1", Addr: common.HexToAddress("0x1")},
+		{URL: "2", Addr: common.HexToAddress("0x2")},
+	}
+	clients := make([]*dataCommitteeClientMock, len(members))
+	for i, member := range members {
+		clients[i] = newDataCommitteeClientMock(t)
+		factory.On("New", member.URL).Return(clients[i]).Once()
+	}
+
+	const forcedBatch uint64 = 3
+	forcedBatchNum := uint64(1)
+	for batchNumber := uint64(1); batchNumber <= 5; batchNumber++ {
+		data := []byte(fmt.Sprintf("batch %d", batchNumber))
+		batch := &state.Batch{BatchNumber: batchNumber, BatchL2Data: data}
+		if batchNumber == forcedBatch {
+			batch.ForcedBatchNum = &forcedBatchNum
+		}
+		st.On("GetVirtualBatch", ctx, batchNumber, nil).Return(&state.VirtualBatch{BatchNumber: batchNumber}, nil).Once()
+		st.On("GetBatchByNumber", ctx, batchNumber, nil).Return(batch, nil).Once()
+		if batchNumber == forcedBatch {
+			continue
+		}
+
+		hash := crypto.Keccak256Hash(data)
+		// Member 0 holds all the data
+		clients[0].On("GetOffChainData", mock.Anything, hash).Return(data, nil).Once()
+		// Member 1 is missing the first batches
+		if batchNumber <= 2 {
+			clients[1].On("GetOffChainData", mock.Anything, hash).Return(nil, errors.New("-32000 failed to get the requested data")).Once()
+		} else {
+			clients[1].On("GetOffChainData", mock.Anything, hash).Return(data, nil).Once()
+		}
+		// Member 2 serves wrong data for the last batch
+		if batchNumber == 5 {
+			clients[2].On("GetOffChainData", mock.Anything, hash).Return([]byte("not the correct data"), nil).Once()
+		} else {
+			clients[2].On("GetOffChainData", mock.Anything, hash).Return(data, nil).Once()
+		}
+	}
+	st.On("GetVirtualBatch", ctx, uint64(6), nil).Return(nil, state.ErrNotFound).Once()
+
+	report, err := Audit(ctx, st, factory, members, 1, 10, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1), report.FromBatch)
+	assert.Equal(t, uint64(5), report.ToBatch)
+	assert.Equal(t, uint64(4), report.Audited)
+	assert.Equal(t, []BatchRange{{From: 3, To: 3}}, report.Forced)
+	assert.False(t, report.Healthy())
+
+	assert.True(t, report.Members[0].Healthy())
+	assert.Equal(t, uint64(4), report.Members[0].Served)
+
+	assert.Equal(t, uint64(2), report.Members[1].Served)
+	assert.Equal(t, []BatchRange{{From: 1, To: 2}}, report.Members[1].Missing)
+	assert.Empty(t, report.Members[1].WrongData)
+	assert.Equal(t, "-32000 failed to get the requested data", report.Members[1].LastError)
+
+	assert.Equal(t, uint64(3), report.Members[2].Served)
+	assert.Empty(t, report.Members[2].Missing)
+	assert.Equal(t, []BatchRange{{From: 5, To: 5}}, report.Members[2].WrongData)
+
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	assert.Contains(t, text.String(), "audited 4 virtual batches from 1 to 5 against 3 committee members")
+	assert.Contains(t, text.String(), "skipped forced batches: 3")
+	assert.Contains(t, text.String(), "[OK] "+members[0].Addr.Hex())
+	assert.Contains(t, text.String(), "    missing: 1-2")
+	assert.Contains(t, text.String(), "    wrong data: 5")
+}
+
+func TestAuditNothingVirtual(t *testing.T) {
+	ctx := context.Background()
+	st := newAuditStateMock(t)
+	factory := newDataCommitteeClientFactoryMock(t)
+	member := etherman.DataCommitteeMember{URL: "0", Addr: common.HexToAddress("0x0")}
+	factory.On("New", member.URL).Return(newDataCommitteeClientMock(t)).Once()
+	st.On("GetVirtualBatch", ctx, uint64(7), nil).Return(nil, state.ErrNotFound).Once()
+
+	_, err := Audit(ctx, st, factory, []etherman.DataCommitteeMember{member}, 7, 10, 0)
+	assert.ErrorIs(t, err, ErrNoVirtualBatches)
+}
+
+func TestAuditRequestTimeout(t *testing.T) {
+	ctx := context.Background()
+	st := newAuditStateMock(t)
+	factory := newDataCommitteeClientFactoryMock(t)
+	member := etherman.DataCommitteeMember{URL: "0", Addr: common.HexToAddress("0x0")}
+	client := newDataCommitteeClientMock(t)
+	factory.On("New", member.URL).Return(client).Once()
+
+	data := []byte("batch 1")
+	st.On("GetVirtualBatch", ctx, uint64(1), nil).Return(&state.VirtualBatch{BatchNumber: 1}, nil).Once()
+	st.On("GetBatchByNumber", ctx, uint64(1), nil).Return(&state.Batch{BatchNumber: 1, BatchL2Data: data}, nil).Once()
+	client.On("GetOffChainData", mock.Anything, crypto.Keccak256Hash(data)).Return(func(ctx context.Context, _ common.Hash) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}).Once()
+
+	report, err := Audit(ctx, st, factory, []etherman.DataCommitteeMember{member}, 1, 1, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, []BatchRange{{From: 1, To: 1}}, report.Members[0].Missing)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Members[0].LastError)
+}
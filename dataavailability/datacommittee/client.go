@@ -0,0 +1,56 @@
+package datacommittee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/sequence"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/client"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ClientFactory creates the clients of the data committee members
+type ClientFactory struct{}
+
+// New returns the client of the data committee member served at url
+func (f *ClientFactory) New(url string) ClientInterface {
+	return &Client{url: url}
+}
+
+// Client calls the endpoints of a data committee member. Unlike the client of the
+// cdk-data-availability module, the requests are canceled when their context is done
+type Client struct {
+	url string
+}
+
+// SignSequence sends a request to sign the given sequence by the data committee member
+// if successful returns the signature. The signature should be validated after using this method!
+func (c *Client) SignSequence(ctx context.Context, signedSequence sequence.SignedSequence) ([]byte, error) {
+	return c.call(ctx, "datacom_signSequence", signedSequence)
+}
+
+// GetOffChainData returns data based on it's hash
+func (c *Client) GetOffChainData(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return c.call(ctx, "sync_getOffChainData", hash)
+}
+
+func (c *Client) call(ctx context.Context, method string, parameters ...interface{}) ([]byte, error) {
+	response, err := client.JSONRPCCallWithContext(ctx, c.url, method, parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	var result types.ArgBytes
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package datacommittee
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	ethman "github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// healthScoreWeight is the weight given to the latest request when updating
+	// the rolling health score and latency of a member
+	healthScoreWeight = 0.3
+	// initialHealthScore is the score given to members that haven't been requested yet
+	initialHealthScore = 1
+	// mismatchPenaltyWeight is the weight given to a request answered with data that doesn't
+	// match the expected hash, higher than a failure as the member could be serving wrong data
+	mismatchPenaltyWeight = 0.6
+	// latencyPenaltyWeight is the part of the health lost by a member answering at the slow latency
+	latencyPenaltyWeight = 0.6
+	// healthRecoveryHalfLife is the time it takes for a member to recover half of the health
+	// lost, so the members skipped for being unhealthy are asked again
+	healthRecoveryHalfLife = 10 * time.Minute
+)
+
+// memberHealth is the rolling view of how a data committee member answers requests
+type memberHealth struct {
+	// score is an exponentially weighted success rate between 0 and 1
+	score float64
+	// latency is an exponentially weighted response time of the successful requests
+	latency time.Duration
+	// updatedAt is the time the score and latency were last recovered
+	updatedAt time.Time
+}
+
+// committeeHealth keeps track of the health of every data committee member
+type committeeHealth struct {
+	// slowLatency is the latency at which a member loses latencyPenaltyWeight of its health,
+	// 0 means the latency doesn't affect the health
+	slowLatency time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	members map[common.Address]*memberHealth
+}
+
+func newCommitteeHealth(slowLatency time.Duration) *committeeHealth {
+	return &committeeHealth{
+		slowLatency: slowLatency,
+		now:         time.Now,
+		members:     make(map[common.Address]*memberHealth),
+	}
+}
+
+// get returns the health of a member, recovering the score toward the initial score and the
+// latency toward 0 since the last time it was updated
+func (h *committeeHealth) get(addr common.Address) *memberHealth {
+	now := h.now()
+	m, found := h.members[addr]
+	if !found {
+		m = &memberHealth{score: initialHealthScore, updatedAt: now}
+		h.members[addr] = m
+		return m
+	}
+	if elapsed := now.Sub(m.updatedAt); elapsed > 0 {
+		recovery := math.Pow(0.5, float64(elapsed)/float64(healthRecoveryHalfLife)) //nolint:gomnd
+		m.score = initialHealthScore - (initialHealthScore-m.score)*recovery
+		m.latency = time.Duration(float64(m.latency) * recovery)
+		m.updatedAt = now
+	}
+	return m
+}
+
+// health returns the health of a member: its score lowered by its latency
+func (h *committeeHealth) health(m *memberHealth) float64 {
+	if h.slowLatency <= 0 {
+		return m.score
+	}
+	slowness := float64(m.latency) / float64(h.slowLatency)
+	if slowness > 1 {
+		slowness = 1
+	}
+	return m.score * (1 - latencyPenaltyWeight*slowness)
+}
+
+// recordSuccess updates the health of a member that returned a valid answer
+func (h *committeeHealth) recordSuccess(addr common.Address, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.get(addr)
+	m.score = m.score*(1-healthScoreWeight) + healthScoreWeight
+	if m.latency == 0 {
+		m.latency = latency
+	} else {
+		m.latency = time.Duration(float64(m.latency)*(1-healthScoreWeight) + float64(latency)*healthScoreWeight)
+	}
+}
+
+// recordFailure updates the health of a member that failed or timed out
+func (h *committeeHealth) recordFailure(addr common.Address) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.get(addr)
+	m.score = m.score * (1 - healthScoreWeight)
+}
+
+// recordMismatch updates the health of a member that returned data not matching the expected hash
+func (h *committeeHealth) recordMismatch(addr common.Address) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.get(addr)
+	m.score = m.score * (1 - mismatchPenaltyWeight)
+}
+
+// score returns the current health of a member, its success rate lowered by its latency
+func (h *committeeHealth) score(addr common.Address) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.health(h.get(addr))
+}
+
+// sortByHealth returns a copy of the members sorted from the healthiest to the least healthy.
+// Members with the same health are sorted by latency
+func (h *committeeHealth) sortByHealth(members []ethman.DataCommitteeMember) []ethman.DataCommitteeMember {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sorted := make([]ethman.DataCommitteeMember, len(members))
+	copy(sorted, members)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		mi, mj := h.get(sorted[i].Addr), h.get(sorted[j].Addr)
+		if hi, hj := h.health(mi), h.health(mj); hi != hj {
+			return hi > hj
+		}
+		return mi.latency < mj.latency
+	})
+	return sorted
+}
+
+// amountToRequest returns how many of the members, already sorted by health, should be asked
+// for their signature upfront. Members under minScore, failing or slow, are skipped as long as
+// the required signatures can be reached without them. They are asked again once their health
+// recovers
+func (h *committeeHealth) amountToRequest(sorted []ethman.DataCommitteeMember, requiredSignatures uint64, minScore float64) int {
+	healthy := 0
+	for _, member := range sorted {
+		if h.score(member.Addr) < minScore {
+			break
+		}
+		healthy++
+	}
+	if uint64(healthy) < requiredSignatures {
+		if requiredSignatures > uint64(len(sorted)) {
+			return len(sorted)
+		}
+		return int(requiredSignatures)
+	}
+	return healthy
+}
@@ -0,0 +1,131 @@
+package datacommittee
+
+import (
+	"testing"
+	"time"
+
+	ethman "github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitteeHealthSortByHealth(t *testing.T) {
+	members := []ethman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x1"), URL: "http://member1"},
+		{Addr: common.HexToAddress("0x2"), URL: "http://member2"},
+		{Addr: common.HexToAddress("0x3"), URL: "http://member3"},
+	}
+	h := newCommitteeHealth(0)
+	h.recordFailure(members[0].Addr)
+	h.recordSuccess(members[1].Addr, 2*time.Second)
+	h.recordSuccess(members[2].Addr, time.Second)
+
+	sorted := h.sortByHealth(members)
+	assert.Equal(t, members[2].Addr, sorted[0].Addr)
+	assert.Equal(t, members[1].Addr, sorted[1].Addr)
+	assert.Equal(t, members[0].Addr, sorted[2].Addr)
+	// the original slice is not modified
+	assert.Equal(t, common.HexToAddress("0x1"), members[0].Addr)
+}
+
+func TestCommitteeHealthAmountToRequest(t *testing.T) {
+	members := []ethman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x1")},
+		{Addr: common.HexToAddress("0x2")},
+		{Addr: common.HexToAddress("0x3")},
+	}
+	tcs := []struct {
+		description        string
+		failures           map[common.Address]int
+		requiredSignatures uint64
+		expected           int
+	}{
+		{
+			description:        "all members healthy",
+			requiredSignatures: 2,
+			expected:           3,
+		},
+		{
+			description:        "unhealthy member skipped",
+			failures:           map[common.Address]int{members[2].Addr: 3},
+			requiredSignatures: 2,
+			expected:           2,
+		},
+		{
+			description:        "unhealthy members needed to reach the required signatures",
+			failures:           map[common.Address]int{members[1].Addr: 3, members[2].Addr: 3},
+			requiredSignatures: 2,
+			expected:           2,
+		},
+		{
+			description:        "more required signatures than members",
+			failures:           map[common.Address]int{members[2].Addr: 3},
+			requiredSignatures: 4,
+			expected:           3,
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			h := newCommitteeHealth(0)
+			for addr, n := range tc.failures {
+				for i := 0; i < n; i++ {
+					h.recordFailure(addr)
+				}
+			}
+			sorted := h.sortByHealth(members)
+			assert.Equal(t, tc.expected, h.amountToRequest(sorted, tc.requiredSignatures, 0.5))
+		})
+	}
+}
+
+func TestCommitteeHealthRecovery(t *testing.T) {
+	members := []ethman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x1")},
+		{Addr: common.HexToAddress("0x2")},
+		{Addr: common.HexToAddress("0x3")},
+	}
+	now := time.Now()
+	h := newCommitteeHealth(0)
+	h.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		h.recordFailure(members[2].Addr)
+	}
+	assert.Equal(t, 2, h.amountToRequest(h.sortByHealth(members), 2, 0.5))
+
+	// The skipped member is asked again once its health recovers
+	now = now.Add(healthRecoveryHalfLife / 4)
+	assert.Equal(t, 2, h.amountToRequest(h.sortByHealth(members), 2, 0.5))
+	now = now.Add(healthRecoveryHalfLife)
+	assert.Equal(t, 3, h.amountToRequest(h.sortByHealth(members), 2, 0.5))
+
+	// A new failure starts from the recovered health
+	h.recordFailure(members[2].Addr)
+	assert.Equal(t, 3, h.amountToRequest(h.sortByHealth(members), 2, 0.5))
+}
+
+func TestCommitteeHealthLatency(t *testing.T) {
+	members := []ethman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x1")},
+		{Addr: common.HexToAddress("0x2")},
+		{Addr: common.HexToAddress("0x3")},
+	}
+	now := time.Now()
+	h := newCommitteeHealth(10 * time.Second)
+	h.now = func() time.Time { return now }
+	h.recordSuccess(members[0].Addr, 9*time.Second)
+	h.recordSuccess(members[1].Addr, time.Second)
+	h.recordSuccess(members[2].Addr, 2*time.Second)
+
+	// The slow member answering is ordered last and skipped
+	sorted := h.sortByHealth(members)
+	assert.Equal(t, members[1].Addr, sorted[0].Addr)
+	assert.Equal(t, members[2].Addr, sorted[1].Addr)
+	assert.Equal(t, members[0].Addr, sorted[2].Addr)
+	assert.Less(t, h.score(members[0].Addr), 0.5)
+	assert.Equal(t, 2, h.amountToRequest(sorted, 2, 0.5))
+
+	// and asked again once its latency recovers
+	now = now.Add(2 * healthRecoveryHalfLife)
+	assert.Equal(t, 3, h.amountToRequest(h.sortByHealth(members), 2, 0.5))
+}
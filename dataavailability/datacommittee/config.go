@@ -0,0 +1,23 @@
+package datacommittee
+
+import (
+	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Config represents the configuration of the data committee backend
+type Config struct {
+	// L2Coinbase is the coinbase of the batches signed by the data committee
+	L2Coinbase common.Address
+	// RequestTimeout is the maximum time to wait for a member to answer a single signature or data request. 0 means no timeout
+	RequestTimeout types.Duration
+	// RequestRetries is the amount of times a failed signature request is retried against the same member
+	RequestRetries uint64
+	// RetryBackoff is the time to wait before the first retry to a member, it is doubled after each failed attempt
+	RetryBackoff types.Duration
+	// MinHealthScore is the health score under which a member is not asked for its signature,
+	// as long as the required signatures can be collected without it
+	MinHealthScore float64
+	// RetrievalParallelRequests is the amount of members requested at once for the data of a batch. 0 means all of them
+	RetrievalParallelRequests uint64
+}
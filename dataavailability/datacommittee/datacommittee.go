@@ -0,0 +1,52 @@
+package datacommittee
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
+	"github.com/0xPolygon/cdk-validium-node/event"
+)
+
+// Backend is the data availability backend that relies on the data availability committee.
+// The data of the sequences is signed by the committee members, and retrieved from them
+type Backend struct {
+	cfg           Config
+	privKey       *ecdsa.PrivateKey
+	etherman      ethermanInterface
+	state         stateInterface
+	eventLog      *event.EventLog
+	clientFactory ClientFactoryInterface
+	// committeeHealth tracks how the members answer signature requests
+	committeeHealth *committeeHealth
+	// retrievalHealth tracks how the members answer data requests
+	retrievalHealth *committeeHealth
+}
+
+var _ dataavailability.DataAvailabilityBackend = (*Backend)(nil)
+
+// New creates a data committee backend. The private key of the trusted sequencer is only
+// needed to post sequences, so it can be nil when the backend is only used to retrieve data
+func New(
+	cfg Config,
+	privKey *ecdsa.PrivateKey,
+	etherman ethermanInterface,
+	state stateInterface,
+	eventLog *event.EventLog,
+	clientFactory ClientFactoryInterface,
+) (*Backend, error) {
+	b := &Backend{
+		cfg:             cfg,
+		privKey:         privKey,
+		etherman:        etherman,
+		state:           state,
+		eventLog:        eventLog,
+		clientFactory:   clientFactory,
+		committeeHealth: newCommitteeHealth(cfg.RequestTimeout.Duration),
+		retrievalHealth: newCommitteeHealth(cfg.RequestTimeout.Duration),
+	}
+	// Fail early if the committee can't be read
+	if _, err := etherman.GetCurrentDataCommittee(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,275 @@
+package datacommittee
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/sequence"
+	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBatchL2Data(t *testing.T) {
+	const batchNum uint64 = 5
+	data := []byte("i poli tis Kerkyras einai omorfi")
+	dataHash := crypto.Keccak256Hash(data)
+
+	committee := &etherman.DataCommittee{
+		AddressesHash: common.HexToHash("0x1"),
+		Members: []etherman.DataCommitteeMember{
+			{URL: "0", Addr: common.HexToAddress("0x0")},
+			{URL: "1", Addr: common.HexToAddress("0x1")},
+			{URL: "2", Addr: common.HexToAddress("0x2")},
+		},
+	}
+	const updatedURL = "the time is now"
+	updatedCommittee := &etherman.DataCommittee{
+		AddressesHash: common.HexToHash("0x2"),
+		Members: []etherman.DataCommitteeMember{
+			{URL: updatedURL, Addr: common.HexToAddress("0xff")},
+		},
+	}
+
+	type testCase struct {
+		Name              string
+		ParallelRequests  uint64
+		ExpectedResult    []byte
+		ExpectedError     string
+		SetupHealth       func(h *committeeHealth)
+		SetupMocks        func(etherman *ethermanMock, factory *dataCommitteeClientFactoryMock)
+		AssertHealth      func(t *testing.T, h *committeeHealth)
+		AssertAfterReturn func(t *testing.T)
+	}
+
+	newClient := func(t *testing.T, factory *dataCommitteeClientFactoryMock, url string) *dataCommitteeClientMock {
+		client := newDataCommitteeClientMock(t)
+		factory.On("New", url).Return(client).Once()
+		return client
+	}
+
+	// cancelled is closed once the slow member sees its request cancelled
+	cancelled := make(chan struct{})
+
+	testCases := []testCase{
+		{
+			Name:             "fails if all the members don't answer",
+			ParallelRequests: 1,
+			ExpectedError:    "couldn't get the data from any committee member",
+			SetupMocks: func(etherman *ethermanMock, factory *dataCommitteeClientFactoryMock) {
+				etherman.On("GetCurrentDataCommittee").Return(committee, nil).Once()
+				newClient(t, factory, "0").On("GetOffChainData", mock.Anything, dataHash).Return([]byte("not the correct data"), nil).Once()
+				newClient(t, factory, "1").On("GetOffChainData", mock.Anything, dataHash).Return(nil, errors.New("not today")).Once()
+				newClient(t, factory, "2").On("GetOffChainData", mock.Anything, dataHash).Return([]byte("not the correct data"), nil).Once()
+			},
+			AssertHealth: func(t *testing.T, h *committeeHealth) {
+				// Returning wrong data is penalized more than failing
+				assert.Less(t, h.score(common.HexToAddress("0x0")), h.score(common.HexToAddress("0x1")))
+				assert.Less(t, h.score(common.HexToAddress("0x1")), float64(initialHealthScore))
+			},
+		},
+		{
+			Name:             "succeeds after the 3rd committee member answers correctly",
+			ParallelRequests: 3,
+			ExpectedResult:   data,
+			SetupMocks: func(etherman *ethermanMock, factory *dataCommitteeClientFactoryMock) {
+				etherman.On("GetCurrentDataCommittee").Return(committee, nil).Once()
+				newClient(t, factory, "0").On("GetOffChainData", mock.Anything, dataHash).Return([]byte("not the correct data"), nil).Once()
+				newClient(t, factory, "1").On("GetOffChainData", mock.Anything, dataHash).Return(nil, errors.New("not today")).Once()
+				newClient(t, factory, "2").On("GetOffChainData", mock.Anything, dataHash).
+					Run(func(args mock.Arguments) {
+						// Answer once the other members have failed
+						time.Sleep(50 * time.Millisecond)
+					}).
+					Return(data, nil).Once()
+			},
+			AssertHealth: func(t *testing.T, h *committeeHealth) {
+				assert.Equal(t, float64(initialHealthScore), h.score(common.HexToAddress("0x2")))
+				assert.Less(t, h.score(common.HexToAddress("0x0")), float64(initialHealthScore))
+				assert.Less(t, h.score(common.HexToAddress("0x1")), float64(initialHealthScore))
+			},
+		},
+		{
+			Name:             "requests first the members with the best score",
+			ParallelRequests: 1,
+			ExpectedResult:   data,
+			SetupHealth: func(h *committeeHealth) {
+				h.recordMismatch(common.HexToAddress("0x0"))
+				h.recordFailure(common.HexToAddress("0x1"))
+			},
+			SetupMocks: func(etherman *ethermanMock, factory *dataCommitteeClientFactoryMock) {
+				etherman.On("GetCurrentDataCommittee").Return(committee, nil).Once()
+				newClient(t, factory, "2").On("GetOffChainData", mock.Anything, dataHash).Return(data, nil).Once()
+			},
+		},
+		{
+			Name:             "returns the fastest answer and cancels the rest of the requests",
+			ParallelRequests: 2,
+			ExpectedResult:   data,
+			SetupMocks: func(etherman *ethermanMock, factory *dataCommitteeClientFactoryMock) {
+				etherman.On("GetCurrentDataCommittee").Return(committee, nil).Once()
+				newClient(t, factory, "0").On("GetOffChainData", mock.Anything, dataHash).
+					Run(func(args mock.Arguments) {
+						<-args.Get(0).(context.Context).Done()
+						close(cancelled)
+					}).
+					Return(nil, context.Canceled).Once()
+				newClient(t, factory, "1").On("GetOffChainData", mock.Anything, dataHash).Return(data, nil).Once()
+			},
+			AssertAfterReturn: func(t *testing.T) {
+				select {
+				case <-cancelled:
+				case <-time.After(time.Second):
+					t.Fatal("the slow request was not cancelled")
+				}
+			},
+		},
+		{
+			Name:             "succeeds using the members of the updated committee",
+			ParallelRequests: 1,
+			ExpectedResult:   data,
+			SetupMocks: func(etherman *ethermanMock, factory *dataCommitteeClientFactoryMock) {
+				etherman.On("GetCurrentDataCommittee").Return(updatedCommittee, nil).Once()
+				newClient(t, factory, updatedURL).On("GetOffChainData", mock.Anything, dataHash).Return(data, nil).Once()
+			},
+		},
+		{
+			Name:          "fails if the committee can't be loaded",
+			ExpectedError: "error loading data committee: not today",
+			SetupMocks: func(etherman *ethermanMock, factory *dataCommitteeClientFactoryMock) {
+				etherman.On("GetCurrentDataCommittee").Return(nil, errors.New("not today")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			ethermanMock := newEthermanMock(t)
+			factoryMock := newDataCommitteeClientFactoryMock(t)
+			tc.SetupMocks(ethermanMock, factoryMock)
+
+			b := &Backend{
+				cfg:             Config{RetrievalParallelRequests: tc.ParallelRequests},
+				etherman:        ethermanMock,
+				clientFactory:   factoryMock,
+				committeeHealth: newCommitteeHealth(0),
+				retrievalHealth: newCommitteeHealth(0),
+			}
+			if tc.SetupHealth != nil {
+				tc.SetupHealth(b.retrievalHealth)
+			}
+
+			res, err := b.GetBatchL2Data(context.Background(), batchNum, dataHash)
+			assert.Equal(t, tc.ExpectedResult, res)
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.ExpectedError, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.AssertHealth != nil {
+				tc.AssertHealth(t, b.retrievalHealth)
+			}
+			if tc.AssertAfterReturn != nil {
+				tc.AssertAfterReturn(t)
+			}
+		})
+	}
+}
+
+func TestRequestSignatureTimeout(t *testing.T) {
+	member := etherman.DataCommitteeMember{URL: "0", Addr: common.HexToAddress("0x0")}
+	client := newDataCommitteeClientMock(t)
+	client.On("SignSequence", mock.Anything, mock.Anything).Return(func(ctx context.Context, _ sequence.SignedSequence) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}).Once()
+	factoryMock := newDataCommitteeClientFactoryMock(t)
+	factoryMock.On("New", member.URL).Return(client).Once()
+
+	b := &Backend{
+		cfg:           Config{RequestTimeout: types.NewDuration(50 * time.Millisecond)},
+		clientFactory: factoryMock,
+	}
+	_, err := b.requestSignature(context.Background(), sequence.SignedSequence{}, member)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout after 50ms")
+}
+
+func TestGetBatchL2DataRequestTimeout(t *testing.T) {
+	data := []byte("i poli tis Kerkyras einai omorfi")
+	dataHash := crypto.Keccak256Hash(data)
+	committee := &etherman.DataCommittee{
+		Members: []etherman.DataCommitteeMember{
+			{URL: "0", Addr: common.HexToAddress("0x0")},
+			{URL: "1", Addr: common.HexToAddress("0x1")},
+		},
+	}
+
+	ethermanMock := newEthermanMock(t)
+	ethermanMock.On("GetCurrentDataCommittee").Return(committee, nil).Once()
+	// The first member never answers, so the second one is requested once the request times out
+	hangingClient := newDataCommitteeClientMock(t)
+	hangingClient.On("GetOffChainData", mock.Anything, dataHash).Return(func(ctx context.Context, _ common.Hash) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}).Once()
+	client := newDataCommitteeClientMock(t)
+	client.On("GetOffChainData", mock.Anything, dataHash).Return(data, nil).Once()
+	factoryMock := newDataCommitteeClientFactoryMock(t)
+	factoryMock.On("New", "0").Return(hangingClient).Once()
+	factoryMock.On("New", "1").Return(client).Once()
+
+	b := &Backend{
+		cfg:             Config{RequestTimeout: types.NewDuration(50 * time.Millisecond), RetrievalParallelRequests: 1},
+		etherman:        ethermanMock,
+		clientFactory:   factoryMock,
+		committeeHealth: newCommitteeHealth(0),
+		retrievalHealth: newCommitteeHealth(0),
+	}
+	actual, err := b.GetBatchL2Data(context.Background(), 1, dataHash)
+	require.NoError(t, err)
+	assert.Equal(t, data, actual)
+	assert.Less(t, b.retrievalHealth.score(common.HexToAddress("0x0")), b.retrievalHealth.score(common.HexToAddress("0x1")))
+}
+
+func TestGetBatchL2DataConcurrently(t *testing.T) {
+	data := []byte("i poli tis Kerkyras einai omorfi")
+	dataHash := crypto.Keccak256Hash(data)
+	committee := &etherman.DataCommittee{
+		Members: []etherman.DataCommitteeMember{{URL: "0", Addr: common.HexToAddress("0x0")}},
+	}
+	const requests = 10
+
+	// The committee is read from the etherman cache on every request, the backend keeps no copy of it
+	ethermanMock := newEthermanMock(t)
+	ethermanMock.On("GetCurrentDataCommittee").Return(committee, nil).Times(requests)
+	client := newDataCommitteeClientMock(t)
+	client.On("GetOffChainData", mock.Anything, dataHash).Return(data, nil).Times(requests)
+	factoryMock := newDataCommitteeClientFactoryMock(t)
+	factoryMock.On("New", "0").Return(client).Times(requests)
+	b := &Backend{
+		etherman:        ethermanMock,
+		clientFactory:   factoryMock,
+		committeeHealth: newCommitteeHealth(0),
+		retrievalHealth: newCommitteeHealth(0),
+	}
+
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		go func(batchNum uint64) {
+			_, err := b.GetBatchL2Data(context.Background(), batchNum, dataHash)
+			errs <- err
+		}(uint64(i))
+	}
+	for i := 0; i < requests; i++ {
+		assert.NoError(t, <-errs)
+	}
+}
@@ -0,0 +1,43 @@
+package datacommittee
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-data-availability/sequence"
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// Consumer interfaces required by the package.
+
+// ethermanInterface contains the methods required to read the data committee from L1
+type ethermanInterface interface {
+	GetCurrentDataCommittee() (*etherman.DataCommittee, error)
+	GetLatestDataCommittee() (*etherman.DataCommittee, error)
+}
+
+// stateInterface gathers the methods required to interact with the state
+type stateInterface interface {
+	GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error)
+	AddSignedSequence(ctx context.Context, signedSequence *state.SignedSequence, dbTx pgx.Tx) error
+	GetSignedSequence(ctx context.Context, fromBatchNumber uint64, dbTx pgx.Tx) (*state.SignedSequence, error)
+}
+
+// ClientFactoryInterface creates the clients of the data committee members
+type ClientFactoryInterface interface {
+	New(url string) ClientInterface
+}
+
+// ClientInterface contains the endpoints of a data committee member
+type ClientInterface interface {
+	GetOffChainData(ctx context.Context, hash common.Hash) ([]byte, error)
+	SignSequence(ctx context.Context, signedSequence sequence.SignedSequence) ([]byte, error)
+}
+
+// auditStateInterface gathers the methods required to read the virtual batches to audit
+type auditStateInterface interface {
+	GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error)
+	GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error)
+}
@@ -1,6 +1,6 @@
 // Code generated by mockery v2.22.1. DO NOT EDIT.
 
-package synchronizer
+package datacommittee
 
 import (
 	context "context"
@@ -43,25 +43,25 @@ func (_m *dataCommitteeClientMock) GetOffChainData(ctx context.Context, hash com
 	return r0, r1
 }
 
-// SignSequence provides a mock function with given fields: signedSequence
-func (_m *dataCommitteeClientMock) SignSequence(signedSequence sequence.SignedSequence) ([]byte, error) {
-	ret := _m.Called(signedSequence)
+// SignSequence provides a mock function with given fields: ctx, signedSequence
+func (_m *dataCommitteeClientMock) SignSequence(ctx context.Context, signedSequence sequence.SignedSequence) ([]byte, error) {
+	ret := _m.Called(ctx, signedSequence)
 
 	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func(sequence.SignedSequence) ([]byte, error)); ok {
-		return rf(signedSequence)
+	if rf, ok := ret.Get(0).(func(context.Context, sequence.SignedSequence) ([]byte, error)); ok {
+		return rf(ctx, signedSequence)
 	}
-	if rf, ok := ret.Get(0).(func(sequence.SignedSequence) []byte); ok {
-		r0 = rf(signedSequence)
+	if rf, ok := ret.Get(0).(func(context.Context, sequence.SignedSequence) []byte); ok {
+		r0 = rf(ctx, signedSequence)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(sequence.SignedSequence) error); ok {
-		r1 = rf(signedSequence)
+	if rf, ok := ret.Get(1).(func(context.Context, sequence.SignedSequence) error); ok {
+		r1 = rf(ctx, signedSequence)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,11 +1,8 @@
 // Code generated by mockery v2.22.1. DO NOT EDIT.
 
-package synchronizer
+package datacommittee
 
-import (
-	client "github.com/0xPolygon/cdk-data-availability/client"
-	mock "github.com/stretchr/testify/mock"
-)
+import mock "github.com/stretchr/testify/mock"
 
 // dataCommitteeClientFactoryMock is an autogenerated mock type for the ClientFactoryInterface type
 type dataCommitteeClientFactoryMock struct {
@@ -13,15 +10,15 @@ type dataCommitteeClientFactoryMock struct {
 }
 
 // New provides a mock function with given fields: url
-func (_m *dataCommitteeClientFactoryMock) New(url string) client.ClientInterface {
+func (_m *dataCommitteeClientFactoryMock) New(url string) ClientInterface {
 	ret := _m.Called(url)
 
-	var r0 client.ClientInterface
-	if rf, ok := ret.Get(0).(func(string) client.ClientInterface); ok {
+	var r0 ClientInterface
+	if rf, ok := ret.Get(0).(func(string) ClientInterface); ok {
 		r0 = rf(url)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(client.ClientInterface)
+			r0 = ret.Get(0).(ClientInterface)
 		}
 	}
 
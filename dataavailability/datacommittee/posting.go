@@ -0,0 +1,277 @@
+package datacommittee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/batch"
+	"github.com/0xPolygon/cdk-data-availability/sequence"
+	ethman "github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/etherman/types"
+	jTypes "github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errInvalidSigner = errors.New("invalid signer")
+
+type signatureMsg struct {
+	addr      common.Address
+	signature []byte
+	err       error
+}
+
+// PostSequence collects the signatures of the committee members for the sequences, returning
+// them along with the committee addresses in the format expected by the data committee contract
+func (b *Backend) PostSequence(ctx context.Context, sequences []types.Sequence) ([]byte, error) {
+	if b.privKey == nil {
+		return nil, errors.New("the private key of the trusted sequencer is required to post sequences")
+	}
+
+	// Get the committee registered on L1, which is the one the data committee contract checks
+	committee, err := b.etherman.GetLatestDataCommittee()
+	if err != nil {
+		return nil, err
+	}
+
+	sequence, err := b.buildDataCommitteeSequence(ctx, sequences)
+	if err != nil {
+		return nil, err
+	}
+	signedHash := common.BytesToHash(sequence.HashToSign())
+	fromBatchNum := sequences[0].BatchNumber
+	toBatchNum := sequences[len(sequences)-1].BatchNumber
+
+	// Reuse the signatures collected previously for the same sequence and committee
+	stored, err := b.state.GetSignedSequence(ctx, fromBatchNum, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return nil, err
+	}
+	if stored != nil {
+		if stored.ToBatchNumber == toBatchNum &&
+			stored.SignedHash == signedHash &&
+			stored.OldAccInputHash == sequence.OldAccInputHash &&
+			stored.CommitteeHash == committee.AddressesHash {
+			err = verifySignaturesAndAddrs(signedHash, stored.SignaturesAndAddrs, committee)
+			if err == nil {
+				log.Infof("reusing stored data committee signatures for batches %d to %d", fromBatchNum, toBatchNum)
+				return stored.SignaturesAndAddrs, nil
+			}
+			log.Warnf("stored data committee signatures for batches %d to %d are invalid, requesting them again. Err: %v", fromBatchNum, toBatchNum, err)
+		} else {
+			log.Infof("stored data committee signatures for batches %d to %d are outdated, requesting them again", stored.FromBatchNumber, stored.ToBatchNumber)
+		}
+	}
+
+	signaturesAndAddrs, err := b.collectSignaturesAndAddrs(ctx, sequence, committee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the blob locally instead of finding out with a reverted L1 tx
+	err = verifySignaturesAndAddrs(signedHash, signaturesAndAddrs, committee)
+	if err != nil {
+		b.logInvalidSignatures(ctx, fromBatchNum, toBatchNum, signedHash, committee, err)
+		return nil, err
+	}
+
+	// Persist the signatures so they can be reused if sending the sequence to L1 fails
+	err = b.state.AddSignedSequence(ctx, &state.SignedSequence{
+		FromBatchNumber:    fromBatchNum,
+		ToBatchNumber:      toBatchNum,
+		OldAccInputHash:    sequence.OldAccInputHash,
+		SignedHash:         signedHash,
+		SignaturesAndAddrs: signaturesAndAddrs,
+		CommitteeHash:      committee.AddressesHash,
+	}, nil)
+	if err != nil {
+		log.Warnf("failed to store data committee signatures for batches %d to %d, err: %v", fromBatchNum, toBatchNum, err)
+	}
+	return signaturesAndAddrs, nil
+}
+
+// buildDataCommitteeSequence builds the sequence that is signed by the data committee
+func (b *Backend) buildDataCommitteeSequence(ctx context.Context, sequences []types.Sequence) (sequence.Sequence, error) {
+	// Get last accInputHash
+	var accInputHash common.Hash
+	if sequences[0].BatchNumber != 0 {
+		prevBatch, err := b.state.GetBatchByNumber(ctx, sequences[0].BatchNumber-1, nil)
+		if err != nil {
+			return sequence.Sequence{}, err
+		}
+		accInputHash = prevBatch.AccInputHash
+	}
+
+	seqToSign := sequence.Sequence{
+		Batches:         []batch.Batch{},
+		OldAccInputHash: accInputHash,
+	}
+	for _, seq := range sequences {
+		seqToSign.Batches = append(seqToSign.Batches, batch.Batch{
+			Number:         jTypes.ArgUint64(seq.BatchNumber),
+			GlobalExitRoot: seq.GlobalExitRoot,
+			Timestamp:      jTypes.ArgUint64(seq.Timestamp),
+			Coinbase:       b.cfg.L2Coinbase,
+			L2Data:         seq.BatchL2Data,
+		})
+	}
+	return seqToSign, nil
+}
+
+// collectSignaturesAndAddrs requests the signatures of the committee members until the required
+// amount is reached, and returns them concatenated with the addresses of the committee
+func (b *Backend) collectSignaturesAndAddrs(ctx context.Context, sequence sequence.Sequence, committee *ethman.DataCommittee) ([]byte, error) {
+	// Authenticate as trusted sequencer by signing the sequences
+	signedSequence, err := sequence.Sign(b.privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request signatures in parallel, starting with the healthiest members
+	members := b.committeeHealth.sortByHealth(committee.Members)
+	nextMember := b.committeeHealth.amountToRequest(members, committee.RequiredSignatures, b.cfg.MinHealthScore)
+	ch := make(chan signatureMsg, len(members))
+	signatureCtx, cancelSignatureCollection := context.WithCancel(ctx)
+	defer cancelSignatureCollection()
+	for _, member := range members[:nextMember] {
+		go b.requestSignatureFromMember(signatureCtx, *signedSequence, member, ch)
+	}
+	pending := nextMember
+	if nextMember < len(members) {
+		log.Infof("skipping %d unhealthy data committee members", len(members)-nextMember)
+	}
+
+	// Collect signatures
+	msgs := []signatureMsg{}
+	var collectedSignatures uint64
+	for collectedSignatures < committee.RequiredSignatures {
+		var msg signatureMsg
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case msg = <-ch:
+		}
+		pending--
+		if msg.err != nil {
+			log.Errorf("error when trying to get signature from %s: %s", msg.addr, msg.err)
+			// Ask the skipped members if the pending requests are not enough anymore
+			for collectedSignatures+uint64(pending) < committee.RequiredSignatures && nextMember < len(members) {
+				member := members[nextMember]
+				log.Infof("requesting signature from skipped member %s, health score %f", member.Addr.Hex(), b.committeeHealth.score(member.Addr))
+				go b.requestSignatureFromMember(signatureCtx, *signedSequence, member, ch)
+				nextMember++
+				pending++
+			}
+			if collectedSignatures+uint64(pending) < committee.RequiredSignatures {
+				return nil, errors.New("too many members failed to send their signature")
+			}
+			continue
+		}
+		log.Infof("received signature from %s", msg.addr)
+		collectedSignatures++
+		msgs = append(msgs, msg)
+	}
+
+	return buildSignaturesAndAddrs(signatureMsgs(msgs), committee.Members), nil
+}
+
+// requestSignatureFromMember asks a member to sign the sequence, retrying with an exponential backoff
+// on failure. The result is always sent through ch
+func (b *Backend) requestSignatureFromMember(ctx context.Context, signedSequence sequence.SignedSequence, member ethman.DataCommitteeMember, ch chan signatureMsg) {
+	var err error
+	backoff := b.cfg.RetryBackoff.Duration
+	for attempt := uint64(0); attempt <= b.cfg.RequestRetries; attempt++ {
+		if attempt > 0 {
+			log.Infof("retrying signature request to %s in %s, attempt %d", member.Addr.Hex(), backoff, attempt)
+			select {
+			case <-ctx.Done():
+				ch <- signatureMsg{addr: member.Addr, err: ctx.Err()}
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		start := time.Now()
+		var signature []byte
+		signature, err = b.requestSignature(ctx, signedSequence, member)
+		if err == nil {
+			b.committeeHealth.recordSuccess(member.Addr, time.Since(start))
+			ch <- signatureMsg{
+				addr:      member.Addr,
+				signature: signature,
+			}
+			return
+		}
+		if ctx.Err() != nil {
+			// The collection was cancelled, this is not the member's fault
+			ch <- signatureMsg{addr: member.Addr, err: ctx.Err()}
+			return
+		}
+		b.committeeHealth.recordFailure(member.Addr)
+		if errors.Is(err, errInvalidSigner) {
+			break
+		}
+	}
+	ch <- signatureMsg{
+		addr: member.Addr,
+		err:  err,
+	}
+}
+
+// requestSignature sends a single signature request to a member and verifies the returned signature.
+// The request is canceled after the RequestTimeout
+func (b *Backend) requestSignature(ctx context.Context, signedSequence sequence.SignedSequence, member ethman.DataCommitteeMember) ([]byte, error) {
+	if b.cfg.RequestTimeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.cfg.RequestTimeout.Duration)
+		defer cancel()
+	}
+	c := b.clientFactory.New(member.URL)
+	log.Infof("sending request to sign the sequence to %s at %s", member.Addr.Hex(), member.URL)
+	signature, err := c.SignSequence(ctx, signedSequence)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timeout after %s waiting for the signature of %s", b.cfg.RequestTimeout.Duration, member.Addr.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// verify returned signature
+	signedSequence.Signature = signature
+	signer, err := signedSequence.Signer()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidSigner, err)
+	}
+	if signer != member.Addr {
+		return nil, fmt.Errorf("%w. Expected %s, actual %s", errInvalidSigner, member.Addr.Hex(), signer.Hex())
+	}
+	return signature, nil
+}
+
+func buildSignaturesAndAddrs(msgs signatureMsgs, members []ethman.DataCommitteeMember) []byte {
+	res := []byte{}
+	sort.Sort(msgs)
+	for _, msg := range msgs {
+		log.Debugf("adding signature %s from %s", common.Bytes2Hex(msg.signature), msg.addr.Hex())
+		res = append(res, msg.signature...)
+	}
+	for _, member := range members {
+		log.Debugf("adding addr %s", common.Bytes2Hex(member.Addr.Bytes()))
+		res = append(res, member.Addr.Bytes()...)
+	}
+	log.Debugf("full res %s", common.Bytes2Hex(res))
+	return res
+}
+
+type signatureMsgs []signatureMsg
+
+func (s signatureMsgs) Len() int { return len(s) }
+func (s signatureMsgs) Less(i, j int) bool {
+	return strings.ToUpper(s[i].addr.Hex()) < strings.ToUpper(s[j].addr.Hex())
+}
+func (s signatureMsgs) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
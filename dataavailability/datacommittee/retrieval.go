@@ -0,0 +1,103 @@
+package datacommittee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const unexpectedHashTemplate = "missmatch on transaction data for batch num %d. Expected hash %s, actual hash: %s"
+
+// offChainDataResult is the answer of a committee member to a data request
+type offChainDataResult struct {
+	member  etherman.DataCommitteeMember
+	data    []byte
+	err     error
+	latency time.Duration
+}
+
+// GetBatchL2Data requests the data of a batch to the committee members, starting with the ones
+// with the best retrieval score. Up to RetrievalParallelRequests members are requested at once,
+// the first data matching the expected hash is returned and the rest of the requests are cancelled
+func (b *Backend) GetBatchL2Data(ctx context.Context, batchNum uint64, expectedTransactionsHash common.Hash) ([]byte, error) {
+	// The committee is cached by the etherman, which reads it again from L1 when the synchronizer
+	// processes a CommitteeUpdated event or the cached one expires
+	committee, err := b.etherman.GetCurrentDataCommittee()
+	if err != nil {
+		return nil, fmt.Errorf("error loading data committee: %s", err)
+	}
+	var members []etherman.DataCommitteeMember
+	if committee != nil {
+		members = b.retrievalHealth.sortByHealth(committee.Members)
+	}
+	parallelRequests := len(members)
+	if b.cfg.RetrievalParallelRequests > 0 && b.cfg.RetrievalParallelRequests < uint64(parallelRequests) {
+		parallelRequests = int(b.cfg.RetrievalParallelRequests)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Buffered so the requests that are still running when the data is found don't block
+	results := make(chan offChainDataResult, len(members))
+	request := func(member etherman.DataCommitteeMember) {
+		log.Infof("trying to get data from %s at %s", member.Addr.Hex(), member.URL)
+		start := time.Now()
+		data, err := b.requestData(ctx, member, expectedTransactionsHash)
+		results <- offChainDataResult{member: member, data: data, err: err, latency: time.Since(start)}
+	}
+
+	nextMember := 0
+	for ; nextMember < parallelRequests; nextMember++ {
+		go request(members[nextMember])
+	}
+	for pending := parallelRequests; pending > 0; pending-- {
+		result := <-results
+		member := result.member
+		if result.err != nil {
+			log.Warnf(
+				"error getting data from DAC node %s at %s: %s",
+				member.Addr.Hex(), member.URL, result.err,
+			)
+			b.retrievalHealth.recordFailure(member.Addr)
+		} else if actualTransactionsHash := crypto.Keccak256Hash(result.data); actualTransactionsHash != expectedTransactionsHash {
+			unexpectedHash := fmt.Errorf(
+				unexpectedHashTemplate, batchNum, expectedTransactionsHash, actualTransactionsHash,
+			)
+			log.Warnf(
+				"error getting data from DAC node %s at %s: %s",
+				member.Addr.Hex(), member.URL, unexpectedHash,
+			)
+			b.retrievalHealth.recordMismatch(member.Addr)
+		} else {
+			b.retrievalHealth.recordSuccess(member.Addr, result.latency)
+			return result.data, nil
+		}
+
+		if nextMember < len(members) {
+			go request(members[nextMember])
+			nextMember++
+			pending++
+		}
+	}
+	return nil, fmt.Errorf("couldn't get the data from any committee member")
+}
+
+// requestData sends a single data request to a member. The request is canceled after the RequestTimeout
+func (b *Backend) requestData(ctx context.Context, member etherman.DataCommitteeMember, hash common.Hash) ([]byte, error) {
+	if b.cfg.RequestTimeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.cfg.RequestTimeout.Duration)
+		defer cancel()
+	}
+	data, err := b.clientFactory.New(member.URL).GetOffChainData(ctx, hash)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timeout after %s waiting for the data of %s", b.cfg.RequestTimeout.Duration, member.Addr.Hex())
+	}
+	return data, err
+}
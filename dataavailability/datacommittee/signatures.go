@@ -0,0 +1,153 @@
+package datacommittee
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ethman "github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	signatureSize = 65
+	addrSize      = common.AddressLength
+)
+
+// Errors mirroring the ones of the data committee smart contract
+var (
+	// ErrUnexpectedAddrsBytesLength is returned when the size of the blob doesn't fit the required signatures plus a set of addresses
+	ErrUnexpectedAddrsBytesLength = errors.New("UnexpectedAddrsBytesLength")
+	// ErrUnexpectedCommitteeHash is returned when the hash of the addresses doesn't match the committee hash
+	ErrUnexpectedCommitteeHash = errors.New("UnexpectedCommitteeHash")
+	// ErrWrongAddrOrder is returned when the addresses are not sorted in ascending order
+	ErrWrongAddrOrder = errors.New("WrongAddrOrder")
+	// ErrCommitteeAddressDoesntExist is returned when a signer is not a committee member, or the
+	// signatures are not sorted in the same order as the addresses
+	ErrCommitteeAddressDoesntExist = errors.New("CommitteeAddressDoesntExist")
+)
+
+// verifySignaturesAndAddrs checks the signatures and addresses blob the same way the data committee
+// smart contract does, so a blob that would make the sequence tx revert is detected before sending it
+func verifySignaturesAndAddrs(signedHash common.Hash, signaturesAndAddrs []byte, committee *ethman.DataCommittee) error {
+	splitByte := signatureSize * int(committee.RequiredSignatures)
+	if len(signaturesAndAddrs) < splitByte || (len(signaturesAndAddrs)-splitByte)%addrSize != 0 {
+		return fmt.Errorf("%w: %d bytes for %d required signatures", ErrUnexpectedAddrsBytesLength, len(signaturesAndAddrs), committee.RequiredSignatures)
+	}
+
+	addrsBytes := signaturesAndAddrs[splitByte:]
+	if addrsHash := crypto.Keccak256Hash(addrsBytes); addrsHash != committee.AddressesHash {
+		return fmt.Errorf("%w: expected %s, actual %s", ErrUnexpectedCommitteeHash, committee.AddressesHash.Hex(), addrsHash.Hex())
+	}
+
+	addrs := make([]common.Address, 0, len(addrsBytes)/addrSize)
+	for i := 0; i < len(addrsBytes); i += addrSize {
+		addr := common.BytesToAddress(addrsBytes[i : i+addrSize])
+		if len(addrs) > 0 && bytes.Compare(addr.Bytes(), addrs[len(addrs)-1].Bytes()) <= 0 {
+			return fmt.Errorf("%w: %s after %s", ErrWrongAddrOrder, addr.Hex(), addrs[len(addrs)-1].Hex())
+		}
+		addrs = append(addrs, addr)
+	}
+
+	// Every signer must be found after the address matched by the previous signature
+	lastAddrIndexUsed := 0
+	for i := 0; i < int(committee.RequiredSignatures); i++ {
+		signer, err := recoverSigner(signedHash, signaturesAndAddrs[i*signatureSize:(i+1)*signatureSize])
+		if err != nil {
+			return fmt.Errorf("%w: failed to recover signer of signature %d: %s", ErrCommitteeAddressDoesntExist, i, err)
+		}
+		found := false
+		for j := lastAddrIndexUsed; j < len(addrs); j++ {
+			if addrs[j] == signer {
+				lastAddrIndexUsed = j + 1
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: signer %s of signature %d not found in the remaining committee addresses", ErrCommitteeAddressDoesntExist, signer.Hex(), i)
+		}
+	}
+	return nil
+}
+
+// Signers returns the addresses that produced the signatures of a signatures and addresses blob
+func Signers(signedHash common.Hash, signaturesAndAddrs []byte, requiredSignatures uint64) ([]common.Address, error) {
+	if len(signaturesAndAddrs) < signatureSize*int(requiredSignatures) {
+		return nil, fmt.Errorf("%w: %d bytes for %d required signatures", ErrUnexpectedAddrsBytesLength, len(signaturesAndAddrs), requiredSignatures)
+	}
+	signers := make([]common.Address, 0, requiredSignatures)
+	for i := 0; i < int(requiredSignatures); i++ {
+		signer, err := recoverSigner(signedHash, signaturesAndAddrs[i*signatureSize:(i+1)*signatureSize])
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover signer of signature %d: %w", i, err)
+		}
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
+
+// recoverSigner returns the address that produced the signature, which uses 27/28 as recovery id
+func recoverSigner(signedHash common.Hash, signature []byte) (common.Address, error) {
+	sig := make([]byte, signatureSize)
+	copy(sig, signature)
+	if sig[64] >= 27 { //nolint:gomnd
+		sig[64] -= 27
+	}
+	pubKey, err := crypto.SigToPub(signedHash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// invalidSignaturesEvent is the structured payload of the event logged when the signatures are rejected locally
+type invalidSignaturesEvent struct {
+	FromBatchNumber    uint64 `json:"fromBatchNumber"`
+	ToBatchNumber      uint64 `json:"toBatchNumber"`
+	SignedHash         string `json:"signedHash"`
+	CommitteeHash      string `json:"committeeHash"`
+	RequiredSignatures uint64 `json:"requiredSignatures"`
+	Reason             string `json:"reason"`
+	Error              string `json:"error"`
+}
+
+// logInvalidSignatures stores an event explaining why the signatures and addresses blob was rejected
+func (b *Backend) logInvalidSignatures(ctx context.Context, fromBatchNum, toBatchNum uint64, signedHash common.Hash, committee *ethman.DataCommittee, err error) {
+	reason := "unknown"
+	for _, knownErr := range []error{ErrUnexpectedAddrsBytesLength, ErrUnexpectedCommitteeHash, ErrWrongAddrOrder, ErrCommitteeAddressDoesntExist} {
+		if errors.Is(err, knownErr) {
+			reason = knownErr.Error()
+			break
+		}
+	}
+	log.Errorf("data committee signatures for batches %d to %d would be rejected by L1 (%s): %v", fromBatchNum, toBatchNum, reason, err)
+	if b.eventLog == nil {
+		return
+	}
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Sequence_Sender,
+		Level:       event.Level_Error,
+		EventID:     event.EventID_InvalidDataCommitteeSignatures,
+		Description: fmt.Sprintf("signatures for batches %d to %d rejected locally: %s", fromBatchNum, toBatchNum, reason),
+		Json: invalidSignaturesEvent{
+			FromBatchNumber:    fromBatchNum,
+			ToBatchNumber:      toBatchNum,
+			SignedHash:         signedHash.Hex(),
+			CommitteeHash:      committee.AddressesHash.Hex(),
+			RequiredSignatures: committee.RequiredSignatures,
+			Reason:             reason,
+			Error:              err.Error(),
+		},
+	}
+	if eventErr := b.eventLog.LogEvent(ctx, ev); eventErr != nil {
+		log.Errorf("error storing invalid data committee signatures event: %v", eventErr)
+	}
+}
@@ -0,0 +1,119 @@
+package datacommittee
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"sort"
+	"testing"
+
+	ethman "github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMember struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func newTestCommittee(t *testing.T, nMembers int, requiredSignatures uint64) ([]testMember, *ethman.DataCommittee) {
+	members := make([]testMember, nMembers)
+	for i := range members {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		members[i] = testMember{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return bytes.Compare(members[i].addr.Bytes(), members[j].addr.Bytes()) < 0
+	})
+	committee := &ethman.DataCommittee{RequiredSignatures: requiredSignatures}
+	addrsBytes := []byte{}
+	for _, m := range members {
+		committee.Members = append(committee.Members, ethman.DataCommitteeMember{Addr: m.addr})
+		addrsBytes = append(addrsBytes, m.addr.Bytes()...)
+	}
+	committee.AddressesHash = crypto.Keccak256Hash(addrsBytes)
+	return members, committee
+}
+
+func signHash(t *testing.T, hash common.Hash, key *ecdsa.PrivateKey) []byte {
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+	sig[64] += 27
+	return sig
+}
+
+func TestVerifySignaturesAndAddrs(t *testing.T) {
+	signedHash := common.HexToHash("0x1234")
+	members, committee := newTestCommittee(t, 3, 2)
+	msgs := signatureMsgs{
+		{addr: members[2].addr, signature: signHash(t, signedHash, members[2].key)},
+		{addr: members[0].addr, signature: signHash(t, signedHash, members[0].key)},
+	}
+	valid := buildSignaturesAndAddrs(msgs, committee.Members)
+	require.NoError(t, verifySignaturesAndAddrs(signedHash, valid, committee))
+
+	t.Run("unexpected length", func(t *testing.T) {
+		err := verifySignaturesAndAddrs(signedHash, valid[:len(valid)-1], committee)
+		assert.ErrorIs(t, err, ErrUnexpectedAddrsBytesLength)
+	})
+
+	t.Run("unexpected committee hash", func(t *testing.T) {
+		wrongCommittee := *committee
+		wrongCommittee.AddressesHash = common.HexToHash("0x1")
+		err := verifySignaturesAndAddrs(signedHash, valid, &wrongCommittee)
+		assert.ErrorIs(t, err, ErrUnexpectedCommitteeHash)
+	})
+
+	t.Run("wrong addr order", func(t *testing.T) {
+		blob := append([]byte{}, valid[:2*signatureSize]...)
+		blob = append(blob, members[1].addr.Bytes()...)
+		blob = append(blob, members[0].addr.Bytes()...)
+		blob = append(blob, members[2].addr.Bytes()...)
+		unsortedCommittee := *committee
+		unsortedCommittee.AddressesHash = crypto.Keccak256Hash(blob[2*signatureSize:])
+		err := verifySignaturesAndAddrs(signedHash, blob, &unsortedCommittee)
+		assert.ErrorIs(t, err, ErrWrongAddrOrder)
+	})
+
+	t.Run("signatures not sorted", func(t *testing.T) {
+		blob := append([]byte{}, valid[signatureSize:2*signatureSize]...)
+		blob = append(blob, valid[:signatureSize]...)
+		blob = append(blob, valid[2*signatureSize:]...)
+		err := verifySignaturesAndAddrs(signedHash, blob, committee)
+		assert.ErrorIs(t, err, ErrCommitteeAddressDoesntExist)
+	})
+
+	t.Run("signer not in committee", func(t *testing.T) {
+		outsider, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		blob := append([]byte{}, valid...)
+		copy(blob[signatureSize:], signHash(t, signedHash, outsider))
+		err = verifySignaturesAndAddrs(signedHash, blob, committee)
+		assert.ErrorIs(t, err, ErrCommitteeAddressDoesntExist)
+	})
+
+	t.Run("signature of another hash", func(t *testing.T) {
+		err := verifySignaturesAndAddrs(common.HexToHash("0x5678"), valid, committee)
+		assert.ErrorIs(t, err, ErrCommitteeAddressDoesntExist)
+	})
+}
+
+func TestSigners(t *testing.T) {
+	signedHash := common.HexToHash("0x1234")
+	members, committee := newTestCommittee(t, 3, 2)
+	msgs := signatureMsgs{
+		{addr: members[2].addr, signature: signHash(t, signedHash, members[2].key)},
+		{addr: members[0].addr, signature: signHash(t, signedHash, members[0].key)},
+	}
+	blob := buildSignaturesAndAddrs(msgs, committee.Members)
+
+	signers, err := Signers(signedHash, blob, committee.RequiredSignatures)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{members[0].addr, members[2].addr}, signers)
+
+	_, err = Signers(signedHash, blob[:signatureSize], committee.RequiredSignatures)
+	assert.ErrorIs(t, err, ErrUnexpectedAddrsBytesLength)
+}
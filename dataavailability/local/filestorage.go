@@ -0,0 +1,47 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	dirPermissions  = 0750
+	filePermissions = 0640
+)
+
+// fileStorage stores the data of every batch in a file named after its hash
+type fileStorage struct {
+	path string
+}
+
+func newFileStorage(path string) (*fileStorage, error) {
+	if err := os.MkdirAll(path, dirPermissions); err != nil {
+		return nil, err
+	}
+	return &fileStorage{path: path}, nil
+}
+
+// Store writes the data of a batch. The file is renamed once written, so a
+// partially written file is never read
+func (s *fileStorage) Store(ctx context.Context, hash common.Hash, data []byte) error {
+	fileName := filepath.Join(s.path, hash.Hex())
+	tmpFileName := fileName + ".tmp"
+	if err := os.WriteFile(tmpFileName, data, filePermissions); err != nil {
+		return err
+	}
+	return os.Rename(tmpFileName, fileName)
+}
+
+// Get reads the data of a batch
+func (s *fileStorage) Get(ctx context.Context, hash common.Hash) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(s.path, hash.Hex()))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
@@ -0,0 +1,85 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
+	"github.com/0xPolygon/cdk-validium-node/etherman/types"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+var (
+	// ErrNotFound is returned when the data of a batch is not stored
+	ErrNotFound = errors.New("batch data not found")
+	// ErrUnknownStorage is returned when the configured storage is not supported
+	ErrUnknownStorage = errors.New("unknown local data availability storage")
+)
+
+// Storage is where the local backend keeps the batches data, indexed by its hash
+type Storage interface {
+	Store(ctx context.Context, hash common.Hash, data []byte) error
+	Get(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// Backend is a data availability backend that stores the data of the batches locally.
+// It doesn't produce any attestation, so it requires a data committee on L1 without
+// members and with 0 required signatures
+type Backend struct {
+	storage Storage
+}
+
+var _ dataavailability.DataAvailabilityBackend = (*Backend)(nil)
+
+// New creates a local backend using the configured storage. The db is only used by the Postgres storage
+func New(cfg dataavailability.LocalConfig, db *pgxpool.Pool) (*Backend, error) {
+	s, err := NewStorage(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{storage: s}, nil
+}
+
+// NewStorage creates the configured storage. The db is only used by the Postgres storage
+func NewStorage(cfg dataavailability.LocalConfig, db *pgxpool.Pool) (Storage, error) {
+	switch cfg.Storage {
+	case dataavailability.LocalStoragePostgres:
+		return newPostgresStorage(db), nil
+	case dataavailability.LocalStorageFilesystem:
+		fs, err := newFileStorage(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		return fs, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStorage, cfg.Storage)
+	}
+}
+
+// PostSequence stores the data of every batch of the sequences
+func (b *Backend) PostSequence(ctx context.Context, sequences []types.Sequence) ([]byte, error) {
+	for _, seq := range sequences {
+		hash := crypto.Keccak256Hash(seq.BatchL2Data)
+		if err := b.storage.Store(ctx, hash, seq.BatchL2Data); err != nil {
+			return nil, fmt.Errorf("failed to store data of batch %d: %w", seq.BatchNumber, err)
+		}
+		log.Debugf("stored data of batch %d locally, hash %s", seq.BatchNumber, hash.String())
+	}
+	return []byte{}, nil
+}
+
+// GetBatchL2Data returns the stored data of a batch
+func (b *Backend) GetBatchL2Data(ctx context.Context, batchNum uint64, hash common.Hash) ([]byte, error) {
+	data, err := b.storage.Get(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data of batch %d: %w", batchNum, err)
+	}
+	if actualHash := crypto.Keccak256Hash(data); actualHash != hash {
+		return nil, fmt.Errorf("missmatch on stored data for batch num %d. Expected hash %s, actual hash: %s", batchNum, hash, actualHash)
+	}
+	return data, nil
+}
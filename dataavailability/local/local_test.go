@@ -0,0 +1,43 @@
+package local
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
+	"github.com/0xPolygon/cdk-validium-node/etherman/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemBackend(t *testing.T) {
+	ctx := context.Background()
+	b, err := New(dataavailability.LocalConfig{
+		Storage: dataavailability.LocalStorageFilesystem,
+		Path:    t.TempDir(),
+	}, nil)
+	require.NoError(t, err)
+
+	sequences := []types.Sequence{
+		{BatchNumber: 1, BatchL2Data: []byte("batch 1")},
+		{BatchNumber: 2, BatchL2Data: []byte("batch 2")},
+	}
+	attestation, err := b.PostSequence(ctx, sequences)
+	require.NoError(t, err)
+	assert.Empty(t, attestation)
+
+	for _, seq := range sequences {
+		data, err := b.GetBatchL2Data(ctx, seq.BatchNumber, crypto.Keccak256Hash(seq.BatchL2Data))
+		require.NoError(t, err)
+		assert.Equal(t, seq.BatchL2Data, data)
+	}
+
+	_, err = b.GetBatchL2Data(ctx, 3, crypto.Keccak256Hash([]byte("batch 3")))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestUnknownStorage(t *testing.T) {
+	_, err := New(dataavailability.LocalConfig{Storage: "S3"}, nil)
+	assert.ErrorIs(t, err, ErrUnknownStorage)
+}
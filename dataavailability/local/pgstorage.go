@@ -0,0 +1,37 @@
+package local
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// postgresStorage stores the batches data in the state DB
+type postgresStorage struct {
+	db *pgxpool.Pool
+}
+
+func newPostgresStorage(db *pgxpool.Pool) *postgresStorage {
+	return &postgresStorage{db: db}
+}
+
+// Store persists the data of a batch, doing nothing if it's already stored
+func (s *postgresStorage) Store(ctx context.Context, hash common.Hash, data []byte) error {
+	const storeSQL = "INSERT INTO state.local_batch_data (hash, data) VALUES ($1, $2) ON CONFLICT (hash) DO NOTHING"
+	_, err := s.db.Exec(ctx, storeSQL, hash.String(), data)
+	return err
+}
+
+// Get loads the data of a batch
+func (s *postgresStorage) Get(ctx context.Context, hash common.Hash) ([]byte, error) {
+	const getSQL = "SELECT data FROM state.local_batch_data WHERE hash = $1"
+	var data []byte
+	err := s.db.QueryRow(ctx, getSQL, hash.String()).Scan(&data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.signed_sequence
+(
+    from_batch_num       BIGINT PRIMARY KEY,
+    to_batch_num         BIGINT NOT NULL,
+    old_acc_input_hash   VARCHAR NOT NULL,
+    signed_hash          VARCHAR NOT NULL,
+    signatures_and_addrs BYTEA NOT NULL,
+    committee_hash       VARCHAR NOT NULL,
+    created_at           TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.signed_sequence;
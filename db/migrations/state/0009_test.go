@@ -0,0 +1,40 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the table to store the sequences signed by the data committee
+type migrationTest0009 struct{}
+
+func (m migrationTest0009) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0009) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const insertSignedSequence = `INSERT INTO state.signed_sequence (
+		from_batch_num, to_batch_num, old_acc_input_hash, signed_hash, signatures_and_addrs, committee_hash) VALUES (
+		1, 10, '0x01', '0x02', '\x0304', '0x05'
+	);`
+	_, err := db.Exec(insertSignedSequence)
+	assert.NoError(t, err)
+
+	_, err = db.Exec(insertSignedSequence)
+	assert.Error(t, err)
+}
+
+func (m migrationTest0009) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const insertSignedSequence = `INSERT INTO state.signed_sequence (
+		from_batch_num, to_batch_num, old_acc_input_hash, signed_hash, signatures_and_addrs, committee_hash) VALUES (
+		1, 10, '0x01', '0x02', '\x0304', '0x05'
+	);`
+	_, err := db.Exec(insertSignedSequence)
+	assert.Error(t, err)
+}
+
+func TestMigration0009(t *testing.T) {
+	runMigrationTest(t, 9, migrationTest0009{})
+}
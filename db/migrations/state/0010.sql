@@ -0,0 +1,9 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.local_batch_data
+(
+    hash VARCHAR PRIMARY KEY,
+    data BYTEA NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.local_batch_data;
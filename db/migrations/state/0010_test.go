@@ -0,0 +1,34 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the table used by the local data availability backend
+type migrationTest0010 struct{}
+
+func (m migrationTest0010) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0010) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const insertBatchData = `INSERT INTO state.local_batch_data (hash, data) VALUES ('0x01', '\x0203');`
+	_, err := db.Exec(insertBatchData)
+	assert.NoError(t, err)
+
+	_, err = db.Exec(insertBatchData)
+	assert.Error(t, err)
+}
+
+func (m migrationTest0010) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const insertBatchData = `INSERT INTO state.local_batch_data (hash, data) VALUES ('0x01', '\x0203');`
+	_, err := db.Exec(insertBatchData)
+	assert.Error(t, err)
+}
+
+func TestMigration0010(t *testing.T) {
+	runMigrationTest(t, 10, migrationTest0010{})
+}
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.trusted_divergence
+(
+    id                  SERIAL PRIMARY KEY,
+    timestamp           TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    batch_num           BIGINT NOT NULL,
+    kind                VARCHAR NOT NULL,
+    halted              BOOLEAN NOT NULL DEFAULT FALSE,
+    expected_state_root VARCHAR NOT NULL,
+    actual_state_root   VARCHAR NOT NULL,
+    expected_ger        VARCHAR NOT NULL,
+    actual_ger          VARCHAR NOT NULL,
+    expected_timestamp  TIMESTAMP WITH TIME ZONE NOT NULL,
+    actual_timestamp    TIMESTAMP WITH TIME ZONE NOT NULL,
+    expected_txs_hash   VARCHAR NOT NULL,
+    actual_txs_hash     VARCHAR NOT NULL,
+    reorged_txs         VARCHAR[] NOT NULL DEFAULT '{}',
+    reason              VARCHAR NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS trusted_divergence_batch_num_idx ON state.trusted_divergence (batch_num);
+
+-- +migrate Down
+DROP INDEX IF EXISTS state.trusted_divergence_batch_num_idx;
+DROP TABLE IF EXISTS state.trusted_divergence;
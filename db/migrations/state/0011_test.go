@@ -0,0 +1,33 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds the table storing the divergences detected in the trusted state
+type migrationTest0011 struct{}
+
+const insertTrustedDivergence0011 = `INSERT INTO state.trusted_divergence
+	(batch_num, kind, expected_state_root, actual_state_root, expected_ger, actual_ger, expected_timestamp, actual_timestamp, expected_txs_hash, actual_txs_hash, reorged_txs, reason)
+	VALUES (1, 'l1', '0x01', '0x02', '0x03', '0x03', NOW(), NOW(), '0x04', '0x05', '{"0x06"}', 'reason');`
+
+func (m migrationTest0011) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0011) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertTrustedDivergence0011)
+	assert.NoError(t, err)
+}
+
+func (m migrationTest0011) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertTrustedDivergence0011)
+	assert.Error(t, err)
+}
+
+func TestMigration0011(t *testing.T) {
+	runMigrationTest(t, 11, migrationTest0011{})
+}
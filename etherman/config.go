@@ -1,11 +1,30 @@
 package etherman
 
-import "github.com/0xPolygon/cdk-validium-node/etherman/etherscan"
+import (
+	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/etherman/etherscan"
+)
 
 // Config represents the configuration of the etherman
 type Config struct {
 	// URL is the URL of the Ethereum node for L1
 	URL string `mapstructure:"URL"`
+	// FallbackURLs are the URLs of additional Ethereum nodes for L1. The requests fail over to them, in order,
+	// when the L1 node in use fails
+	FallbackURLs []string `mapstructure:"FallbackURLs"`
+	// HealthCheckInterval is the time between the health checks of the L1 nodes, only done when there are FallbackURLs
+	HealthCheckInterval types.Duration `mapstructure:"HealthCheckInterval"`
+	// MaxBlockLag is the number of blocks an L1 node can be behind the highest one before being considered
+	// unhealthy by the health checks. 0 disables it
+	MaxBlockLag uint64 `mapstructure:"MaxBlockLag"`
+	// QuorumSize is the number of L1 nodes that must return the same data for the critical reads: the block
+	// headers, the blocks and the logs of the rollup info. The latest block is the highest one reached by
+	// QuorumSize nodes, and the L1 gas price is the median of the nodes. 0 or 1 disables the quorum reads
+	QuorumSize int `mapstructure:"QuorumSize"`
+	// RequestTimeout is the time each L1 node has to answer a request when there are FallbackURLs, so a node
+	// that stops answering doesn't block the requests. A node that doesn't answer in time is considered unhealthy,
+	// and the request fails over to the next node or, for the quorum reads, goes on without its answer. 0 disables it
+	RequestTimeout types.Duration `mapstructure:"RequestTimeout"`
 
 	// ForkIDChunkSize is the max interval for each call to L1 provider to get the forkIDs
 	ForkIDChunkSize uint64 `mapstructure:"ForkIDChunkSize"`
@@ -14,4 +33,9 @@ type Config struct {
 	MultiGasProvider bool `mapstructure:"MultiGasProvider"`
 	// Configuration for use Etherscan as used as gas provider, basically it needs the API-KEY
 	Etherscan etherscan.Config
+
+	// DataCommitteeCacheMaxAge is the maximum time the data committee is cached before reading it again from L1.
+	// The synchronizer also refreshes the cache when it processes a CommitteeUpdated event, and the sequence sender
+	// reads the committee hash from L1 before using the cached committee. 0 means no max age
+	DataCommitteeCacheMaxAge types.Duration `mapstructure:"DataCommitteeCacheMaxAge"`
 }
@@ -1,11 +1,16 @@
 package etherman
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
+	"github.com/0xPolygon/cdk-validium-node/log"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // DataCommitteeMember represents a member of the Data Committee
@@ -21,17 +26,122 @@ type DataCommittee struct {
 	RequiredSignatures uint64
 }
 
+// DataCommitteeCache keeps the last data committee read from L1 so it's not requested
+// on every call
+type DataCommitteeCache struct {
+	mu        sync.RWMutex
+	committee *DataCommittee
+	loadedAt  time.Time
+	maxAge    time.Duration
+}
+
+// NewDataCommitteeCache creates an empty data committee cache. If maxAge is greater than 0
+// the cached committee expires after that time
+func NewDataCommitteeCache(maxAge time.Duration) *DataCommitteeCache {
+	return &DataCommitteeCache{maxAge: maxAge}
+}
+
+// Get returns the cached committee, if any and not expired
+func (c *DataCommitteeCache) Get() (*DataCommittee, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.committee == nil || (c.maxAge > 0 && time.Since(c.loadedAt) > c.maxAge) {
+		return nil, false
+	}
+	return c.committee, true
+}
+
+// Set stores the committee in the cache
+func (c *DataCommitteeCache) Set(committee *DataCommittee) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.committee = committee
+	c.loadedAt = time.Now()
+}
+
+// Invalidate removes the cached committee, so the next read goes to L1
+func (c *DataCommitteeCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.committee = nil
+}
+
+// InvalidateDataCommitteeCache forces the next GetCurrentDataCommittee call to read the committee from L1
+func (etherMan *Client) InvalidateDataCommitteeCache() {
+	if etherMan.committeeCache != nil {
+		etherMan.committeeCache.Invalidate()
+	}
+}
+
 // GetCurrentDataCommittee return the currently registered data committee
 func (etherMan *Client) GetCurrentDataCommittee() (*DataCommittee, error) {
-	addrsHash, err := etherMan.DataCommittee.CommitteeHash(&bind.CallOpts{Pending: false})
+	if etherMan.committeeCache != nil {
+		if committee, found := etherMan.committeeCache.Get(); found {
+			return committee, nil
+		}
+	}
+	committee, err := etherMan.getDataCommitteeFromL1(&bind.CallOpts{Pending: false})
+	if err != nil {
+		return nil, err
+	}
+	if etherMan.committeeCache != nil {
+		etherMan.committeeCache.Set(committee)
+	}
+	return committee, nil
+}
+
+// GetLatestDataCommittee returns the data committee registered at the latest L1 block. The committee
+// hash is always read from L1 and the cached committee is only returned if it matches, so a committee
+// updated on L1 is used right away even if this client doesn't process the CommitteeUpdated events
+func (etherMan *Client) GetLatestDataCommittee() (*DataCommittee, error) {
+	opts := &bind.CallOpts{Pending: false}
+	if etherMan.committeeCache != nil {
+		if committee, found := etherMan.committeeCache.Get(); found {
+			addrsHash, err := etherMan.DataCommittee.CommitteeHash(opts)
+			if err != nil {
+				return nil, fmt.Errorf("error getting CommitteeHash from L1 SC: %w", err)
+			}
+			if common.Hash(addrsHash) == committee.AddressesHash {
+				return committee, nil
+			}
+			log.Infof("data committee updated on L1, hash %s, reading the new committee", common.Hash(addrsHash).Hex())
+		}
+	}
+	committee, err := etherMan.getDataCommitteeFromL1(opts)
+	if err != nil {
+		return nil, err
+	}
+	if etherMan.committeeCache != nil {
+		etherMan.committeeCache.Set(committee)
+	}
+	return committee, nil
+}
+
+// LoadDataCommitteeAt caches the data committee registered at the given L1 block, so it's returned
+// by GetCurrentDataCommittee. If it can't be read, for instance because the L1 node doesn't keep the
+// state of old blocks, the cache is invalidated so the latest committee is read next time
+func (etherMan *Client) LoadDataCommitteeAt(blockNumber uint64) error {
+	committee, err := etherMan.getDataCommitteeFromL1(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNumber)})
+	if err != nil {
+		etherMan.InvalidateDataCommitteeCache()
+		return err
+	}
+	if etherMan.committeeCache != nil {
+		etherMan.committeeCache.Set(committee)
+	}
+	return nil
+}
+
+func (etherMan *Client) getDataCommitteeFromL1(opts *bind.CallOpts) (*DataCommittee, error) {
+	addrsHash, err := etherMan.DataCommittee.CommitteeHash(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error getting CommitteeHash from L1 SC: %w", err)
 	}
-	reqSign, err := etherMan.DataCommittee.RequiredAmountOfSignatures(&bind.CallOpts{Pending: false})
+	reqSign, err := etherMan.DataCommittee.RequiredAmountOfSignatures(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error getting RequiredAmountOfSignatures from L1 SC: %w", err)
 	}
-	members, err := etherMan.GetCurrentDataCommitteeMembers()
+	members, err := etherMan.getDataCommitteeMembers(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +155,17 @@ func (etherMan *Client) GetCurrentDataCommittee() (*DataCommittee, error) {
 
 // GetCurrentDataCommitteeMembers return the currently registered data committee members
 func (etherMan *Client) GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, error) {
+	return etherMan.getDataCommitteeMembers(&bind.CallOpts{Pending: false})
+}
+
+func (etherMan *Client) getDataCommitteeMembers(opts *bind.CallOpts) ([]DataCommitteeMember, error) {
 	members := []DataCommitteeMember{}
-	nMembers, err := etherMan.DataCommittee.GetAmountOfMembers(&bind.CallOpts{Pending: false})
+	nMembers, err := etherMan.DataCommittee.GetAmountOfMembers(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error getting GetAmountOfMembers from L1 SC: %w", err)
 	}
 	for i := int64(0); i < nMembers.Int64(); i++ {
-		member, err := etherMan.DataCommittee.Members(&bind.CallOpts{Pending: false}, big.NewInt(i))
+		member, err := etherMan.DataCommittee.Members(opts, big.NewInt(i))
 		if err != nil {
 			return nil, fmt.Errorf("error getting Members %d from L1 SC: %w", i, err)
 		}
@@ -62,3 +176,36 @@ func (etherMan *Client) GetCurrentDataCommitteeMembers() ([]DataCommitteeMember,
 	}
 	return members, nil
 }
+
+func (etherMan *Client) committeeUpdatedEvent(ctx context.Context, vLog types.Log, blocks *[]Block, blocksOrder *map[common.Hash][]Order) error {
+	log.Debug("CommitteeUpdated event detected")
+	committeeUpdated, err := etherMan.DataCommittee.ParseCommitteeUpdated(vLog)
+	if err != nil {
+		return err
+	}
+	update := CommitteeUpdate{
+		BlockNumber:   vLog.BlockNumber,
+		CommitteeHash: common.Hash(committeeUpdated.CommitteeHash),
+	}
+
+	if len(*blocks) == 0 || ((*blocks)[len(*blocks)-1].BlockHash != vLog.BlockHash || (*blocks)[len(*blocks)-1].BlockNumber != vLog.BlockNumber) {
+		fullBlock, err := etherMan.EthClient.BlockByHash(ctx, vLog.BlockHash)
+		if err != nil {
+			return fmt.Errorf("error getting hashParent. BlockNumber: %d. Error: %w", vLog.BlockNumber, err)
+		}
+		block := prepareBlock(vLog, time.Unix(int64(fullBlock.Time()), 0), fullBlock)
+		block.CommitteeUpdates = append(block.CommitteeUpdates, update)
+		*blocks = append(*blocks, block)
+	} else if (*blocks)[len(*blocks)-1].BlockHash == vLog.BlockHash && (*blocks)[len(*blocks)-1].BlockNumber == vLog.BlockNumber {
+		(*blocks)[len(*blocks)-1].CommitteeUpdates = append((*blocks)[len(*blocks)-1].CommitteeUpdates, update)
+	} else {
+		log.Error("Error processing CommitteeUpdated event. BlockHash:", vLog.BlockHash, ". BlockNumber: ", vLog.BlockNumber)
+		return fmt.Errorf("error processing CommitteeUpdated event")
+	}
+	or := Order{
+		Name: CommitteeUpdatedOrder,
+		Pos:  len((*blocks)[len(*blocks)-1].CommitteeUpdates) - 1,
+	}
+	(*blocksOrder)[(*blocks)[len(*blocks)-1].BlockHash] = append((*blocksOrder)[(*blocks)[len(*blocks)-1].BlockHash], or)
+	return nil
+}
@@ -1,6 +1,7 @@
 package etherman
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
@@ -46,4 +47,78 @@ func TestUpdateDataCommitteeEvent(t *testing.T) {
 	}
 	expectedSetup.Members = expectedMembers
 	assert.Equal(t, expectedSetup, *actualSetup)
+
+	// Update the committee again, the cached committee is returned until the event is read
+	ctx := context.Background()
+	initBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	URLs = []string{"1", "2"}
+	addrsBytes = append(addrs[0].Bytes(), addrs[1].Bytes()...)
+	_, err = da.SetupCommittee(auth, big.NewInt(1), URLs, addrsBytes)
+	require.NoError(t, err)
+	ethBackend.Commit()
+	cachedSetup, err := etherman.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, expectedSetup, *cachedSetup)
+
+	finalBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	finalBlockNumber := finalBlock.NumberU64()
+	blocks, order, err := etherman.GetRollupInfoByBlockRange(ctx, initBlock.NumberU64()+1, &finalBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(blocks))
+	require.Equal(t, 1, len(blocks[0].CommitteeUpdates))
+	assert.Equal(t, crypto.Keccak256Hash(addrsBytes), blocks[0].CommitteeUpdates[0].CommitteeHash)
+	assert.Equal(t, CommitteeUpdatedOrder, order[blocks[0].BlockHash][0].Name)
+
+	// Reading the event doesn't refresh the cache, the committee is loaded when the event is processed
+	cachedSetup, err = etherman.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, expectedSetup, *cachedSetup)
+	require.NoError(t, etherman.LoadDataCommitteeAt(blocks[0].BlockNumber))
+	updatedSetup, err := etherman.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), updatedSetup.RequiredSignatures)
+	assert.Equal(t, crypto.Keccak256Hash(addrsBytes), updatedSetup.AddressesHash)
+	assert.Equal(t, 2, len(updatedSetup.Members))
+
+	// The cache is invalidated when the committee can't be read at the block, the simulated
+	// backend only reads the state of the last block
+	etherman.committeeCache.Set(&expectedSetup)
+	assert.Error(t, etherman.LoadDataCommitteeAt(initBlock.NumberU64()))
+	updatedSetup, err = etherman.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(updatedSetup.Members))
+}
+
+func TestGetLatestDataCommittee(t *testing.T) {
+	etherman, ethBackend, auth, _, _, da := newTestingEnv()
+	setupCommittee := func(addrs ...common.Address) common.Hash {
+		URLs := []string{}
+		addrsBytes := []byte{}
+		for _, addr := range addrs {
+			URLs = append(URLs, addr.Hex())
+			addrsBytes = append(addrsBytes, addr.Bytes()...)
+		}
+		_, err := da.SetupCommittee(auth, big.NewInt(1), URLs, addrsBytes)
+		require.NoError(t, err)
+		ethBackend.Commit()
+		return crypto.Keccak256Hash(addrsBytes)
+	}
+
+	firstHash := setupCommittee(common.HexToAddress("0x1"))
+	committee, err := etherman.GetLatestDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, firstHash, committee.AddressesHash)
+
+	// The committee updated on L1 is returned without processing the CommitteeUpdated event,
+	// and it replaces the cached committee
+	updatedHash := setupCommittee(common.HexToAddress("0x1"), common.HexToAddress("0x2"))
+	committee, err = etherman.GetLatestDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, updatedHash, committee.AddressesHash)
+	assert.Equal(t, 2, len(committee.Members))
+	committee, err = etherman.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, updatedHash, committee.AddressesHash)
 }
@@ -64,6 +64,9 @@ var (
 	proveNonDeterministicPendingStateSignatureHash = crypto.Keccak256Hash([]byte("ProveNonDeterministicPendingState(bytes32,bytes32)"))
 	overridePendingStateSignatureHash              = crypto.Keccak256Hash([]byte("OverridePendingState(uint64,bytes32,address)"))
 
+	// Events cdkdatacommittee SC
+	committeeUpdatedSignatureHash = crypto.Keccak256Hash([]byte("CommitteeUpdated(bytes32)"))
+
 	// Proxy events
 	initializedSignatureHash    = crypto.Keccak256Hash([]byte("Initialized(uint8)"))
 	adminChangedSignatureHash   = crypto.Keccak256Hash([]byte("AdminChanged(address,address)"))
@@ -101,6 +104,8 @@ const (
 	SequenceForceBatchesOrder EventOrder = "SequenceForceBatches"
 	// ForkIDsOrder identifies an updateZkevmVersion event
 	ForkIDsOrder EventOrder = "forkIDs"
+	// CommitteeUpdatedOrder identifies a CommitteeUpdated event of the data committee
+	CommitteeUpdatedOrder EventOrder = "CommitteeUpdated"
 )
 
 type ethereumClient interface {
@@ -146,17 +151,17 @@ type Client struct {
 
 	GasProviders externalGasProviders
 
-	l1Cfg L1Config
-	cfg   Config
-	auth  map[common.Address]bind.TransactOpts // empty in case of read-only client
+	l1Cfg          L1Config
+	cfg            Config
+	auth           map[common.Address]bind.TransactOpts // empty in case of read-only client
+	committeeCache *DataCommitteeCache
 }
 
 // NewClient creates a new etherman.
 func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
 	// Connect to ethereum node
-	ethClient, err := ethclient.Dial(cfg.URL)
+	ethClient, err := dialL1(cfg)
 	if err != nil {
-		log.Errorf("error connecting to %s: %+v", cfg.URL, err)
 		return nil, err
 	}
 	// Create smc clients
@@ -177,7 +182,7 @@ func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
 		return nil, err
 	}
 	var scAddresses []common.Address
-	scAddresses = append(scAddresses, l1Config.CDKValidiumAddr, l1Config.GlobalExitRootManagerAddr)
+	scAddresses = append(scAddresses, l1Config.CDKValidiumAddr, l1Config.GlobalExitRootManagerAddr, l1Config.DataCommitteeAddr)
 
 	gProviders := []ethereum.GasPricer{ethClient}
 	if cfg.MultiGasProvider {
@@ -202,12 +207,60 @@ func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
 			MultiGasProvider: cfg.MultiGasProvider,
 			Providers:        gProviders,
 		},
-		l1Cfg: l1Config,
-		cfg:   cfg,
-		auth:  map[common.Address]bind.TransactOpts{},
+		l1Cfg:          l1Config,
+		cfg:            cfg,
+		auth:           map[common.Address]bind.TransactOpts{},
+		committeeCache: NewDataCommitteeCache(cfg.DataCommitteeCacheMaxAge.Duration),
 	}, nil
 }
 
+// dialL1 connects to the L1 node, or to all the L1 nodes with failover and quorum reads when there are FallbackURLs
+func dialL1(cfg Config) (endpointClient, error) {
+	urls := append([]string{cfg.URL}, cfg.FallbackURLs...)
+	clients := make([]endpointClient, 0, len(urls))
+	for _, url := range urls {
+		ethClient, err := ethclient.Dial(url)
+		if err != nil {
+			log.Errorf("error connecting to %s: %+v", url, err)
+			closeClients(clients)
+			return nil, err
+		}
+		clients = append(clients, ethClient)
+	}
+	if len(clients) == 1 {
+		if cfg.QuorumSize > 1 {
+			closeClients(clients)
+			return nil, fmt.Errorf("the L1 quorum size %d requires FallbackURLs", cfg.QuorumSize)
+		}
+		return clients[0], nil
+	}
+	client, err := newMultiClient(clients, urls, cfg.QuorumSize, cfg.MaxBlockLag, cfg.RequestTimeout.Duration)
+	if err != nil {
+		closeClients(clients)
+		return nil, err
+	}
+	if cfg.HealthCheckInterval.Duration > 0 {
+		go client.healthCheck(cfg.HealthCheckInterval.Duration)
+	}
+	return client, nil
+}
+
+// closeClients closes the connections of the clients dialed before dialL1 failed
+func closeClients(clients []endpointClient) {
+	for _, client := range clients {
+		if c, ok := client.(interface{ Close() }); ok {
+			c.Close()
+		}
+	}
+}
+
+// Close stops the health checks of the L1 nodes and closes the connections to them
+func (etherMan *Client) Close() {
+	if client, ok := etherMan.EthClient.(interface{ Close() }); ok {
+		client.Close()
+	}
+}
+
 // VerifyGenBlockNumber verifies if the genesis Block Number is valid
 func (etherMan *Client) VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error) {
 	start := time.Now()
@@ -427,6 +480,8 @@ func (etherMan *Client) processEvent(ctx context.Context, vLog types.Log, blocks
 	case overridePendingStateSignatureHash:
 		log.Debug("OverridePendingState event detected")
 		return nil
+	case committeeUpdatedSignatureHash:
+		return etherMan.committeeUpdatedEvent(ctx, vLog, blocks, blocksOrder)
 	}
 	log.Warn("Event not registered: ", vLog)
 	return nil
@@ -1072,6 +1127,82 @@ func (etherMan *Client) ApproveMatic(ctx context.Context, account common.Address
 	return tx, nil
 }
 
+// GetSequencedBatchAccInputHash gets the accumulated input hash stored in the smc for the batch, which is
+// only stored for the last batch of each sequence
+func (etherMan *Client) GetSequencedBatchAccInputHash(batchNumber uint64) (common.Hash, error) {
+	sequencedBatch, err := etherMan.CDKValidium.SequencedBatches(&bind.CallOpts{Pending: false}, batchNumber)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(sequencedBatch.AccInputHash), nil
+}
+
+// GetBatchStateRoot gets the state root stored in the smc for the batch, which is only stored for the last
+// batch of each consolidated verification
+func (etherMan *Client) GetBatchStateRoot(batchNumber uint64) (common.Hash, error) {
+	stateRoot, err := etherMan.CDKValidium.BatchNumToStateRoot(&bind.CallOpts{Pending: false}, batchNumber)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(stateRoot), nil
+}
+
+// BatchEvent is a batch sequenced or verified by an event in L1
+type BatchEvent struct {
+	BlockNumber uint64
+	TxHash      common.Hash
+	BatchNumber uint64
+	// StateRoot is the state root of a verified batch, empty for a sequenced batch
+	StateRoot common.Hash
+}
+
+// GetBatchEvents returns the last batches of the sequences and the batches verified by the trusted
+// aggregator in the block range, in the order of their events. Unlike GetRollupInfoByBlockRange, it
+// doesn't read the txs of the sequences
+func (etherMan *Client) GetBatchEvents(ctx context.Context, fromBlock, toBlock uint64) ([]BatchEvent, []BatchEvent, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{etherMan.l1Cfg.CDKValidiumAddr},
+		Topics: [][]common.Hash{{
+			sequencedBatchesEventSignatureHash, forceSequencedBatchesSignatureHash, verifyBatchesTrustedAggregatorSignatureHash,
+		}},
+	}
+	logs, err := etherMan.EthClient.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sequenced, verified []BatchEvent
+	for _, vLog := range logs {
+		event := BatchEvent{BlockNumber: vLog.BlockNumber, TxHash: vLog.TxHash}
+		switch vLog.Topics[0] {
+		case sequencedBatchesEventSignatureHash:
+			sb, err := etherMan.CDKValidium.ParseSequenceBatches(vLog)
+			if err != nil {
+				return nil, nil, err
+			}
+			event.BatchNumber = sb.NumBatch
+			sequenced = append(sequenced, event)
+		case forceSequencedBatchesSignatureHash:
+			fsb, err := etherMan.CDKValidium.ParseSequenceForceBatches(vLog)
+			if err != nil {
+				return nil, nil, err
+			}
+			event.BatchNumber = fsb.NumBatch
+			sequenced = append(sequenced, event)
+		case verifyBatchesTrustedAggregatorSignatureHash:
+			vb, err := etherMan.CDKValidium.ParseVerifyBatchesTrustedAggregator(vLog)
+			if err != nil {
+				return nil, nil, err
+			}
+			event.BatchNumber = vb.NumBatch
+			event.StateRoot = vb.StateRoot
+			verified = append(verified, event)
+		}
+	}
+	return sequenced, verified, nil
+}
+
 // GetTrustedSequencerURL Gets the trusted sequencer url from rollup smc
 func (etherMan *Client) GetTrustedSequencerURL() (string, error) {
 	return etherMan.CDKValidium.TrustedSequencerURL(&bind.CallOpts{Pending: false})
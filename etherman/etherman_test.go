@@ -429,8 +429,12 @@ func TestGetForks(t *testing.T) {
 	t.Logf("Blocks: %+v", blocks)
 	assert.Equal(t, 1, len(blocks))
 	assert.Equal(t, 1, len(blocks[0].ForkIDs))
-	assert.Equal(t, 0, order[blocks[0].BlockHash][0].Pos)
-	assert.Equal(t, ForkIDsOrder, order[blocks[0].BlockHash][0].Name)
+	// The data committee is set up in the same block
+	assert.Equal(t, 1, len(blocks[0].CommitteeUpdates))
+	assert.Equal(t, 2, len(order[blocks[0].BlockHash]))
+	assert.Equal(t, CommitteeUpdatedOrder, order[blocks[0].BlockHash][0].Name)
+	assert.Equal(t, 0, order[blocks[0].BlockHash][1].Pos)
+	assert.Equal(t, ForkIDsOrder, order[blocks[0].BlockHash][1].Name)
 	assert.Equal(t, uint64(0), blocks[0].ForkIDs[0].BatchNumber)
 	assert.Equal(t, uint64(1), blocks[0].ForkIDs[0].ForkID)
 	assert.Equal(t, "v1", blocks[0].ForkIDs[0].Version)
@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/0xPolygon/cdk-validium-node/metrics"
@@ -31,6 +32,18 @@ const (
 
 	// EventCounterName is the name of the label to count the processed events.
 	EventCounterName = Prefix + "processed_events_counter"
+
+	// L1EndpointFailoverName is the name of the label to count the failovers to an L1 endpoint.
+	L1EndpointFailoverName = Prefix + "l1_endpoint_failover_counter"
+
+	// L1QuorumDisagreementName is the name of the label to count the disagreements between the L1 endpoints.
+	L1QuorumDisagreementName = Prefix + "l1_quorum_disagreement_counter"
+
+	// EndpointLabelName is the name of the label for the index of the L1 endpoint.
+	EndpointLabelName = "endpoint"
+
+	// MethodLabelName is the name of the label for the L1 method.
+	MethodLabelName = "method"
 )
 
 // Register the metrics for the etherman package.
@@ -74,7 +87,25 @@ func Register() {
 		},
 	}
 
+	counterVecs := []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: L1EndpointFailoverName,
+				Help: "[ETHERMAN] count failovers to an L1 endpoint",
+			},
+			Labels: []string{EndpointLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: L1QuorumDisagreementName,
+				Help: "[ETHERMAN] count disagreements between the L1 endpoints",
+			},
+			Labels: []string{MethodLabelName},
+		},
+	}
+
 	metrics.RegisterCounters(counters...)
+	metrics.RegisterCounterVecs(counterVecs...)
 	metrics.RegisterHistograms(histograms...)
 }
 
@@ -118,3 +149,14 @@ func VerifyGenBlockTime(lastProcessTime time.Duration) {
 func EventCounter() {
 	metrics.CounterInc(EventCounterName)
 }
+
+// L1EndpointFailover increases the counter for the failovers to the L1 endpoint. The endpoint is
+// identified by its index, as the URLs can contain API keys
+func L1EndpointFailover(endpoint int) {
+	metrics.CounterVecInc(L1EndpointFailoverName, strconv.Itoa(endpoint))
+}
+
+// L1QuorumDisagreement increases the counter for the disagreements between the L1 endpoints on the method
+func L1QuorumDisagreement(method string) {
+	metrics.CounterVecInc(L1QuorumDisagreementName, method)
+}
@@ -0,0 +1,642 @@
+package etherman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman/metrics"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// ErrNoQuorum is returned when not enough L1 endpoints return the same data for a quorum read
+	ErrNoQuorum = errors.New("no quorum between the L1 endpoints")
+	// errRequestTimeout is returned when an endpoint doesn't answer a request in time
+	errRequestTimeout = errors.New("L1 endpoint request timed out")
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+// endpointClient is the client of one of the L1 endpoints, used both by the etherman and the smart contract bindings
+type endpointClient interface {
+	ethereumClient
+	bind.ContractBackend
+}
+
+// endpoint is one of the L1 nodes used by a multiClient
+type endpoint struct {
+	index  int
+	name   string
+	client endpointClient
+
+	mu      sync.RWMutex
+	healthy bool
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.healthy
+}
+
+func (e *endpoint) setHealthy(healthy bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.healthy && !healthy {
+		log.Warnf("%s is unhealthy: %v", e.name, err)
+	} else if !e.healthy && healthy {
+		log.Infof("%s is healthy again", e.name)
+	}
+	e.healthy = healthy
+}
+
+// multiClient is an L1 client backed by several L1 endpoints. Requests go to the first healthy endpoint,
+// failing over to the next ones when it fails. The critical reads, which are the block headers, the
+// blocks and the logs used to get the rollup info, can require the agreement of a quorum of endpoints
+type multiClient struct {
+	endpoints []*endpoint
+	// quorum is the number of endpoints that must return the same data for the critical reads, 1 disables it
+	quorum      int
+	maxBlockLag uint64
+	// requestTimeout is the time each endpoint has to answer a request, 0 if unlimited
+	requestTimeout time.Duration
+
+	// closed stops the health checks
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newMultiClient(clients []endpointClient, urls []string, quorum int, maxBlockLag uint64, requestTimeout time.Duration) (*multiClient, error) {
+	if quorum < 1 {
+		quorum = 1
+	}
+	if quorum > len(clients) {
+		return nil, fmt.Errorf("the L1 quorum size %d is greater than the number of L1 endpoints %d", quorum, len(clients))
+	}
+	m := &multiClient{quorum: quorum, maxBlockLag: maxBlockLag, requestTimeout: requestTimeout, closed: make(chan struct{})}
+	for i, client := range clients {
+		m.endpoints = append(m.endpoints, &endpoint{
+			index:   i,
+			name:    fmt.Sprintf("L1 endpoint %d (%s)", i, redactURL(urls[i])),
+			client:  client,
+			healthy: true,
+		})
+	}
+	return m, nil
+}
+
+// redactURL removes the path, query and credentials of the URL, as they usually contain API keys
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "invalid URL"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// isEndpointError returns true if the error is caused by the endpoint, like a connection error, instead
+// of being the answer of the L1 node to the request, like a reverted call or a not found block
+func isEndpointError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	var dataErr rpc.DataError
+	return !errors.As(err, &rpcErr) && !errors.As(err, &dataErr)
+}
+
+// orderedEndpoints returns the healthy endpoints in order of preference, followed by the unhealthy ones
+func (m *multiClient) orderedEndpoints() []*endpoint {
+	ordered := make([]*endpoint, 0, len(m.endpoints))
+	unhealthy := make([]*endpoint, 0)
+	for _, e := range m.endpoints {
+		if e.isHealthy() {
+			ordered = append(ordered, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	return append(ordered, unhealthy...)
+}
+
+// do runs the request on the first healthy endpoint, failing over to the next endpoints on endpoint errors,
+// including not answering in requestTimeout
+func (m *multiClient) do(ctx context.Context, request func(context.Context, endpointClient) error) error {
+	var err error
+	for i, e := range m.orderedEndpoints() {
+		if i > 0 {
+			metrics.L1EndpointFailover(e.index)
+		}
+		_, err = m.requestWithTimeout(ctx, e, func(ctx context.Context, c endpointClient) (interface{}, error) {
+			return nil, request(ctx, c)
+		})
+		if !isEndpointError(err) {
+			return err
+		}
+		e.setHealthy(false, err)
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// quorumResponse is the answer of an endpoint to a quorum read
+type quorumResponse struct {
+	endpoint *endpoint
+	key      string
+	value    interface{}
+	err      error
+}
+
+// quorumRead runs the request on the healthy endpoints, or on all of them when there are not enough healthy
+// ones, returning the value that a quorum of endpoints agree on. The key identifies the equal values.
+// Disagreements between the endpoints are reported even if there is a quorum
+func (m *multiClient) quorumRead(ctx context.Context, method string, request func(context.Context, endpointClient) (interface{}, error), key func(interface{}) string) (interface{}, error) {
+	if m.quorum <= 1 {
+		var value interface{}
+		err := m.do(ctx, func(ctx context.Context, c endpointClient) error {
+			var err error
+			value, err = request(ctx, c)
+			return err
+		})
+		return value, err
+	}
+
+	responses := m.requestAll(ctx, request)
+	counts := map[string]int{}
+	var winner *quorumResponse
+	var lastEndpointErr error
+	for i := range responses {
+		r := &responses[i]
+		if isEndpointError(r.err) {
+			r.endpoint.setHealthy(false, r.err)
+			lastEndpointErr = r.err
+			continue
+		}
+		if r.err != nil {
+			r.key = "error: " + r.err.Error()
+		} else {
+			r.key = key(r.value)
+		}
+		counts[r.key]++
+		if counts[r.key] >= m.quorum && winner == nil {
+			winner = r
+		}
+	}
+	if len(counts) > 1 {
+		m.reportDisagreement(method, responses)
+	}
+	if winner == nil {
+		if len(counts) == 0 && lastEndpointErr != nil {
+			return nil, fmt.Errorf("%w for %s: %v", ErrNoQuorum, method, lastEndpointErr)
+		}
+		return nil, fmt.Errorf("%w for %s: %d endpoints required, %d distinct answers", ErrNoQuorum, method, m.quorum, len(counts))
+	}
+	return winner.value, winner.err
+}
+
+// requestAll runs the request in parallel on the healthy endpoints, or on all of them when there are
+// not enough healthy ones to reach the quorum. Each endpoint has requestTimeout to answer, so a slow
+// endpoint doesn't delay the read
+func (m *multiClient) requestAll(ctx context.Context, request func(context.Context, endpointClient) (interface{}, error)) []quorumResponse {
+	endpoints := make([]*endpoint, 0, len(m.endpoints))
+	for _, e := range m.endpoints {
+		if e.isHealthy() {
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(endpoints) < m.quorum {
+		endpoints = m.endpoints
+	}
+	responses := make([]quorumResponse, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			value, err := m.requestWithTimeout(ctx, e, request)
+			responses[i] = quorumResponse{endpoint: e, value: value, err: err}
+		}(i, e)
+	}
+	wg.Wait()
+	return responses
+}
+
+// requestWithTimeout runs the request on the endpoint, giving it requestTimeout to answer. Not answering
+// in time is returned as errRequestTimeout, which is an endpoint error
+func (m *multiClient) requestWithTimeout(ctx context.Context, e *endpoint, request func(context.Context, endpointClient) (interface{}, error)) (interface{}, error) {
+	requestCtx := ctx
+	if m.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		requestCtx, cancel = context.WithTimeout(ctx, m.requestTimeout)
+		defer cancel()
+	}
+	value, err := request(requestCtx, e.client)
+	if err != nil && ctx.Err() == nil && errors.Is(requestCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %v: %v", errRequestTimeout, m.requestTimeout, err)
+	}
+	return value, err
+}
+
+func (m *multiClient) reportDisagreement(method string, responses []quorumResponse) {
+	metrics.L1QuorumDisagreement(method)
+	answers := make([]string, 0, len(responses))
+	for _, r := range responses {
+		if r.key != "" {
+			answers = append(answers, fmt.Sprintf("%s: %s", r.endpoint.name, r.key))
+		}
+	}
+	log.Warnf("L1 endpoints disagree on %s: %s", method, strings.Join(answers, ", "))
+}
+
+// isBlockTag returns true if the block number is nil, meaning the latest block, or one of the block tags
+func isBlockTag(number *big.Int) bool {
+	return number == nil || number.Sign() < 0
+}
+
+// resolveBlockTag returns the block number of the tag that a quorum of endpoints have reached, which is
+// the quorum-th highest number returned by the endpoints, as they can be a few blocks apart
+func (m *multiClient) resolveBlockTag(ctx context.Context, number *big.Int) (*big.Int, error) {
+	responses := m.requestAll(ctx, func(ctx context.Context, c endpointClient) (interface{}, error) {
+		return c.HeaderByNumber(ctx, number)
+	})
+	numbers := make([]uint64, 0, len(responses))
+	var lastErr error
+	for _, r := range responses {
+		if r.err != nil {
+			if isEndpointError(r.err) {
+				r.endpoint.setHealthy(false, r.err)
+			}
+			lastErr = r.err
+			continue
+		}
+		if header, ok := r.value.(*types.Header); ok && header != nil {
+			numbers = append(numbers, header.Number.Uint64())
+		}
+	}
+	if len(numbers) < m.quorum {
+		return nil, fmt.Errorf("%w for block %s: %d endpoints required, %d answers. Last error: %v", ErrNoQuorum, blockTagName(number), m.quorum, len(numbers), lastErr)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+	return new(big.Int).SetUint64(numbers[m.quorum-1]), nil
+}
+
+func blockTagName(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return rpc.BlockNumber(number.Int64()).String()
+}
+
+func headerKey(value interface{}) string {
+	header, ok := value.(*types.Header)
+	if !ok || header == nil {
+		return "nil"
+	}
+	return header.Hash().String()
+}
+
+func logsKey(value interface{}) string {
+	logs, ok := value.([]types.Log)
+	if !ok {
+		return "nil"
+	}
+	data, err := json.Marshal(logs)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return fmt.Sprintf("%d logs %s", len(logs), crypto.Keccak256Hash(data).String())
+}
+
+// healthCheck checks the endpoints periodically until the client is closed. An endpoint is unhealthy if
+// it fails to return its latest block, or if it's more than maxBlockLag blocks behind the highest endpoint
+func (m *multiClient) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.checkEndpoints()
+		case <-m.closed:
+			return
+		}
+	}
+}
+
+// Close stops the health checks and closes the connections to the endpoints
+func (m *multiClient) Close() {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+		for _, e := range m.endpoints {
+			if client, ok := e.client.(interface{ Close() }); ok {
+				client.Close()
+			}
+		}
+	})
+}
+
+func (m *multiClient) checkEndpoints() {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	latest := make([]uint64, len(m.endpoints))
+	errs := make([]error, len(m.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range m.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			header, err := e.client.HeaderByNumber(ctx, nil)
+			if err == nil && header == nil {
+				err = errors.New("missing latest header")
+			}
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			latest[i] = header.Number.Uint64()
+		}(i, e)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for i := range m.endpoints {
+		if errs[i] == nil && latest[i] > highest {
+			highest = latest[i]
+		}
+	}
+	for i, e := range m.endpoints {
+		switch {
+		case errs[i] != nil:
+			e.setHealthy(false, errs[i])
+		case m.maxBlockLag > 0 && highest-latest[i] > m.maxBlockLag:
+			e.setHealthy(false, fmt.Errorf("latest block %d is %d blocks behind the highest endpoint", latest[i], highest-latest[i]))
+		default:
+			e.setHealthy(true, nil)
+		}
+	}
+}
+
+// HeaderByNumber returns the header agreed by a quorum of endpoints. For the latest, safe and finalized
+// headers, it's the header of the highest block reached by a quorum of endpoints
+func (m *multiClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if m.quorum > 1 && isBlockTag(number) {
+		var err error
+		if number, err = m.resolveBlockTag(ctx, number); err != nil {
+			return nil, err
+		}
+	}
+	value, err := m.quorumRead(ctx, "HeaderByNumber", func(ctx context.Context, c endpointClient) (interface{}, error) {
+		return c.HeaderByNumber(ctx, number)
+	}, headerKey)
+	header, _ := value.(*types.Header)
+	return header, err
+}
+
+// BlockByNumber returns the block agreed by a quorum of endpoints, as its hash is used to detect L1 reorgs
+func (m *multiClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	if m.quorum > 1 && isBlockTag(number) {
+		var err error
+		if number, err = m.resolveBlockTag(ctx, number); err != nil {
+			return nil, err
+		}
+	}
+	value, err := m.quorumRead(ctx, "BlockByNumber", func(ctx context.Context, c endpointClient) (interface{}, error) {
+		return c.BlockByNumber(ctx, number)
+	}, func(value interface{}) string {
+		block, ok := value.(*types.Block)
+		if !ok || block == nil {
+			return "nil"
+		}
+		return block.Hash().String()
+	})
+	block, _ := value.(*types.Block)
+	return block, err
+}
+
+// FilterLogs returns the logs agreed by a quorum of endpoints. A query up to the latest block is limited
+// to the highest block reached by a quorum of endpoints
+func (m *multiClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if m.quorum > 1 && query.BlockHash == nil && isBlockTag(query.ToBlock) {
+		toBlock, err := m.resolveBlockTag(ctx, query.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+		query.ToBlock = toBlock
+	}
+	value, err := m.quorumRead(ctx, "FilterLogs", func(ctx context.Context, c endpointClient) (interface{}, error) {
+		return c.FilterLogs(ctx, query)
+	}, logsKey)
+	logs, _ := value.([]types.Log)
+	return logs, err
+}
+
+// SuggestGasPrice returns the median of the gas prices of the endpoints when the quorum reads are
+// enabled, so a single endpoint can't skew it
+func (m *multiClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if m.quorum <= 1 {
+		var gasPrice *big.Int
+		err := m.do(ctx, func(ctx context.Context, c endpointClient) (err error) {
+			gasPrice, err = c.SuggestGasPrice(ctx)
+			return err
+		})
+		return gasPrice, err
+	}
+	responses := m.requestAll(ctx, func(ctx context.Context, c endpointClient) (interface{}, error) {
+		return c.SuggestGasPrice(ctx)
+	})
+	gasPrices := make([]*big.Int, 0, len(responses))
+	var lastErr error
+	for _, r := range responses {
+		if r.err != nil {
+			if isEndpointError(r.err) {
+				r.endpoint.setHealthy(false, r.err)
+			}
+			lastErr = r.err
+			continue
+		}
+		if gasPrice, ok := r.value.(*big.Int); ok && gasPrice != nil {
+			gasPrices = append(gasPrices, gasPrice)
+		}
+	}
+	if len(gasPrices) < m.quorum {
+		return nil, fmt.Errorf("%w for SuggestGasPrice: %d endpoints required, %d answers. Last error: %v", ErrNoQuorum, m.quorum, len(gasPrices), lastErr)
+	}
+	sort.Slice(gasPrices, func(i, j int) bool { return gasPrices[i].Cmp(gasPrices[j]) < 0 })
+	return gasPrices[len(gasPrices)/2], nil
+}
+
+// BlockByHash returns the block from the first healthy endpoint
+func (m *multiClient) BlockByHash(ctx context.Context, hash common.Hash) (block *types.Block, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		block, err = c.BlockByHash(ctx, hash)
+		return err
+	})
+	return block, err
+}
+
+// HeaderByHash returns the header from the first healthy endpoint
+func (m *multiClient) HeaderByHash(ctx context.Context, hash common.Hash) (header *types.Header, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		header, err = c.HeaderByHash(ctx, hash)
+		return err
+	})
+	return header, err
+}
+
+// TransactionCount returns the number of transactions of the block from the first healthy endpoint
+func (m *multiClient) TransactionCount(ctx context.Context, blockHash common.Hash) (count uint, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		count, err = c.TransactionCount(ctx, blockHash)
+		return err
+	})
+	return count, err
+}
+
+// TransactionInBlock returns the transaction of the block from the first healthy endpoint
+func (m *multiClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (tx *types.Transaction, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		tx, err = c.TransactionInBlock(ctx, blockHash, index)
+		return err
+	})
+	return tx, err
+}
+
+// SubscribeNewHead subscribes to the new heads of the first healthy endpoint
+func (m *multiClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (sub ethereum.Subscription, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		sub, err = c.SubscribeNewHead(ctx, ch)
+		return err
+	})
+	return sub, err
+}
+
+// BalanceAt returns the balance of the account from the first healthy endpoint
+func (m *multiClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		balance, err = c.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+// StorageAt returns the storage of the account from the first healthy endpoint
+func (m *multiClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) (storage []byte, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		storage, err = c.StorageAt(ctx, account, key, blockNumber)
+		return err
+	})
+	return storage, err
+}
+
+// CodeAt returns the code of the account from the first healthy endpoint
+func (m *multiClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		code, err = c.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// NonceAt returns the nonce of the account from the first healthy endpoint
+func (m *multiClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		nonce, err = c.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+// PendingCodeAt returns the pending code of the account from the first healthy endpoint
+func (m *multiClient) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		code, err = c.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// PendingNonceAt returns the pending nonce of the account from the first healthy endpoint
+func (m *multiClient) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		nonce, err = c.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// CallContract executes the call on the first healthy endpoint
+func (m *multiClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		result, err = c.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// EstimateGas estimates the gas of the call on the first healthy endpoint
+func (m *multiClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		gas, err = c.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SuggestGasTipCap returns the gas tip cap from the first healthy endpoint
+func (m *multiClient) SuggestGasTipCap(ctx context.Context) (gasTipCap *big.Int, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		gasTipCap, err = c.SuggestGasTipCap(ctx)
+		return err
+	})
+	return gasTipCap, err
+}
+
+// SubscribeFilterLogs subscribes to the logs of the first healthy endpoint
+func (m *multiClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (sub ethereum.Subscription, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		sub, err = c.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
+
+// TransactionByHash returns the transaction from the first healthy endpoint
+func (m *multiClient) TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		tx, isPending, err = c.TransactionByHash(ctx, txHash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+// TransactionReceipt returns the receipt from the first healthy endpoint
+func (m *multiClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		receipt, err = c.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+// SendTransaction sends the transaction through the first healthy endpoint. Sending it again through
+// another endpoint after a failure is safe, as it's the same signed transaction
+func (m *multiClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return m.do(ctx, func(ctx context.Context, c endpointClient) error {
+		return c.SendTransaction(ctx, tx)
+	})
+}
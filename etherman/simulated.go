@@ -139,6 +139,7 @@ func NewSimulatedEtherman(cfg Config, auth *bind.TransactOpts) (
 		SCAddresses:           []common.Address{poeAddr, exitManagerAddr, dataCommitteeAddr},
 		auth:                  map[common.Address]bind.TransactOpts{},
 		cfg:                   cfg,
+		committeeCache:        NewDataCommitteeCache(cfg.DataCommitteeCacheMaxAge.Duration),
 	}
 	err = c.AddOrReplaceAuth(*auth)
 	if err != nil {
@@ -18,6 +18,7 @@ type Block struct {
 	VerifiedBatches       []VerifiedBatch
 	SequencedForceBatches [][]SequencedForceBatch
 	ForkIDs               []ForkID
+	CommitteeUpdates      []CommitteeUpdate
 	ReceivedAt            time.Time
 }
 
@@ -74,3 +75,9 @@ type ForkID struct {
 	ForkID      uint64
 	Version     string
 }
+
+// CommitteeUpdate is a struct to track the CommitteeUpdated event of the data committee.
+type CommitteeUpdate struct {
+	BlockNumber   uint64
+	CommitteeHash common.Hash
+}
@@ -155,7 +155,7 @@ func (c *Client) Result(ctx context.Context, owner, id string, dbTx pgx.Tx) (Mon
 // SetStatusDone sets the status of a monitored tx to MonitoredStatusDone.
 // this method is provided to the callers to decide when a monitored tx should be
 // considered done, so they can start to ignore it when querying it by Status.
-func (c *Client) setStatusDone(ctx context.Context, owner, id string, dbTx pgx.Tx) error {
+func (c *Client) SetStatusDone(ctx context.Context, owner, id string, dbTx pgx.Tx) error {
 	mTx, err := c.storage.Get(ctx, owner, id, nil)
 	if err != nil {
 		return err
@@ -599,6 +599,9 @@ type ResultHandler func(MonitoredTxResult, pgx.Tx)
 // and wait until all of them are either confirmed or failed before continuing
 //
 // for the confirmed and failed ones, the resultHandler will be triggered
+//
+// it returns early if the context is done, so the resultHandler can stop the processing
+// of a failed monitored tx that is not going to be discarded by canceling the context
 func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, owner string, resultHandler ResultHandler, dbTx pgx.Tx) {
 	statusesFilter := []MonitoredTxStatus{
 		MonitoredTxStatusCreated,
@@ -609,6 +612,9 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, owner string, r
 	}
 	// keep running until there are pending monitored txs
 	for {
+		if ctx.Err() != nil {
+			return
+		}
 		results, err := c.ResultsByStatus(ctx, owner, statusesFilter, dbTx)
 		if err != nil {
 			// if something goes wrong here, we log, wait a bit and keep it in the infinite loop to not unlock the caller.
@@ -623,11 +629,14 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, owner string, r
 		}
 
 		for _, result := range results {
+			if ctx.Err() != nil {
+				return
+			}
 			resultLog := log.WithFields("owner", owner, "id", result.ID)
 
 			// if the result is confirmed, we set it as done do stop looking into this monitored tx
 			if result.Status == MonitoredTxStatusConfirmed {
-				err := c.setStatusDone(ctx, owner, result.ID, dbTx)
+				err := c.SetStatusDone(ctx, owner, result.ID, dbTx)
 				if err != nil {
 					resultLog.Errorf("failed to set monitored tx as done, err: %v", err)
 					// if something goes wrong at this point, we skip this result and move to the next.
@@ -647,7 +656,7 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, owner string, r
 			}
 
 			// if the result is either not confirmed or failed, it means we need to wait until it gets confirmed of failed.
-			for {
+			for ctx.Err() == nil {
 				// wait before refreshing the result info
 				time.Sleep(time.Second)
 
@@ -32,6 +32,8 @@ const (
 	EventID_ExecutorRLPError EventID = "EXECUTOR RLP ERROR"
 	// EventID_FinalizerHalt is triggered when the finalizer halts
 	EventID_FinalizerHalt EventID = "FINALIZER HALT"
+	// EventID_FinalizerHaltRetried is triggered when the operator retries a halted finalizer
+	EventID_FinalizerHaltRetried EventID = "FINALIZER HALT RETRIED"
 	// EventID_FinalizerRestart is triggered when the finalizer restarts
 	EventID_FinalizerRestart EventID = "FINALIZER RESTART"
 	// EventID_FinalizerBreakEvenGasPriceBigDifference is triggered when the finalizer recalculates the break even gas price and detects a big difference
@@ -40,6 +42,38 @@ const (
 	EventID_SynchronizerRestart EventID = "SYNCHRONIZER RESTART"
 	// EventID_SynchronizerHalt is triggered when the synchronizer halts
 	EventID_SynchronizerHalt EventID = "SYNCHRONIZER HALT"
+	// EventID_SynchronizerStopped is triggered when the synchronizer reaches its stop batch or L1 block
+	EventID_SynchronizerStopped EventID = "SYNCHRONIZER STOPPED"
+	// EventID_SynchronizerHaltRetried is triggered when the operator retries a halted synchronizer
+	EventID_SynchronizerHaltRetried EventID = "SYNCHRONIZER HALT RETRIED"
+	// EventID_SynchronizerRollbackRequested is triggered when the operator requests a synchronizer rollback to a L1 block
+	EventID_SynchronizerRollbackRequested EventID = "SYNCHRONIZER ROLLBACK REQUESTED"
+	// EventID_SynchronizerRolledBack is triggered when the synchronizer rolls back to the L1 block requested by the operator
+	EventID_SynchronizerRolledBack EventID = "SYNCHRONIZER ROLLED BACK"
+	// EventID_SynchronizerForcedBatchCheckSkipped is triggered when the operator skips a failed forced batch check
+	EventID_SynchronizerForcedBatchCheckSkipped EventID = "SYNCHRONIZER FORCED BATCH CHECK SKIPPED"
+	// EventID_SequenceSenderHalt is triggered when the sequence sender halts
+	EventID_SequenceSenderHalt EventID = "SEQUENCE SENDER HALT"
+	// EventID_SequenceSenderHaltRetried is triggered when the operator retries a halted sequence sender
+	EventID_SequenceSenderHaltRetried EventID = "SEQUENCE SENDER HALT RETRIED"
+	// EventID_SequenceSenderPaused is triggered when the sequence sender is paused through its admin API
+	EventID_SequenceSenderPaused EventID = "SEQUENCE SENDER PAUSED"
+	// EventID_SequenceSenderResumed is triggered when the sequence sender is resumed through its admin API
+	EventID_SequenceSenderResumed EventID = "SEQUENCE SENDER RESUMED"
+	// EventID_SequenceSenderSendTriggered is triggered when a send attempt is forced through the sequence sender admin API
+	EventID_SequenceSenderSendTriggered EventID = "SEQUENCE SENDER SEND TRIGGERED"
+	// EventID_SequenceSenderSequenceCapped is triggered when the next sequence is capped through the sequence sender admin API
+	EventID_SequenceSenderSequenceCapped EventID = "SEQUENCE SENDER SEQUENCE CAPPED"
+	// EventID_InvalidDataCommitteeSignatures is triggered when the signatures collected from the data committee would be rejected by L1
+	EventID_InvalidDataCommitteeSignatures EventID = "INVALID DATA COMMITTEE SIGNATURES"
+	// EventID_AggregatorProofUnlocked is triggered when a proof is unlocked through the aggregator admin API
+	EventID_AggregatorProofUnlocked EventID = "AGGREGATOR PROOF UNLOCKED"
+	// EventID_AggregatorProofDeleted is triggered when a proof is deleted through the aggregator admin API
+	EventID_AggregatorProofDeleted EventID = "AGGREGATOR PROOF DELETED"
+	// EventID_AggregatorLockedProofsCleanedUp is triggered when the locked proofs are cleaned up through the aggregator admin API
+	EventID_AggregatorLockedProofsCleanedUp EventID = "AGGREGATOR LOCKED PROOFS CLEANED UP"
+	// EventID_AggregatorProverRejected is triggered when the aggregator rejects a prover failing the authentication
+	EventID_AggregatorProverRejected EventID = "AGGREGATOR PROVER REJECTED"
 	// Source_Node is the source of the event
 	Source_Node Source = "node"
 
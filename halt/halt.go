@@ -0,0 +1,96 @@
+package halt
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotHalted is returned when retrying a component that is not halted
+	ErrNotHalted = errors.New("not halted")
+	// ErrNotRetryable is returned when retrying a halt that can't be retried
+	ErrNotRetryable = errors.New("the halt can't be retried")
+)
+
+// Control holds the halt state of a component, which is retried by the operator through the admin API
+type Control struct {
+	mu        sync.Mutex
+	halted    bool
+	retryable bool
+	reason    string
+	haltedAt  time.Time
+	// retry wakes up the halted component
+	retry chan struct{}
+}
+
+// NewControl creates a Control of a component that is not halted
+func NewControl() *Control {
+	return &Control{retry: make(chan struct{}, 1)}
+}
+
+// Status is the halt state of a component
+type Status struct {
+	Halted    bool   `json:"halted"`
+	Retryable bool   `json:"retryable,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	HaltedAt  int64  `json:"haltedAt,omitempty"`
+}
+
+// Status returns the halt state of the component
+func (c *Control) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := Status{Halted: c.halted, Retryable: c.retryable, Reason: c.reason}
+	if c.halted {
+		status.HaltedAt = c.haltedAt.Unix()
+	}
+	return status
+}
+
+// SetHalted marks the component as halted. Only a retryable halt is woken up by WakeUp
+func (c *Control) SetHalted(reason string, retryable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.halted = true
+	c.retryable = retryable
+	c.reason = reason
+	c.haltedAt = time.Now()
+	// A retry requested before halting doesn't apply to this halt
+	select {
+	case <-c.retry:
+	default:
+	}
+}
+
+// ClearHalted marks the component as not halted
+func (c *Control) ClearHalted() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.halted = false
+	c.retryable = false
+	c.reason = ""
+}
+
+// WakeUp makes the halted component retry, signaling the Retry channel
+func (c *Control) WakeUp() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.halted {
+		return ErrNotHalted
+	}
+	if !c.retryable {
+		return ErrNotRetryable
+	}
+	select {
+	case c.retry <- struct{}{}:
+	default:
+		// a retry is already pending
+	}
+	return nil
+}
+
+// Retry is signaled when the operator retries the halted component
+func (c *Control) Retry() <-chan struct{} {
+	return c.retry
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +27,12 @@ func NewClient(url string) *Client {
 // the provided method and parameters, which is compatible with the Ethereum
 // JSON RPC Server.
 func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response, error) {
+	return JSONRPCCallWithContext(context.Background(), url, method, parameters...)
+}
+
+// JSONRPCCallWithContext executes a 2.0 JSON RPC HTTP Post Request to the provided URL with
+// the provided method and parameters, canceling it when the context is done.
+func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters ...interface{}) (types.Response, error) {
 	const jsonRPCVersion = "2.0"
 
 	params, err := json.Marshal(parameters)
@@ -46,7 +53,7 @@ func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response,
 	}
 
 	reqBodyReader := bytes.NewReader(reqBody)
-	httpReq, err := http.NewRequest(http.MethodPost, url, reqBodyReader)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqBodyReader)
 	if err != nil {
 		return types.Response{}, err
 	}
@@ -38,6 +38,24 @@ type Config struct {
 	// TraceBatchUseHTTPS enables, in the debug_traceBatchByNum endpoint, the use of the HTTPS protocol (instead of HTTP)
 	// to do the parallel requests to RPC.debug_traceTransaction endpoint
 	TraceBatchUseHTTPS bool `mapstructure:"TraceBatchUseHTTPS"`
+
+	// AuthToken, when set, is required in the Authorization header of the HTTP requests
+	// as a bearer token. Requests without it are rejected with 401 Unauthorized
+	AuthToken string `mapstructure:"AuthToken"`
+}
+
+// AdminAPIConfig represents the configuration of the admin JSON-RPC API of a component, which is
+// served apart from the public JSON-RPC endpoints
+type AdminAPIConfig struct {
+	// Enabled starts the API along with the component
+	Enabled bool `mapstructure:"Enabled"`
+	// Host is the address to bind the API
+	Host string `mapstructure:"Host"`
+	// Port is the port to bind the API
+	Port int `mapstructure:"Port"`
+	// AuthToken is the bearer token required by the API. The admin methods changing the state of
+	// the component are only available when it's set
+	AuthToken string `mapstructure:"AuthToken"`
 }
 
 // WebSocketsConfig has parameters to config the rpc websocket support
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/0xPolygon/cdk-validium-node/hex"
@@ -185,6 +186,42 @@ func (z *ZKEVMEndpoints) GetBatchByNumber(batchNumber types.BatchNumber, fullTx
 	})
 }
 
+// GetTrustedReorgs returns the divergences detected in the trusted state for the batches
+// between fromBatch and toBatch, from the first batch to the last one by default
+func (z *ZKEVMEndpoints) GetTrustedReorgs(fromBatch, toBatch *types.ArgUint64) (interface{}, types.Error) {
+	var fromBatchNumber uint64
+	if fromBatch != nil {
+		fromBatchNumber = uint64(*fromBatch)
+	}
+	if toBatch != nil && fromBatchNumber > uint64(*toBatch) {
+		return nil, types.NewRPCError(types.InvalidParamsErrorCode, "fromBatch can't be greater than toBatch")
+	}
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		var toBatchNumber uint64
+		if toBatch != nil {
+			toBatchNumber = uint64(*toBatch)
+			if toBatchNumber > math.MaxInt64 {
+				toBatchNumber = math.MaxInt64
+			}
+		} else {
+			lastBatchNumber, err := z.state.GetLastBatchNumber(ctx, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get the last batch number from state", err)
+			}
+			toBatchNumber = lastBatchNumber
+		}
+		divergences, err := z.state.GetTrustedDivergences(ctx, fromBatchNumber, toBatchNumber, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load the trusted reorgs from state", err)
+		}
+		reorgs := make([]types.TrustedReorg, 0, len(divergences))
+		for _, divergence := range divergences {
+			reorgs = append(reorgs, types.NewTrustedReorg(divergence))
+		}
+		return reorgs, nil
+	})
+}
+
 // GetFullBlockByNumber returns information about a block by block number
 func (z *ZKEVMEndpoints) GetFullBlockByNumber(number types.BlockNumber, fullTx bool) (interface{}, types.Error) {
 	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
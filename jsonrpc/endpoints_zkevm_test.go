@@ -1547,6 +1547,92 @@ func TestGetL2FullBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestGetTrustedReorgs(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	timestamp := time.Unix(1700000000, 0)
+	divergence := state.TrustedDivergence{
+		ID:                1,
+		Timestamp:         timestamp,
+		BatchNumber:       5,
+		Kind:              state.TrustedDivergenceL1,
+		ExpectedStateRoot: common.HexToHash("0x1"),
+		ActualStateRoot:   common.HexToHash("0x2"),
+		ExpectedGER:       common.HexToHash("0x3"),
+		ActualGER:         common.HexToHash("0x3"),
+		ExpectedTimestamp: timestamp,
+		ActualTimestamp:   timestamp,
+		ExpectedTxsHash:   common.HexToHash("0x4"),
+		ActualTxsHash:     common.HexToHash("0x5"),
+		ReorgedTxs:        []common.Hash{common.HexToHash("0x6")},
+		Reason:            "Different field StateRoot",
+	}
+
+	t.Run("get the trusted reorgs of a batch range", func(t *testing.T) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetTrustedDivergences", context.Background(), uint64(1), uint64(10), m.DbTx).Return([]state.TrustedDivergence{divergence}, nil).Once()
+
+		res, err := s.JSONRPCCall("zkevm_getTrustedReorgs", "0x1", "0xa")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+
+		var result []types.TrustedReorg
+		require.NoError(t, json.Unmarshal(res.Result, &result))
+		assert.Equal(t, []types.TrustedReorg{types.NewTrustedReorg(divergence)}, result)
+	})
+
+	t.Run("get all the trusted reorgs", func(t *testing.T) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetLastBatchNumber", context.Background(), m.DbTx).Return(uint64(20), nil).Once()
+		m.State.On("GetTrustedDivergences", context.Background(), uint64(0), uint64(20), m.DbTx).Return([]state.TrustedDivergence{}, nil).Once()
+
+		res, err := s.JSONRPCCall("zkevm_getTrustedReorgs")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+
+		var result []types.TrustedReorg
+		require.NoError(t, json.Unmarshal(res.Result, &result))
+		assert.Empty(t, result)
+	})
+
+	t.Run("get a state root divergence", func(t *testing.T) {
+		halted := state.TrustedDivergence{
+			ID:                2,
+			Timestamp:         timestamp,
+			BatchNumber:       7,
+			Kind:              state.TrustedDivergenceStateRoot,
+			Halted:            true,
+			ExpectedStateRoot: common.HexToHash("0x1"),
+			ActualStateRoot:   common.HexToHash("0x2"),
+			ReorgedTxs:        []common.Hash{},
+		}
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetTrustedDivergences", context.Background(), uint64(7), uint64(7), m.DbTx).Return([]state.TrustedDivergence{halted}, nil).Once()
+
+		res, err := s.JSONRPCCall("zkevm_getTrustedReorgs", "0x7", "0x7")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+
+		// The fields that weren't compared are zero
+		var result []types.TrustedReorg
+		require.NoError(t, json.Unmarshal(res.Result, &result))
+		require.Len(t, result, 1)
+		assert.Equal(t, types.ArgUint64(0), result[0].ExpectedTimestamp)
+		assert.Equal(t, common.Hash{}, result[0].ActualTxsHash)
+	})
+
+	t.Run("invalid batch range", func(t *testing.T) {
+		res, err := s.JSONRPCCall("zkevm_getTrustedReorgs", "0xa", "0x1")
+		require.NoError(t, err)
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+	})
+}
+
 func ptrUint64(n uint64) *uint64 {
 	return &n
 }
@@ -876,6 +876,32 @@ func (_m *StateMock) GetTransactionsByBatchNumber(ctx context.Context, batchNumb
 	return r0, r1, r2
 }
 
+// GetTrustedDivergences provides a mock function with given fields: ctx, fromBatchNumber, toBatchNumber, dbTx
+func (_m *StateMock) GetTrustedDivergences(ctx context.Context, fromBatchNumber uint64, toBatchNumber uint64, dbTx pgx.Tx) ([]state.TrustedDivergence, error) {
+	ret := _m.Called(ctx, fromBatchNumber, toBatchNumber, dbTx)
+
+	var r0 []state.TrustedDivergence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]state.TrustedDivergence, error)); ok {
+		return rf(ctx, fromBatchNumber, toBatchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) []state.TrustedDivergence); ok {
+		r0 = rf(ctx, fromBatchNumber, toBatchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.TrustedDivergence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromBatchNumber, toBatchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVerifiedBatch provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StateMock) GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -3,12 +3,14 @@ package jsonrpc
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -61,7 +63,10 @@ func NewServer(
 	storage storageInterface,
 	services []Service,
 ) *Server {
-	s.PrepareWebSocket()
+	// The state is nil for servers that only expose their own services, like the DAC stub
+	if s != nil {
+		s.PrepareWebSocket()
+	}
 	handler := newJSONRpcHandler()
 
 	for _, service := range services {
@@ -212,6 +217,11 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if !s.isAuthorized(req) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	if req.Method != "POST" {
 		err := errors.New("method " + req.Method + " not allowed")
 		s.handleInvalidRequest(w, err)
@@ -241,6 +251,28 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	combinedLog(req, start, http.StatusOK, respLen)
 }
 
+// isAuthorized checks the bearer token of the request when an auth token is configured
+func (s *Server) isAuthorized(req *http.Request) bool {
+	if s.config.AuthToken == "" {
+		return true
+	}
+	header := req.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	token := strings.TrimPrefix(header, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AuthToken)) == 1
+}
+
+// CheckAdminAuth rejects the admin methods of a component when its API is not protected by an auth
+// token. The token itself is checked by isAuthorized on every request
+func CheckAdminAuth(cfg AdminAPIConfig) types.Error {
+	if cfg.AuthToken == "" {
+		return types.NewRPCError(types.DefaultErrorCode, "admin methods require the API auth token to be configured")
+	}
+	return nil
+}
+
 func (s *Server) isSingleRequest(data []byte) (bool, types.Error) {
 	x := bytes.TrimLeft(data, " \t\r\n")
 
@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -171,3 +172,36 @@ func (s *mockedServer) JSONRPCCall(method string, parameters ...interface{}) (ty
 func (s *mockedServer) ChainID() uint64 {
 	return chainID
 }
+
+func TestIsAuthorized(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authToken     string
+		header        string
+		expectedValid bool
+	}{
+		{name: "no token configured", authToken: "", header: "", expectedValid: true},
+		{name: "missing header", authToken: "secret", header: "", expectedValid: false},
+		{name: "wrong scheme", authToken: "secret", header: "Basic secret", expectedValid: false},
+		{name: "wrong token", authToken: "secret", header: "Bearer wrong", expectedValid: false},
+		{name: "valid token", authToken: "secret", header: "Bearer secret", expectedValid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{config: Config{AuthToken: tc.authToken}}
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			require.Equal(t, tc.expectedValid, s.isAuthorized(req))
+		})
+	}
+}
+
+func TestCheckAdminAuth(t *testing.T) {
+	rpcErr := CheckAdminAuth(AdminAPIConfig{Enabled: true})
+	require.NotNil(t, rpcErr)
+	require.Equal(t, "admin methods require the API auth token to be configured", rpcErr.Error())
+	require.Nil(t, CheckAdminAuth(AdminAPIConfig{Enabled: true, AuthToken: "secret"}))
+}
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/0xPolygon/cdk-validium-node/hex"
 	"github.com/0xPolygon/cdk-validium-node/state"
@@ -668,6 +669,53 @@ func NewLog(l types.Log) Log {
 	}
 }
 
+// TrustedReorg structure, describing a divergence detected in the trusted state. The fields
+// that aren't compared for the kind are zero
+type TrustedReorg struct {
+	BatchNumber            ArgUint64     `json:"batchNumber"`
+	Timestamp              ArgUint64     `json:"timestamp"`
+	Kind                   string        `json:"kind"`
+	Halted                 bool          `json:"halted"`
+	ExpectedStateRoot      common.Hash   `json:"expectedStateRoot"`
+	ActualStateRoot        common.Hash   `json:"actualStateRoot"`
+	ExpectedGlobalExitRoot common.Hash   `json:"expectedGlobalExitRoot"`
+	ActualGlobalExitRoot   common.Hash   `json:"actualGlobalExitRoot"`
+	ExpectedTimestamp      ArgUint64     `json:"expectedTimestamp"`
+	ActualTimestamp        ArgUint64     `json:"actualTimestamp"`
+	ExpectedTxsHash        common.Hash   `json:"expectedTransactionsHash"`
+	ActualTxsHash          common.Hash   `json:"actualTransactionsHash"`
+	ReorgedTxs             []common.Hash `json:"reorgedTransactions"`
+	Reason                 string        `json:"reason"`
+}
+
+// NewTrustedReorg creates a TrustedReorg instance
+func NewTrustedReorg(d state.TrustedDivergence) TrustedReorg {
+	return TrustedReorg{
+		BatchNumber:            ArgUint64(d.BatchNumber),
+		Timestamp:              ArgUint64(d.Timestamp.Unix()),
+		Kind:                   string(d.Kind),
+		Halted:                 d.Halted,
+		ExpectedStateRoot:      d.ExpectedStateRoot,
+		ActualStateRoot:        d.ActualStateRoot,
+		ExpectedGlobalExitRoot: d.ExpectedGER,
+		ActualGlobalExitRoot:   d.ActualGER,
+		ExpectedTimestamp:      unixOrZero(d.ExpectedTimestamp),
+		ActualTimestamp:        unixOrZero(d.ActualTimestamp),
+		ExpectedTxsHash:        d.ExpectedTxsHash,
+		ActualTxsHash:          d.ActualTxsHash,
+		ReorgedTxs:             d.ReorgedTxs,
+		Reason:                 d.Reason,
+	}
+}
+
+// unixOrZero returns the unix time of t, or 0 if t is the zero time
+func unixOrZero(t time.Time) ArgUint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return ArgUint64(t.Unix())
+}
+
 // ToBatchNumArg converts a big.Int into a batch number rpc parameter
 func ToBatchNumArg(number *big.Int) string {
 	if number == nil {
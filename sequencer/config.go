@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -65,6 +66,9 @@ type Config struct {
 
 	// L2Coinbase is the address receives the L2 fee, should be consistent with the  L2Coinbase in SequenceSender
 	L2Coinbase common.Address `mapstructure:"L2Coinbase"`
+
+	// API is the configuration of the admin API of the sequencer, used to recover the finalizer when halted
+	API jsonrpc.AdminAPIConfig `mapstructure:"API"`
 }
 
 // FinalizerCfg contains the finalizer's configuration properties
@@ -0,0 +1,42 @@
+package sequencer
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+)
+
+// APISequencer is the namespace of the sequencer endpoints
+const APISequencer = "sequencer"
+
+// Endpoints contains the implementation of the "sequencer" endpoints
+type Endpoints struct {
+	s *Sequencer
+}
+
+// Services returns the JSON-RPC services exposed by the sequencer
+func (s *Sequencer) Services() []jsonrpc.Service {
+	return []jsonrpc.Service{
+		{
+			Name:    APISequencer,
+			Service: &Endpoints{s: s},
+		},
+	}
+}
+
+// GetFinalizerHaltStatus returns the halt reason of the finalizer
+func (e *Endpoints) GetFinalizerHaltStatus() (interface{}, types.Error) {
+	return e.s.FinalizerHaltStatus(), nil
+}
+
+// RetryFinalizer makes the halted finalizer retry the operation that halted it
+func (e *Endpoints) RetryFinalizer() (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	if err := e.s.RetryFinalizer(context.Background()); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return e.s.FinalizerHaltStatus(), nil
+}
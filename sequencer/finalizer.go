@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/halt"
 	"github.com/0xPolygon/cdk-validium-node/hex"
 	"github.com/0xPolygon/cdk-validium-node/log"
 	"github.com/0xPolygon/cdk-validium-node/pool"
@@ -57,6 +58,8 @@ type finalizer struct {
 	nextForcedBatchDeadline int64
 	nextForcedBatchesMux    *sync.RWMutex
 	handlingL2Reorg         bool
+	// halts holds the halt state, retried by the operator through the admin API
+	halts *halt.Control
 	// event log
 	eventLog *event.EventLog
 	// effective gas price calculation
@@ -139,6 +142,7 @@ func newFinalizer(
 		nextForcedBatchDeadline: 0,
 		nextForcedBatchesMux:    new(sync.RWMutex),
 		handlingL2Reorg:         false,
+		halts:                   halt.NewControl(),
 		// event log
 		eventLog:                                eventLog,
 		maxBreakEvenGasPriceDeviationPercentage: new(big.Int).SetUint64(effectiveGasPriceCfg.MaxBreakEvenGasPriceDeviationPercentage),
@@ -290,7 +294,8 @@ func (f *finalizer) listenForClosingSignals(ctx context.Context) {
 		case <-f.closingSignalCh.L2ReorgCh:
 			log.Debug("finalizer received L2 reorg event")
 			f.handlingL2Reorg = true
-			f.halt(ctx, fmt.Errorf("L2 reorg event received"))
+			// The WIP batch can be stale after the L2 reorg, so the finalizer isn't resumed
+			f.haltWith(ctx, fmt.Errorf("L2 reorg event received"), false)
 			return
 		}
 	}
@@ -365,6 +370,8 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 			// There is an error reprocessing previous batch closed (parallel sanity check)
 			// We halt the execution of the Sequencer at this point
 			f.halt(ctx, fmt.Errorf("halting Sequencer because of error reprocessing full batch (sanity check). Check previous errors in logs to know which was the cause"))
+			// The operator checked the error and retried the finalizer
+			f.reprocessFullBatchError.Store(false)
 		}
 
 		if f.isDeadlineEncountered() {
@@ -424,8 +431,13 @@ func (f *finalizer) finalizeBatch(ctx context.Context) {
 	}
 }
 
-// halt halts the finalizer
+// halt halts the finalizer until the operator retries it through the admin API
 func (f *finalizer) halt(ctx context.Context, err error) {
+	f.haltWith(ctx, err, true)
+}
+
+// haltWith halts the finalizer, until the operator retries it if the halt is retryable
+func (f *finalizer) haltWith(ctx context.Context, err error, retryable bool) {
 	event := &event.Event{
 		ReceivedAt:  time.Now(),
 		Source:      event.Source_Node,
@@ -440,10 +452,19 @@ func (f *finalizer) halt(ctx context.Context, err error) {
 		log.Errorf("error storing finalizer halt event: %v", eventErr)
 	}
 
+	f.halts.SetHalted(err.Error(), retryable)
+	defer f.halts.ClearHalted()
 	for {
 		log.Errorf("fatal error: %s", err)
 		log.Error("halting the finalizer")
-		time.Sleep(5 * time.Second) //nolint:gomnd
+		select {
+		case <-f.halts.Retry():
+			log.Info("resuming the halted finalizer")
+			return
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second): //nolint:gomnd
+		}
 	}
 }
 
@@ -496,9 +517,12 @@ func (f *finalizer) newWIPBatch(ctx context.Context) (*WipBatch, error) {
 
 	// Reprocess full batch as sanity check
 	if f.cfg.SequentialReprocessFullBatch {
-		// Do the full batch reprocess now
-		_, err := f.reprocessFullBatch(ctx, f.batch.batchNumber, f.batch.initialStateRoot, f.batch.stateRoot)
-		if err != nil {
+		// Do the full batch reprocess now, again each time the operator retries the halted finalizer
+		for {
+			_, err := f.reprocessFullBatch(ctx, f.batch.batchNumber, f.batch.initialStateRoot, f.batch.stateRoot)
+			if err == nil || ctx.Err() != nil {
+				break
+			}
 			// There is an error reprocessing the batch. We halt the execution of the Sequencer at this point
 			f.halt(ctx, fmt.Errorf("halting Sequencer because of error reprocessing full batch %d (sanity check). Error: %s ", f.batch.batchNumber, err))
 		}
@@ -631,6 +655,8 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker) (errW
 	} else if err == nil && !processBatchResponse.IsRomLevelError && len(processBatchResponse.Responses) == 0 && tx != nil {
 		err = fmt.Errorf("executor returned no errors and no responses for tx: %s", tx.HashStr)
 		f.halt(ctx, err)
+		// The tx is processed again once the operator retries the finalizer
+		return nil, err
 	} else if processBatchResponse.IsExecutorLevelError && tx != nil {
 		log.Errorf("error received from executor. Error: %v", err)
 		// Delete tx from the worker
@@ -779,8 +805,12 @@ func (f *finalizer) storeProcessedTx(ctx context.Context, txToStore transactionT
 	} else {
 		log.Info("storeProcessedTx: storing processed txToStore")
 	}
-	err := f.dbManager.StoreProcessedTxAndDeleteFromPool(ctx, txToStore)
-	if err != nil {
+	// The tx is stored again each time the operator retries the halted finalizer
+	for {
+		err := f.dbManager.StoreProcessedTxAndDeleteFromPool(ctx, txToStore)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
 		log.Info("halting the finalizer because of a database error on storing processed transaction")
 		f.halt(ctx, err)
 	}
@@ -998,10 +1028,14 @@ func (f *finalizer) processForcedBatch(ctx context.Context, lastBatchNumberInSta
 	}
 
 	response, err := f.dbManager.ProcessForcedBatch(forcedBatch.ForcedBatchNumber, request)
-	if err != nil {
-		// If there is EXECUTOR (Batch level) error, halt the finalizer.
+	for err != nil {
+		// If there is EXECUTOR (Batch level) error, halt the finalizer. The forced batch is processed
+		// again once the operator retries it
 		f.halt(ctx, fmt.Errorf("failed to process forced batch, Executor err: %w", err))
-		return lastBatchNumberInState, stateRoot
+		if ctx.Err() != nil {
+			return lastBatchNumberInState, stateRoot
+		}
+		response, err = f.dbManager.ProcessForcedBatch(forcedBatch.ForcedBatchNumber, request)
 	}
 
 	if len(response.Responses) > 0 && !response.IsRomOOCError {
@@ -0,0 +1,50 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/halt"
+	"github.com/0xPolygon/cdk-validium-node/log"
+)
+
+// ErrFinalizerNotHalted is returned when retrying a finalizer that is not halted
+var ErrFinalizerNotHalted = errors.New("finalizer is not halted")
+
+// FinalizerHaltStatus is the halt state of the finalizer
+type FinalizerHaltStatus = halt.Status
+
+// FinalizerHaltStatus returns the halt state of the finalizer
+func (s *Sequencer) FinalizerHaltStatus() FinalizerHaltStatus {
+	return s.halts.Status()
+}
+
+// RetryFinalizer makes the halted finalizer retry the operation that halted it. A finalizer halted
+// because of a persistent condition, like reaching StopSequencerOnBatchNum, halts again, and the
+// halt caused by a L2 reorg can't be retried
+func (s *Sequencer) RetryFinalizer(ctx context.Context) error {
+	if err := s.halts.WakeUp(); errors.Is(err, halt.ErrNotHalted) {
+		return ErrFinalizerNotHalted
+	} else if err != nil {
+		return err
+	}
+	description := "finalizer halt retried by the operator"
+	log.Warn(description)
+	if s.eventLog == nil {
+		return nil
+	}
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Sequencer,
+		Level:       event.Level_Warning,
+		EventID:     event.EventID_FinalizerHaltRetried,
+		Description: description,
+	}
+	if err := s.eventLog.LogEvent(ctx, ev); err != nil {
+		log.Errorf("error storing finalizer recovery event: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/event/nileventstorage"
+	"github.com/0xPolygon/cdk-validium-node/halt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryFinalizer(t *testing.T) {
+	ctx := context.Background()
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+	halts := halt.NewControl()
+	seq := &Sequencer{eventLog: eventLog, halts: halts}
+	finalizer := &finalizer{eventLog: eventLog, halts: halts}
+
+	assert.ErrorIs(t, seq.RetryFinalizer(ctx), ErrFinalizerNotHalted)
+
+	resumed := make(chan struct{})
+	go func() {
+		finalizer.halt(ctx, errors.New("executor error"))
+		close(resumed)
+	}()
+	require.Eventually(t, func() bool { return seq.FinalizerHaltStatus().Halted }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "executor error", seq.FinalizerHaltStatus().Reason)
+
+	require.NoError(t, seq.RetryFinalizer(ctx))
+	select {
+	case <-resumed:
+	case <-time.After(time.Second):
+		t.Fatal("the halted finalizer was not retried")
+	}
+	assert.False(t, seq.FinalizerHaltStatus().Halted)
+}
+
+func TestRetryFinalizerAfterL2Reorg(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+	halts := halt.NewControl()
+	seq := &Sequencer{eventLog: eventLog, halts: halts}
+	finalizer := &finalizer{eventLog: eventLog, halts: halts}
+
+	stopped := make(chan struct{})
+	go func() {
+		finalizer.haltWith(ctx, errors.New("L2 reorg event received"), false)
+		close(stopped)
+	}()
+	require.Eventually(t, func() bool { return seq.FinalizerHaltStatus().Halted }, time.Second, 10*time.Millisecond)
+	assert.False(t, seq.FinalizerHaltStatus().Retryable)
+
+	// The finalizer halted by a L2 reorg is not resumed
+	assert.ErrorIs(t, seq.RetryFinalizer(ctx), halt.ErrNotRetryable)
+	select {
+	case <-stopped:
+		t.Fatal("the finalizer halted by a L2 reorg was resumed")
+	case <-time.After(100 * time.Millisecond):
+	}
+	cancel()
+	<-stopped
+}
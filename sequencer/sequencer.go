@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/halt"
 	"github.com/0xPolygon/cdk-validium-node/log"
 	"github.com/0xPolygon/cdk-validium-node/pool"
 	"github.com/0xPolygon/cdk-validium-node/sequencer/metrics"
@@ -26,6 +27,9 @@ type Sequencer struct {
 	etherman     etherman
 
 	address common.Address
+
+	// halts holds the halt state of the finalizer, retried through the admin API
+	halts *halt.Control
 }
 
 // batchConstraints represents the constraints for a batch
@@ -75,6 +79,7 @@ func New(cfg Config, txPool txPool, state stateInterface, etherman etherman, man
 		ethTxManager: manager,
 		address:      addr,
 		eventLog:     eventLog,
+		halts:        halt.NewControl(),
 	}, nil
 }
 
@@ -115,6 +120,7 @@ func (s *Sequencer) Start(ctx context.Context) {
 	go dbManager.Start()
 
 	finalizer := newFinalizer(s.cfg.Finalizer, s.cfg.EffectiveGasPrice, worker, dbManager, s.state, s.address, s.isSynced, closingSignalCh, batchConstraints, s.eventLog)
+	finalizer.halts = s.halts
 	currBatch, processingReq := s.bootstrap(ctx, dbManager, finalizer)
 	go finalizer.Start(ctx, currBatch, processingReq)
 
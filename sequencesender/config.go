@@ -2,6 +2,7 @@ package sequencesender
 
 import (
 	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -24,4 +25,17 @@ type Config struct {
 	PrivateKey types.KeystoreFileConfig `mapstructure:"PrivateKey"`
 	// Batch number where there is a forkid change (fork upgrade)
 	ForkUpgradeBatchNumber uint64
+	// MaxL1CostPerBatch is the maximum L1 gas cost, in wei, per batch of a sequence. Before
+	// LastBatchVirtualizationTimeMaxWaitPeriod is reached, a sequence is only sent if its estimated
+	// gas cost divided by its amount of batches is under this value. The data committee only signs the
+	// sequence once the cost estimated from the previous sequences is under the limit. 0 means disabled
+	MaxL1CostPerBatch uint64 `mapstructure:"MaxL1CostPerBatch"`
+	// MaxSequenceFeePerBatch is the maximum fee per batch, in the smallest unit of the fee token, charged
+	// by the rollup contract to sequence batches. It applies the same way as MaxL1CostPerBatch. 0 means disabled
+	MaxSequenceFeePerBatch uint64 `mapstructure:"MaxSequenceFeePerBatch"`
+	// DryRun builds the sequences, collects their signatures and estimates their cost, but doesn't
+	// send them to L1. The built txs can be inspected through the sequence sender API
+	DryRun bool `mapstructure:"DryRun"`
+	// API is the configuration of the JSON-RPC API of the sequence sender
+	API jsonrpc.AdminAPIConfig `mapstructure:"API"`
 }
@@ -0,0 +1,145 @@
+package sequencesender
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/log"
+)
+
+// sendControl holds the runtime controls of the sequence sender set through its admin API
+type sendControl struct {
+	mu sync.Mutex
+	// paused stops sending sequences until resumed. Pending txs are still monitored
+	paused bool
+	// forceSend makes the next attempt send the sequences found, without waiting for more batches
+	forceSend bool
+	// capToBatch is the last batch of the next sequence sent, 0 if not capped
+	capToBatch uint64
+	// trigger wakes up the sequence sender when waiting for the next tick
+	trigger chan struct{}
+}
+
+func newSendControl() *sendControl {
+	return &sendControl{trigger: make(chan struct{}, 1)}
+}
+
+// SendControlStatus is the current state of the runtime controls of the sequence sender
+type SendControlStatus struct {
+	Paused bool `json:"paused"`
+	// ForceSend is true when a send attempt was triggered and not performed yet
+	ForceSend bool `json:"forceSend"`
+	// CapToBatch is the last batch of the next sequence sent, 0 if not capped
+	CapToBatch uint64 `json:"capToBatch"`
+}
+
+func (c *sendControl) status() SendControlStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return SendControlStatus{Paused: c.paused, ForceSend: c.forceSend, CapToBatch: c.capToBatch}
+}
+
+func (c *sendControl) setPaused(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = paused
+}
+
+// triggerSend forces the next attempt and wakes up the sequence sender if it's waiting
+func (c *sendControl) triggerSend() {
+	c.mu.Lock()
+	c.forceSend = true
+	c.mu.Unlock()
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+		// a wake up is already pending
+	}
+}
+
+// startAttempt returns whether sending is paused and whether the attempt was forced, consuming the trigger
+func (c *sendControl) startAttempt() (paused bool, forced bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	forced = c.forceSend
+	c.forceSend = false
+	return c.paused, forced
+}
+
+func (c *sendControl) setCap(toBatch uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capToBatch = toBatch
+}
+
+func (c *sendControl) getCap() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capToBatch
+}
+
+// clearCap removes the cap once a sequence up to it has been sent. A new cap set in the
+// meantime is kept
+func (c *sendControl) clearCap(toBatch uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.capToBatch != 0 && c.capToBatch <= toBatch {
+		c.capToBatch = 0
+	}
+}
+
+// Pause stops sending sequences to L1 until Resume is called
+func (s *SequenceSender) Pause(ctx context.Context) {
+	s.control.setPaused(true)
+	s.logControlEvent(ctx, event.EventID_SequenceSenderPaused, "sequence sender paused")
+}
+
+// Resume resumes sending sequences to L1
+func (s *SequenceSender) Resume(ctx context.Context) {
+	s.control.setPaused(false)
+	s.logControlEvent(ctx, event.EventID_SequenceSenderResumed, "sequence sender resumed")
+}
+
+// TriggerSend makes the sequence sender try to send the pending batches right away, without waiting
+// for the sequence to be worth sending. A single attempt is done even if the sequence sender is paused
+func (s *SequenceSender) TriggerSend(ctx context.Context) {
+	s.control.triggerSend()
+	s.logControlEvent(ctx, event.EventID_SequenceSenderSendTriggered, "sequence sender send attempt triggered")
+}
+
+// CapNextSequence limits the next sequence sent to L1 to end at toBatch at most. A toBatch
+// of 0 removes the cap
+func (s *SequenceSender) CapNextSequence(ctx context.Context, toBatch uint64) {
+	s.control.setCap(toBatch)
+	description := fmt.Sprintf("next sequence capped to batch %d", toBatch)
+	if toBatch == 0 {
+		description = "next sequence cap removed"
+	}
+	s.logControlEvent(ctx, event.EventID_SequenceSenderSequenceCapped, description)
+}
+
+// ControlStatus returns the current state of the runtime controls
+func (s *SequenceSender) ControlStatus() SendControlStatus {
+	return s.control.status()
+}
+
+func (s *SequenceSender) logControlEvent(ctx context.Context, eventID event.EventID, description string) {
+	log.Info(description)
+	if s.eventLog == nil {
+		return
+	}
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Sequence_Sender,
+		Level:       event.Level_Notice,
+		EventID:     eventID,
+		Description: description,
+	}
+	if err := s.eventLog.LogEvent(ctx, ev); err != nil {
+		log.Errorf("error storing sequence sender control event: %v", err)
+	}
+}
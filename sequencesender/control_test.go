@@ -0,0 +1,109 @@
+package sequencesender
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendControl(t *testing.T) {
+	c := newSendControl()
+
+	paused, forced := c.startAttempt()
+	assert.False(t, paused)
+	assert.False(t, forced)
+
+	c.setPaused(true)
+	c.triggerSend()
+	// A second trigger doesn't block while the first wake up is pending
+	c.triggerSend()
+	assert.Equal(t, SendControlStatus{Paused: true, ForceSend: true}, c.status())
+
+	paused, forced = c.startAttempt()
+	assert.True(t, paused)
+	assert.True(t, forced)
+	paused, forced = c.startAttempt()
+	assert.True(t, paused)
+	assert.False(t, forced)
+
+	c.setCap(10)
+	c.clearCap(9)
+	assert.Equal(t, uint64(10), c.getCap())
+	c.clearCap(10)
+	assert.Equal(t, uint64(0), c.getCap())
+}
+
+func TestWaitTickTriggered(t *testing.T) {
+	s := &SequenceSender{control: newSendControl()}
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	s.control.triggerSend()
+	done := make(chan struct{})
+	go func() {
+		s.waitTick(context.Background(), ticker)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("waitTick didn't return after a send attempt was triggered")
+	}
+}
+
+func TestControlEndpoints(t *testing.T) {
+	s := &SequenceSender{control: newSendControl()}
+	e := &Endpoints{s: s}
+
+	_, rpcErr := e.Pause()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "admin methods require the API auth token to be configured", rpcErr.Error())
+
+	s.cfg.API.AuthToken = "secret"
+	_, rpcErr = e.Pause()
+	require.Nil(t, rpcErr)
+	_, rpcErr = e.CapNextSequence(5)
+	require.Nil(t, rpcErr)
+	status, rpcErr := e.TriggerSend()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, SendControlStatus{Paused: true, ForceSend: true, CapToBatch: 5}, status)
+
+	status, rpcErr = e.Resume()
+	require.Nil(t, rpcErr)
+	assert.False(t, status.(SendControlStatus).Paused)
+}
+
+func TestGetSequencesToSendCapped(t *testing.T) {
+	ctx := context.Background()
+	stateMock := newStateMock(t)
+	s := &SequenceSender{
+		cfg:     Config{LastBatchVirtualizationTimeMaxWaitPeriod: types.NewDuration(time.Hour)},
+		state:   stateMock,
+		control: newSendControl(),
+	}
+	stateMock.On("GetLastVirtualBatchNum", ctx, nil).Return(uint64(0), nil)
+	stateMock.On("GetSignedSequence", ctx, uint64(1), nil).Return(nil, state.ErrNotFound)
+	for batchNumber := uint64(1); batchNumber <= 2; batchNumber++ {
+		stateMock.On("IsBatchClosed", ctx, batchNumber, nil).Return(true, nil)
+		stateMock.On("GetBatchByNumber", ctx, batchNumber, nil).Return(&state.Batch{BatchNumber: batchNumber, Timestamp: time.Now()}, nil)
+	}
+	stateMock.On("GetTimeForLatestBatchVirtualization", ctx, nil).Return(time.Now(), nil)
+	s.control.setCap(2)
+
+	// The cap limits the sequence, but doesn't send it before the deadline
+	sequences, _, err := s.getSequencesToSend(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, sequences)
+
+	// A triggered send attempt sends the sequence up to the cap
+	sequences, _, err = s.getSequencesToSend(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, sequences, 2)
+	assert.Equal(t, uint64(2), sequences[1].BatchNumber)
+	stateMock.AssertNotCalled(t, "IsBatchClosed", ctx, uint64(3), nil)
+}
@@ -0,0 +1,146 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/dataavailability/datacommittee"
+	ethmanTypes "github.com/0xPolygon/cdk-validium-node/etherman/types"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// dryRunResultsLimit is the amount of dry run results kept in memory
+const dryRunResultsLimit = 100
+
+// DryRunResult is a sequence tx built in dry run mode, which was not sent to L1
+type DryRunResult struct {
+	FromBatchNumber types.ArgUint64 `json:"fromBatchNumber"`
+	ToBatchNumber   types.ArgUint64 `json:"toBatchNumber"`
+	To              common.Address  `json:"to"`
+	Data            types.ArgBytes  `json:"data"`
+	// Signers are the data committee members whose signatures are included in the tx
+	Signers []common.Address `json:"signers"`
+	// Gas is the estimated gas of the tx, 0 if the estimation failed
+	Gas      types.ArgUint64 `json:"gas"`
+	GasPrice *types.ArgBig   `json:"gasPrice"`
+	// L1Cost is the estimated gas multiplied by the gas price, in wei
+	L1Cost *types.ArgBig `json:"l1Cost"`
+	// SequenceFee is the fee charged by the rollup contract to sequence the batches
+	SequenceFee *types.ArgBig `json:"sequenceFee"`
+	// Error is the reason why the tx couldn't be estimated, usually the revert reason
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// dryRunResults keeps the latest dry run results
+type dryRunResults struct {
+	mu      sync.RWMutex
+	results []DryRunResult
+}
+
+// add stores a result as the latest one, replacing the stored result that covers the same batches
+func (r *dryRunResults) add(result DryRunResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.results {
+		if r.results[i].FromBatchNumber == result.FromBatchNumber && r.results[i].ToBatchNumber == result.ToBatchNumber {
+			r.results = append(r.results[:i], r.results[i+1:]...)
+			break
+		}
+	}
+	r.results = append(r.results, result)
+	if len(r.results) > dryRunResultsLimit {
+		r.results = r.results[len(r.results)-dryRunResultsLimit:]
+	}
+}
+
+// list returns the stored results, from the oldest to the latest
+func (r *dryRunResults) list() []DryRunResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	results := make([]DryRunResult, len(r.results))
+	copy(results, r.results)
+	return results
+}
+
+// latest returns the latest result, if any
+func (r *dryRunResults) latest() (DryRunResult, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.results) == 0 {
+		return DryRunResult{}, false
+	}
+	return r.results[len(r.results)-1], true
+}
+
+// storeDryRun estimates the cost of the sequence tx and stores it instead of sending it to L1.
+// The signatures of the sequence are deleted, as the batches won't be virtualized and the next
+// sequence must be able to grow with the new batches instead of reusing them
+func (s *SequenceSender) storeDryRun(ctx context.Context, sequences []ethmanTypes.Sequence, to *common.Address, data, signaturesAndAddrs []byte) {
+	firstSequence := sequences[0]
+	lastSequence := sequences[len(sequences)-1]
+	result := DryRunResult{
+		FromBatchNumber: types.ArgUint64(firstSequence.BatchNumber),
+		ToBatchNumber:   types.ArgUint64(lastSequence.BatchNumber),
+		Data:            data,
+		Signers:         s.getSigners(ctx, firstSequence.BatchNumber, signaturesAndAddrs),
+		CreatedAt:       time.Now(),
+	}
+	if to != nil {
+		result.To = *to
+	}
+
+	gasPrice := s.etherman.GetL1GasPrice(ctx)
+	result.GasPrice = (*types.ArgBig)(gasPrice)
+	tx, err := s.etherman.EstimateGasSequenceBatches(s.cfg.SenderAddress, sequences, s.cfg.L2Coinbase, signaturesAndAddrs)
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Gas = types.ArgUint64(tx.Gas())
+		result.L1Cost = (*types.ArgBig)(new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), gasPrice))
+	}
+	fee, err := s.etherman.GetSendSequenceFee(uint64(len(sequences)))
+	if err != nil {
+		log.Warnf("dry run: failed to get the fee to sequence batches, err: %v", err)
+	} else {
+		result.SequenceFee = (*types.ArgBig)(fee)
+	}
+
+	s.dryRunResults.add(result)
+	s.dryRunToBatch = lastSequence.BatchNumber
+	log.Infof("dry run: sequence from batch %d to batch %d not sent to L1. Gas: %d, error: %q",
+		firstSequence.BatchNumber, lastSequence.BatchNumber, result.Gas, result.Error)
+
+	if err := s.state.DeleteSignedSequence(ctx, firstSequence.BatchNumber, nil); err != nil {
+		log.Warnf("dry run: failed to delete signed sequence starting at batch %d, err: %v", firstSequence.BatchNumber, err)
+	}
+}
+
+// getSigners returns the data committee members that signed the sequence, using the signed hash stored
+// when the signatures were collected. The list is empty if the signatures aren't from the data committee
+func (s *SequenceSender) getSigners(ctx context.Context, fromBatchNumber uint64, signaturesAndAddrs []byte) []common.Address {
+	signedSequence, err := s.state.GetSignedSequence(ctx, fromBatchNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return []common.Address{}
+	} else if err != nil {
+		log.Warnf("dry run: failed to get signed sequence starting at batch %d, err: %v", fromBatchNumber, err)
+		return []common.Address{}
+	}
+	committee, err := s.etherman.GetLatestDataCommittee()
+	if err != nil {
+		log.Warnf("dry run: failed to get the data committee, err: %v", err)
+		return []common.Address{}
+	}
+	signers, err := datacommittee.Signers(signedSequence.SignedHash, signaturesAndAddrs, committee.RequiredSignatures)
+	if err != nil {
+		log.Warnf("dry run: failed to get the signers of the sequence, err: %v", err)
+		return []common.Address{}
+	}
+	return signers
+}
@@ -0,0 +1,97 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethmanTypes "github.com/0xPolygon/cdk-validium-node/etherman/types"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunResults(t *testing.T) {
+	var results dryRunResults
+
+	_, found := results.latest()
+	assert.False(t, found)
+	assert.Empty(t, results.list())
+
+	results.add(DryRunResult{FromBatchNumber: 1, ToBatchNumber: 2, Error: "first attempt"})
+	results.add(DryRunResult{FromBatchNumber: 1, ToBatchNumber: 2})
+	list := results.list()
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].Error)
+
+	results.add(DryRunResult{FromBatchNumber: 1, ToBatchNumber: 3})
+	latest, found := results.latest()
+	require.True(t, found)
+	assert.Equal(t, types.ArgUint64(3), latest.ToBatchNumber)
+	assert.Len(t, results.list(), 2)
+
+	// A result covering the same batches as an older one replaces it as the latest one
+	results.add(DryRunResult{FromBatchNumber: 1, ToBatchNumber: 2, Error: "again"})
+	list = results.list()
+	require.Len(t, list, 2)
+	assert.Equal(t, types.ArgUint64(3), list[0].ToBatchNumber)
+	assert.Equal(t, "again", list[1].Error)
+
+	for i := 0; i < dryRunResultsLimit; i++ {
+		results.add(DryRunResult{FromBatchNumber: types.ArgUint64(10 + i), ToBatchNumber: types.ArgUint64(10 + i)})
+	}
+	list = results.list()
+	require.Len(t, list, dryRunResultsLimit)
+	assert.Equal(t, types.ArgUint64(10), list[0].FromBatchNumber)
+	assert.Equal(t, types.ArgUint64(10+dryRunResultsLimit-1), list[dryRunResultsLimit-1].FromBatchNumber)
+}
+
+func TestDryRunEndpoints(t *testing.T) {
+	s := &SequenceSender{}
+	e := &Endpoints{s: s}
+
+	_, rpcErr := e.GetDryRunResults()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "dry run mode is disabled", rpcErr.Error())
+
+	s.cfg.DryRun = true
+	result, rpcErr := e.GetLatestDryRunResult()
+	require.Nil(t, rpcErr)
+	assert.Nil(t, result)
+
+	s.dryRunResults.add(DryRunResult{FromBatchNumber: 1, ToBatchNumber: 2})
+	result, rpcErr = e.GetLatestDryRunResult()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, DryRunResult{FromBatchNumber: 1, ToBatchNumber: 2}, result)
+
+	result, rpcErr = e.GetDryRunResults()
+	require.Nil(t, rpcErr)
+	assert.Len(t, result, 1)
+}
+
+func TestStoreDryRun(t *testing.T) {
+	ctx := context.Background()
+	ethermanMock := newEthermanMock(t)
+	stateMock := newStateMock(t)
+	s := &SequenceSender{etherman: ethermanMock, state: stateMock}
+	sequences := []ethmanTypes.Sequence{{BatchNumber: 3}, {BatchNumber: 4}}
+
+	stateMock.On("GetSignedSequence", ctx, uint64(3), nil).Return(nil, state.ErrNotFound).Once()
+	ethermanMock.On("GetL1GasPrice", ctx).Return(big.NewInt(10)).Once()
+	ethermanMock.On("EstimateGasSequenceBatches", s.cfg.SenderAddress, sequences, s.cfg.L2Coinbase, []byte{}).
+		Return(nil, errors.New("execution reverted")).Once()
+	ethermanMock.On("GetSendSequenceFee", uint64(2)).Return(big.NewInt(100), nil).Once()
+	// The signatures are deleted, so the next sequence can grow with the new batches
+	stateMock.On("DeleteSignedSequence", ctx, uint64(3), nil).Return(nil).Once()
+
+	s.storeDryRun(ctx, sequences, nil, []byte{0x1}, []byte{})
+	assert.Equal(t, uint64(4), s.dryRunToBatch)
+	latest, found := s.dryRunResults.latest()
+	require.True(t, found)
+	assert.Equal(t, types.ArgUint64(3), latest.FromBatchNumber)
+	assert.Equal(t, types.ArgUint64(4), latest.ToBatchNumber)
+	assert.Equal(t, "execution reverted", latest.Error)
+	assert.Equal(t, (*types.ArgBig)(big.NewInt(100)), latest.SequenceFee)
+}
@@ -0,0 +1,103 @@
+package sequencesender
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+)
+
+// APISequenceSender is the namespace of the sequence sender endpoints
+const APISequenceSender = "seqsender"
+
+// Endpoints contains the implementation of the "seqsender" endpoints
+type Endpoints struct {
+	s *SequenceSender
+}
+
+// Services returns the JSON-RPC services exposed by the sequence sender
+func (s *SequenceSender) Services() []jsonrpc.Service {
+	return []jsonrpc.Service{
+		{
+			Name:    APISequenceSender,
+			Service: &Endpoints{s: s},
+		},
+	}
+}
+
+// GetDryRunResults returns the sequence txs built in dry run mode, from the oldest to the latest
+func (e *Endpoints) GetDryRunResults() (interface{}, types.Error) {
+	if !e.s.cfg.DryRun {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "dry run mode is disabled")
+	}
+	return e.s.dryRunResults.list(), nil
+}
+
+// GetLatestDryRunResult returns the latest sequence tx built in dry run mode, or null if there is none
+func (e *Endpoints) GetLatestDryRunResult() (interface{}, types.Error) {
+	if !e.s.cfg.DryRun {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "dry run mode is disabled")
+	}
+	result, found := e.s.dryRunResults.latest()
+	if !found {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// GetStatus returns the state of the runtime controls of the sequence sender
+func (e *Endpoints) GetStatus() (interface{}, types.Error) {
+	return e.s.ControlStatus(), nil
+}
+
+// Pause stops sending sequences to L1 until resumed
+func (e *Endpoints) Pause() (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	e.s.Pause(context.Background())
+	return e.s.ControlStatus(), nil
+}
+
+// Resume resumes sending sequences to L1
+func (e *Endpoints) Resume() (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	e.s.Resume(context.Background())
+	return e.s.ControlStatus(), nil
+}
+
+// TriggerSend forces an immediate attempt to send the pending batches to L1
+func (e *Endpoints) TriggerSend() (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	e.s.TriggerSend(context.Background())
+	return e.s.ControlStatus(), nil
+}
+
+// CapNextSequence limits the last batch of the next sequence sent to L1, 0 removes the cap
+func (e *Endpoints) CapNextSequence(toBatch types.ArgUint64) (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	e.s.CapNextSequence(context.Background(), uint64(toBatch))
+	return e.s.ControlStatus(), nil
+}
+
+// GetHaltStatus returns the halt reason of the sequence sender
+func (e *Endpoints) GetHaltStatus() (interface{}, types.Error) {
+	return e.s.HaltStatus(), nil
+}
+
+// RetryHalt resumes the halted sequence sender, discarding the failed sequence tx that halted it
+func (e *Endpoints) RetryHalt() (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	if err := e.s.RetryHalt(context.Background()); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return e.s.HaltStatus(), nil
+}
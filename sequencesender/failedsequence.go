@@ -0,0 +1,202 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/ethtxmanager"
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/halt"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+)
+
+// failedSequenceAction is what the sequence sender does after a sequence tx fails on L1
+type failedSequenceAction string
+
+const (
+	// failedSequenceActionRebuild discards the failed tx and builds the sequence again from the last virtual batch
+	failedSequenceActionRebuild failedSequenceAction = "rebuild"
+	// failedSequenceActionResign discards the failed tx and the stored signatures, so they are collected
+	// again from the current data committee
+	failedSequenceActionResign failedSequenceAction = "resign"
+	// failedSequenceActionHalt stops the sequence sender until an operator reviews the failure
+	failedSequenceActionHalt failedSequenceAction = "halt"
+)
+
+var (
+	// ErrNotHalted is returned when retrying a sequence sender that is not halted
+	ErrNotHalted = errors.New("sequence sender is not halted")
+	// errUnrecoverableSequence is returned when a failed sequence tx can't be recovered automatically
+	errUnrecoverableSequence = errors.New("failed sequence can't be recovered automatically")
+	// committeeRevertErrors are the reverts caused by signatures that don't match the committee stored on L1
+	committeeRevertErrors = []string{
+		"UnexpectedCommitteeHash",
+		"UnexpectedAddrsBytesLength",
+		"UnexpectedAddrsAndSignaturesSize",
+		"CommitteeAddressDoesntExist",
+	}
+	// stateRevertErrors are the reverts caused by a sequence built on top of an outdated L1 state
+	stateRevertErrors = []string{
+		"SequenceZeroBatches",
+		"SequencedTimestampInvalid",
+		"SequencedTimestampBelowForcedTimestamp",
+		"GlobalExitRootNotExist",
+		"ForcedDataDoesNotMatch",
+		"ForceBatchesOverflow",
+	}
+)
+
+// classifyRevert returns the action to take for the revert message of a failed sequence tx.
+// The message is matched against the name of the custom errors of the contracts and against
+// their selector, as some nodes only return the raw revert data
+func classifyRevert(revertMessage string) (failedSequenceAction, string) {
+	if matched := matchRevertError(revertMessage, committeeRevertErrors); matched != "" {
+		return failedSequenceActionResign, matched
+	}
+	if matched := matchRevertError(revertMessage, stateRevertErrors); matched != "" {
+		return failedSequenceActionRebuild, matched
+	}
+	return failedSequenceActionHalt, ""
+}
+
+func matchRevertError(revertMessage string, errorNames []string) string {
+	lowerMessage := strings.ToLower(revertMessage)
+	for _, name := range errorNames {
+		selector := common.Bytes2Hex(crypto.Keccak256([]byte(name + "()"))[:4])
+		if strings.Contains(revertMessage, name) || strings.Contains(lowerMessage, selector) {
+			return name
+		}
+	}
+	return ""
+}
+
+// handleFailedSequence applies the recovery policy to a sequence tx that failed on L1. The failed
+// monitored tx is discarded unless the sequence sender has to halt, in which case an error wrapping
+// errUnrecoverableSequence is returned so the caller halts once the db tx of the monitored txs is released.
+// Any other error is temporary and the failed monitored tx is handled again later
+func (s *SequenceSender) handleFailedSequence(ctx context.Context, result ethtxmanager.MonitoredTxResult, dbTx pgx.Tx) error {
+	resultLog := log.WithFields("owner", ethTxManagerOwner, "id", result.ID)
+
+	// The operator reviewed the failure that halted the sequence sender and retried it
+	if result.ID == s.retriedFailedTxID {
+		if err := s.discardFailedTx(ctx, result.ID, dbTx); err != nil {
+			return err
+		}
+		resultLog.Warn("failed monitored tx discarded after the operator retried the halt, the sequence will be built again")
+		return nil
+	}
+
+	var fromBatchNum, toBatchNum uint64
+	if _, err := fmt.Sscanf(result.ID, monitoredIDFormat, &fromBatchNum, &toBatchNum); err != nil {
+		return fmt.Errorf("%w: failed to parse the batches of failed monitored tx %s: %v", errUnrecoverableSequence, result.ID, err)
+	}
+
+	revertMessage := s.getRevertMessage(ctx, result)
+	action, revertError := classifyRevert(revertMessage)
+	resultLog.Warnf("sequence of batches %d to %d failed on L1, revert message: %q, action: %s", fromBatchNum, toBatchNum, revertMessage, action)
+
+	switch action {
+	case failedSequenceActionResign:
+		// The committee could have been updated after the signatures were collected. The new committee
+		// is read from L1 when the signatures are collected again, as its hash won't match the cached one
+		if err := s.state.DeleteSignedSequence(ctx, fromBatchNum, dbTx); err != nil {
+			return fmt.Errorf("failed to delete signed sequence starting at batch %d: %w", fromBatchNum, err)
+		}
+	case failedSequenceActionRebuild:
+		// Nothing to clean, the next sequence is built from the last virtual batch once the state is synced
+	default:
+		return fmt.Errorf("%w: sequence of batches %d to %d (monitored tx %s) failed on L1 with revert message %q",
+			errUnrecoverableSequence, fromBatchNum, toBatchNum, result.ID, revertMessage)
+	}
+
+	if err := s.discardFailedTx(ctx, result.ID, dbTx); err != nil {
+		return err
+	}
+	resultLog.Infof("failed monitored tx discarded (%s), the sequence will be built again", revertError)
+	return nil
+}
+
+// discardFailedTx sets the failed monitored tx as done, so the sequence sender stops monitoring it
+func (s *SequenceSender) discardFailedTx(ctx context.Context, monitoredTxID string, dbTx pgx.Tx) error {
+	if err := s.ethTxManager.SetStatusDone(ctx, ethTxManagerOwner, monitoredTxID, dbTx); err != nil {
+		return fmt.Errorf("failed to discard failed monitored tx %s: %w", monitoredTxID, err)
+	}
+	return nil
+}
+
+// getRevertMessage returns the revert message of the failed txs of a monitored tx
+func (s *SequenceSender) getRevertMessage(ctx context.Context, result ethtxmanager.MonitoredTxResult) string {
+	for txHash, txResult := range result.Txs {
+		if txResult.Receipt == nil || txResult.Receipt.Status != types.ReceiptStatusFailed {
+			continue
+		}
+		if txResult.RevertMessage != "" {
+			return txResult.RevertMessage
+		}
+		revertMessage, err := s.etherman.GetRevertMessage(ctx, txResult.Tx)
+		if err != nil {
+			// The error returned by the node when calling the tx usually contains the revert reason
+			log.Warnf("failed to get revert message of tx %s, err: %v", txHash.String(), err)
+			return err.Error()
+		}
+		return revertMessage
+	}
+	return ""
+}
+
+// halt stops the sequence sender after storing an event with the reason, until the operator retries
+// it through the admin API. It returns true if the halt was retried
+func (s *SequenceSender) halt(ctx context.Context, err error) bool {
+	if s.eventLog != nil {
+		ev := &event.Event{
+			ReceivedAt:  time.Now(),
+			Source:      event.Source_Node,
+			Component:   event.Component_Sequence_Sender,
+			Level:       event.Level_Critical,
+			EventID:     event.EventID_SequenceSenderHalt,
+			Description: fmt.Sprintf("sequence sender halted due to error: %s", err),
+		}
+		if eventErr := s.eventLog.LogEvent(ctx, ev); eventErr != nil {
+			log.Errorf("error storing sequence sender halt event: %v", eventErr)
+		}
+	}
+
+	s.halts.SetHalted(err.Error(), true)
+	defer s.halts.ClearHalted()
+	for {
+		log.Errorf("fatal error: %s", err)
+		log.Error("halting the sequence sender")
+		select {
+		case <-s.halts.Retry():
+			log.Info("resuming the halted sequence sender")
+			return true
+		case <-ctx.Done():
+			return false
+		case <-time.After(5 * time.Second): //nolint:gomnd
+		}
+	}
+}
+
+// HaltStatus returns the halt state of the sequence sender
+func (s *SequenceSender) HaltStatus() halt.Status {
+	return s.halts.Status()
+}
+
+// RetryHalt resumes the halted sequence sender. The failed monitored tx that halted it is discarded
+// and the sequence is built again from the last virtual batch
+func (s *SequenceSender) RetryHalt(ctx context.Context) error {
+	if err := s.halts.WakeUp(); errors.Is(err, halt.ErrNotHalted) {
+		return ErrNotHalted
+	} else if err != nil {
+		return err
+	}
+	s.logControlEvent(ctx, event.EventID_SequenceSenderHaltRetried, "sequence sender halt retried by the operator")
+	return nil
+}
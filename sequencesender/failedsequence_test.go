@@ -0,0 +1,202 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/ethtxmanager"
+	"github.com/0xPolygon/cdk-validium-node/halt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyRevert(t *testing.T) {
+	tcs := []struct {
+		revertMessage  string
+		expectedAction failedSequenceAction
+		expectedError  string
+	}{
+		{
+			revertMessage:  "execution reverted: UnexpectedCommitteeHash",
+			expectedAction: failedSequenceActionResign,
+			expectedError:  "UnexpectedCommitteeHash",
+		},
+		{
+			// selector of CommitteeAddressDoesntExist()
+			revertMessage:  "execution reverted, data: 0x84317213",
+			expectedAction: failedSequenceActionResign,
+			expectedError:  "CommitteeAddressDoesntExist",
+		},
+		{
+			revertMessage:  "GlobalExitRootNotExist",
+			expectedAction: failedSequenceActionRebuild,
+			expectedError:  "GlobalExitRootNotExist",
+		},
+		{
+			revertMessage:  "OnlyTrustedSequencer",
+			expectedAction: failedSequenceActionHalt,
+		},
+		{
+			revertMessage:  "",
+			expectedAction: failedSequenceActionHalt,
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.revertMessage, func(t *testing.T) {
+			action, revertError := classifyRevert(tc.revertMessage)
+			assert.Equal(t, tc.expectedAction, action)
+			assert.Equal(t, tc.expectedError, revertError)
+		})
+	}
+}
+
+func TestHandleFailedSequence(t *testing.T) {
+	ctx := context.Background()
+	const monitoredID = "sequence-from-5-to-7"
+	failedResult := func(revertMessage string) ethtxmanager.MonitoredTxResult {
+		return ethtxmanager.MonitoredTxResult{
+			ID:     monitoredID,
+			Status: ethtxmanager.MonitoredTxStatusFailed,
+			Txs: map[common.Hash]ethtxmanager.TxResult{
+				common.HexToHash("0x1"): {
+					Tx:            types.NewTx(&types.LegacyTx{}),
+					Receipt:       &types.Receipt{Status: types.ReceiptStatusFailed},
+					RevertMessage: revertMessage,
+				},
+			},
+		}
+	}
+
+	type mocks struct {
+		etherman     *ethermanMock
+		state        *stateMock
+		ethTxManager *ethTxManagerMock
+	}
+	tcs := []struct {
+		name              string
+		result            ethtxmanager.MonitoredTxResult
+		retriedFailedTxID string
+		setup             func(m mocks)
+		expectedError     string
+		expectedHalt      bool
+	}{
+		{
+			name:   "resign",
+			result: failedResult("UnexpectedCommitteeHash"),
+			setup: func(m mocks) {
+				m.state.On("DeleteSignedSequence", ctx, uint64(5), nil).Return(nil).Once()
+				m.ethTxManager.On("SetStatusDone", ctx, ethTxManagerOwner, monitoredID, nil).Return(nil).Once()
+			},
+		},
+		{
+			name:   "resign keeps the monitored tx if the signatures can't be deleted",
+			result: failedResult("UnexpectedCommitteeHash"),
+			setup: func(m mocks) {
+				m.state.On("DeleteSignedSequence", ctx, uint64(5), nil).Return(errors.New("banana")).Once()
+			},
+			expectedError: "failed to delete signed sequence starting at batch 5: banana",
+		},
+		{
+			name:   "rebuild keeps the monitored tx if it can't be discarded",
+			result: failedResult("GlobalExitRootNotExist"),
+			setup: func(m mocks) {
+				m.ethTxManager.On("SetStatusDone", ctx, ethTxManagerOwner, monitoredID, nil).Return(errors.New("banana")).Once()
+			},
+			expectedError: "failed to discard failed monitored tx sequence-from-5-to-7: banana",
+		},
+		{
+			name:   "rebuild",
+			result: failedResult("GlobalExitRootNotExist"),
+			setup: func(m mocks) {
+				m.ethTxManager.On("SetStatusDone", ctx, ethTxManagerOwner, monitoredID, nil).Return(nil).Once()
+			},
+		},
+		{
+			name:   "rebuild with the revert message of the node",
+			result: failedResult(""),
+			setup: func(m mocks) {
+				m.etherman.On("GetRevertMessage", ctx, mock.Anything).Return("SequencedTimestampInvalid", nil).Once()
+				m.ethTxManager.On("SetStatusDone", ctx, ethTxManagerOwner, monitoredID, nil).Return(nil).Once()
+			},
+		},
+		{
+			name:          "halt",
+			result:        failedResult("OnlyTrustedSequencer"),
+			setup:         func(m mocks) {},
+			expectedError: "sequence of batches 5 to 7 (monitored tx sequence-from-5-to-7) failed on L1 with revert message \"OnlyTrustedSequencer\"",
+			expectedHalt:  true,
+		},
+		{
+			name:          "halt on an unknown monitored tx",
+			result:        ethtxmanager.MonitoredTxResult{ID: "banana", Status: ethtxmanager.MonitoredTxStatusFailed},
+			setup:         func(m mocks) {},
+			expectedError: "failed to parse the batches of failed monitored tx banana",
+			expectedHalt:  true,
+		},
+		{
+			name:              "discard the monitored tx retried by the operator",
+			result:            failedResult("OnlyTrustedSequencer"),
+			retriedFailedTxID: monitoredID,
+			setup: func(m mocks) {
+				m.ethTxManager.On("SetStatusDone", ctx, ethTxManagerOwner, monitoredID, nil).Return(nil).Once()
+			},
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			m := mocks{
+				etherman:     newEthermanMock(t),
+				state:        newStateMock(t),
+				ethTxManager: newEthTxManagerMock(t),
+			}
+			tc.setup(m)
+			s := &SequenceSender{etherman: m.etherman, state: m.state, ethTxManager: m.ethTxManager, retriedFailedTxID: tc.retriedFailedTxID}
+
+			err := s.handleFailedSequence(ctx, tc.result, nil)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.Equal(t, tc.expectedHalt, errors.Is(err, errUnrecoverableSequence))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRetryHalt(t *testing.T) {
+	s := &SequenceSender{control: newSendControl(), halts: halt.NewControl()}
+	e := &Endpoints{s: s}
+	s.cfg.API.AuthToken = "secret"
+
+	_, rpcErr := e.RetryHalt()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, ErrNotHalted.Error(), rpcErr.Error())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	retried := make(chan bool)
+	go func() {
+		retried <- s.halt(ctx, errors.New("banana"))
+	}()
+	require.Eventually(t, func() bool { return s.HaltStatus().Halted }, time.Second, 10*time.Millisecond)
+	status, rpcErr := e.GetHaltStatus()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "banana", status.(halt.Status).Reason)
+
+	_, rpcErr = e.RetryHalt()
+	require.Nil(t, rpcErr)
+	select {
+	case r := <-retried:
+		assert.True(t, r)
+	case <-time.After(time.Second):
+		t.Fatal("the sequence sender didn't resume after retrying the halt")
+	}
+	assert.False(t, s.HaltStatus().Halted)
+}
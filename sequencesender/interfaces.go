@@ -5,7 +5,7 @@ import (
 	"math/big"
 	"time"
 
-	theEtherman "github.com/0xPolygon/cdk-validium-node/etherman"
+	ethman "github.com/0xPolygon/cdk-validium-node/etherman"
 	ethmanTypes "github.com/0xPolygon/cdk-validium-node/etherman/types"
 	"github.com/0xPolygon/cdk-validium-node/ethtxmanager"
 	"github.com/0xPolygon/cdk-validium-node/state"
@@ -20,10 +20,13 @@ import (
 type etherman interface {
 	BuildSequenceBatchesTxData(sender common.Address, sequences []ethmanTypes.Sequence, l2Coinbase common.Address, committeeSignaturesAndAddrs []byte) (to *common.Address, data []byte, err error)
 	EstimateGasSequenceBatches(sender common.Address, sequences []ethmanTypes.Sequence, l2Coinbase common.Address, committeeSignaturesAndAddrs []byte) (*types.Transaction, error)
+	GetSendSequenceFee(numBatches uint64) (*big.Int, error)
+	GetL1GasPrice(ctx context.Context) *big.Int
 	GetLastBatchTimestamp() (uint64, error)
 	GetLatestBlockTimestamp(ctx context.Context) (uint64, error)
 	GetLatestBatchNumber() (uint64, error)
-	GetCurrentDataCommittee() (*theEtherman.DataCommittee, error)
+	GetLatestDataCommittee() (*ethman.DataCommittee, error)
+	GetRevertMessage(ctx context.Context, tx *types.Transaction) (string, error)
 }
 
 // stateInterface gathers the methods required to interact with the state.
@@ -34,9 +37,13 @@ type stateInterface interface {
 	GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error)
 	GetTimeForLatestBatchVirtualization(ctx context.Context, dbTx pgx.Tx) (time.Time, error)
 	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	GetSignedSequence(ctx context.Context, fromBatchNumber uint64, dbTx pgx.Tx) (*state.SignedSequence, error)
+	DeleteSignedSequence(ctx context.Context, fromBatchNumber uint64, dbTx pgx.Tx) error
+	DeleteSignedSequencesUntilBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
 }
 
 type ethTxManager interface {
 	Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, dbTx pgx.Tx) error
 	ProcessPendingMonitoredTxs(ctx context.Context, owner string, failedResultHandler ethtxmanager.ResultHandler, dbTx pgx.Tx)
+	SetStatusDone(ctx context.Context, owner, id string, dbTx pgx.Tx) error
 }
@@ -0,0 +1,138 @@
+package sequencesender
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman/types"
+)
+
+// sequenceCost is the estimated cost of sending a sequence to L1
+type sequenceCost struct {
+	numBatches uint64
+	gas        uint64
+	gasPrice   *big.Int
+	// l1CostPerBatch is the gas cost of the sequence tx divided by the amount of batches, in wei.
+	// It's nil when the gas of the sequence tx is unknown
+	l1CostPerBatch *big.Int
+	// feePerBatch is the fee charged by the rollup contract for each batch
+	feePerBatch *big.Int
+}
+
+func (c sequenceCost) String() string {
+	if c.l1CostPerBatch == nil {
+		return fmt.Sprintf("batches: %d, feePerBatch: %s", c.numBatches, c.feePerBatch.String())
+	}
+	return fmt.Sprintf("batches: %d, gas: %d, gasPrice: %s, l1CostPerBatch: %s, feePerBatch: %s",
+		c.numBatches, c.gas, c.gasPrice.String(), c.l1CostPerBatch.String(), c.feePerBatch.String())
+}
+
+// costLimitsEnabled returns true if the sequences can be sent before
+// LastBatchVirtualizationTimeMaxWaitPeriod depending on their cost
+func (s *SequenceSender) costLimitsEnabled() bool {
+	return s.cfg.MaxL1CostPerBatch > 0 || s.cfg.MaxSequenceFeePerBatch > 0
+}
+
+// sequenceGasEstimation is the gas estimated for a sequence tx signed by the data committee
+type sequenceGasEstimation struct {
+	numBatches uint64
+	gas        uint64
+}
+
+// sequenceGasModel learns the gas of the sequence txs from the ones estimated with the signatures of
+// the data committee, so the cost of a sequence can be checked before collecting its signatures. The
+// gas is modeled as a fixed gas per tx plus a gas per batch, fitted to the last estimations of two
+// sequences with a different amount of batches
+type sequenceGasModel struct {
+	last     *sequenceGasEstimation
+	previous *sequenceGasEstimation
+}
+
+// observe adds the gas estimated for a signed sequence tx with the given amount of batches
+func (m *sequenceGasModel) observe(numBatches, gas uint64) {
+	if m.last != nil && m.last.numBatches != numBatches {
+		m.previous = m.last
+	}
+	m.last = &sequenceGasEstimation{numBatches: numBatches, gas: gas}
+}
+
+// estimate returns the gas of a sequence tx with the given amount of batches, false until two
+// sequences with a different amount of batches have been observed
+func (m *sequenceGasModel) estimate(numBatches uint64) (uint64, bool) {
+	if m.last == nil || m.previous == nil {
+		return 0, false
+	}
+	smaller, bigger := m.previous, m.last
+	if smaller.numBatches > bigger.numBatches {
+		smaller, bigger = bigger, smaller
+	}
+	var gasPerBatch, fixedGas uint64
+	if bigger.gas > smaller.gas {
+		gasPerBatch = (bigger.gas - smaller.gas) / (bigger.numBatches - smaller.numBatches)
+	}
+	if smaller.gas > gasPerBatch*smaller.numBatches {
+		fixedGas = smaller.gas - gasPerBatch*smaller.numBatches
+	}
+	return fixedGas + gasPerBatch*numBatches, true
+}
+
+// estimateUnsignedSequenceCost estimates the cost of sending the sequences before the data committee
+// signs them. The gas is estimated by the gas model, so the L1 cost is left empty until the model
+// has learned the gas of the sequence txs
+func (s *SequenceSender) estimateUnsignedSequenceCost(ctx context.Context, sequences []types.Sequence) (sequenceCost, error) {
+	numBatches := uint64(len(sequences))
+	fee, err := s.etherman.GetSendSequenceFee(numBatches)
+	if err != nil {
+		return sequenceCost{}, fmt.Errorf("failed to get the fee to sequence batches: %w", err)
+	}
+	cost := sequenceCost{
+		numBatches:  numBatches,
+		feePerBatch: new(big.Int).Div(fee, new(big.Int).SetUint64(numBatches)),
+	}
+	if gas, ok := s.gasModel.estimate(numBatches); ok {
+		cost.setGas(gas, s.etherman.GetL1GasPrice(ctx))
+	}
+	return cost, nil
+}
+
+// estimateSequenceCost estimates the cost of sending the sequences with the given signatures.
+// The data committee signatures are needed, as the tx reverts without them
+func (s *SequenceSender) estimateSequenceCost(ctx context.Context, sequences []types.Sequence, signaturesAndAddrs []byte) (sequenceCost, error) {
+	numBatches := uint64(len(sequences))
+	tx, err := s.etherman.EstimateGasSequenceBatches(s.cfg.SenderAddress, sequences, s.cfg.L2Coinbase, signaturesAndAddrs)
+	if err != nil {
+		return sequenceCost{}, fmt.Errorf("failed to estimate gas to sequence batches: %w", err)
+	}
+	s.gasModel.observe(numBatches, tx.Gas())
+	fee, err := s.etherman.GetSendSequenceFee(numBatches)
+	if err != nil {
+		return sequenceCost{}, fmt.Errorf("failed to get the fee to sequence batches: %w", err)
+	}
+	cost := sequenceCost{
+		numBatches:  numBatches,
+		feePerBatch: new(big.Int).Div(fee, new(big.Int).SetUint64(numBatches)),
+	}
+	cost.setGas(tx.Gas(), s.etherman.GetL1GasPrice(ctx))
+	return cost, nil
+}
+
+// setGas sets the gas of the sequence tx and the L1 cost per batch it implies
+func (c *sequenceCost) setGas(gas uint64, gasPrice *big.Int) {
+	l1Cost := new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice)
+	c.gas = gas
+	c.gasPrice = gasPrice
+	c.l1CostPerBatch = l1Cost.Div(l1Cost, new(big.Int).SetUint64(c.numBatches))
+}
+
+// isWorthSending returns if the cost per batch is under the configured limits,
+// otherwise the reason why it's not worth sending the sequence yet. An empty L1 cost is not checked
+func (s *SequenceSender) isWorthSending(cost sequenceCost) (bool, string) {
+	if s.cfg.MaxL1CostPerBatch > 0 && cost.l1CostPerBatch != nil && cost.l1CostPerBatch.Cmp(new(big.Int).SetUint64(s.cfg.MaxL1CostPerBatch)) > 0 {
+		return false, fmt.Sprintf("L1 cost per batch %s is over the maximum %d", cost.l1CostPerBatch.String(), s.cfg.MaxL1CostPerBatch)
+	}
+	if s.cfg.MaxSequenceFeePerBatch > 0 && cost.feePerBatch.Cmp(new(big.Int).SetUint64(s.cfg.MaxSequenceFeePerBatch)) > 0 {
+		return false, fmt.Sprintf("sequence fee per batch %s is over the maximum %d", cost.feePerBatch.String(), s.cfg.MaxSequenceFeePerBatch)
+	}
+	return true, ""
+}
@@ -0,0 +1,113 @@
+package sequencesender
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman/types"
+)
+
+func TestIsWorthSending(t *testing.T) {
+	cost := sequenceCost{
+		numBatches:     10,
+		gas:            200000,
+		gasPrice:       big.NewInt(1000),
+		l1CostPerBatch: big.NewInt(20000000),
+		feePerBatch:    big.NewInt(100),
+	}
+	tcs := []struct {
+		description            string
+		maxL1CostPerBatch      uint64
+		maxSequenceFeePerBatch uint64
+		unknownL1Cost          bool
+		expected               bool
+	}{
+		{
+			description: "no limits",
+			expected:    true,
+		},
+		{
+			description:       "under the L1 cost limit",
+			maxL1CostPerBatch: 20000000,
+			expected:          true,
+		},
+		{
+			description:       "over the L1 cost limit",
+			maxL1CostPerBatch: 19999999,
+			expected:          false,
+		},
+		{
+			description:       "unknown L1 cost",
+			maxL1CostPerBatch: 1,
+			unknownL1Cost:     true,
+			expected:          true,
+		},
+		{
+			description:            "over the sequence fee limit",
+			maxL1CostPerBatch:      20000000,
+			maxSequenceFeePerBatch: 99,
+			expected:               false,
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			s := SequenceSender{cfg: Config{MaxL1CostPerBatch: tc.maxL1CostPerBatch, MaxSequenceFeePerBatch: tc.maxSequenceFeePerBatch}}
+			cost := cost
+			if tc.unknownL1Cost {
+				cost.l1CostPerBatch = nil
+			}
+			worth, reason := s.isWorthSending(cost)
+			assert.Equal(t, tc.expected, worth)
+			assert.Equal(t, tc.expected, reason == "")
+		})
+	}
+}
+
+func TestSequenceGasModel(t *testing.T) {
+	var m sequenceGasModel
+	_, ok := m.estimate(10)
+	assert.False(t, ok)
+
+	// Two sequences with the same amount of batches are not enough to learn the gas per batch
+	m.observe(2, 120000)
+	m.observe(2, 120000)
+	_, ok = m.estimate(10)
+	assert.False(t, ok)
+
+	m.observe(4, 140000)
+	gas, ok := m.estimate(10)
+	require.True(t, ok)
+	assert.Equal(t, uint64(200000), gas)
+
+	// The last estimations are used
+	m.observe(5, 160000)
+	gas, _ = m.estimate(10)
+	assert.Equal(t, uint64(260000), gas)
+}
+
+func TestEstimateUnsignedSequenceCost(t *testing.T) {
+	ctx := context.Background()
+	ethermanMock := newEthermanMock(t)
+	s := SequenceSender{etherman: ethermanMock}
+	sequences := make([]types.Sequence, 4)
+
+	// Only the fee is known until the gas of the sequences is learned
+	ethermanMock.On("GetSendSequenceFee", uint64(4)).Return(big.NewInt(400), nil)
+	cost, err := s.estimateUnsignedSequenceCost(ctx, sequences)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), cost.feePerBatch)
+	assert.Nil(t, cost.l1CostPerBatch)
+
+	s.gasModel.observe(2, 120000)
+	s.gasModel.observe(3, 130000)
+	ethermanMock.On("GetL1GasPrice", ctx).Return(big.NewInt(10)).Once()
+	cost, err = s.estimateUnsignedSequenceCost(ctx, sequences)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(140000), cost.gas)
+	assert.Equal(t, big.NewInt(350000), cost.l1CostPerBatch)
+}
@@ -2,14 +2,15 @@ package sequencesender
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
 	"github.com/0xPolygon/cdk-validium-node/etherman/types"
 	"github.com/0xPolygon/cdk-validium-node/ethtxmanager"
 	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/halt"
 	"github.com/0xPolygon/cdk-validium-node/log"
 	"github.com/0xPolygon/cdk-validium-node/sequencer/metrics"
 	"github.com/0xPolygon/cdk-validium-node/state"
@@ -20,6 +21,8 @@ import (
 const (
 	ethTxManagerOwner = "sequencer"
 	monitoredIDFormat = "sequence-from-%v-to-%v"
+	// maxMonitoredTxIDAttempts is the number of times a sequence covering the same batches can be sent again
+	maxMonitoredTxIDAttempts = 100
 )
 
 var (
@@ -32,22 +35,42 @@ var (
 // SequenceSender represents a sequence sender
 type SequenceSender struct {
 	cfg          Config
-	privKey      *ecdsa.PrivateKey
 	state        stateInterface
 	ethTxManager ethTxManager
 	etherman     etherman
 	eventLog     *event.EventLog
+	// daBackend makes the data of the sequences available and provides the attestation sent to L1
+	daBackend dataavailability.SequencePoster
+	// heldSequenceToBatch is the last batch of the last sequence that was not sent because of its cost,
+	// so it's not estimated again until new batches can be added to it
+	heldSequenceToBatch uint64
+	// gasModel estimates the gas of the sequences before the data committee signs them
+	gasModel sequenceGasModel
+	// dryRunToBatch is the last batch of the last sequence built in dry run mode, so the same
+	// sequence is not signed and built again until new batches can be added to it
+	dryRunToBatch uint64
+	// dryRunResults are the sequence txs built in dry run mode
+	dryRunResults dryRunResults
+	// control holds the runtime controls set through the admin API
+	control *sendControl
+	// halts holds the halt state of the sequence sender, which is retried through the admin API
+	halts *halt.Control
+	// retriedFailedTxID is the failed monitored tx that halted the sequence sender, which is discarded
+	// once the operator retries the halt
+	retriedFailedTxID string
 }
 
 // New inits sequence sender
-func New(cfg Config, state stateInterface, etherman etherman, manager ethTxManager, eventLog *event.EventLog, privKey *ecdsa.PrivateKey) (*SequenceSender, error) {
+func New(cfg Config, state stateInterface, etherman etherman, manager ethTxManager, eventLog *event.EventLog, daBackend dataavailability.SequencePoster) (*SequenceSender, error) {
 	return &SequenceSender{
 		cfg:          cfg,
 		state:        state,
 		etherman:     etherman,
 		ethTxManager: manager,
 		eventLog:     eventLog,
-		privKey:      privKey,
+		daBackend:    daBackend,
+		control:      newSendControl(),
+		halts:        halt.NewControl(),
 	}, nil
 }
 
@@ -62,35 +85,66 @@ func (s *SequenceSender) Start(ctx context.Context) {
 func (s *SequenceSender) tryToSendSequence(ctx context.Context, ticker *time.Ticker) {
 	retry := false
 	// process monitored sequences before starting a next cycle
-	s.ethTxManager.ProcessPendingMonitoredTxs(ctx, ethTxManagerOwner, func(result ethtxmanager.MonitoredTxResult, dbTx pgx.Tx) {
-		if result.Status == ethtxmanager.MonitoredTxStatusFailed {
+	// a failed sequence that can't be handled stops the processing, so the sequence sender halts
+	// or waits outside of it
+	var (
+		failedErr  error
+		failedTxID string
+	)
+	monitorCtx, stopMonitoring := context.WithCancel(ctx)
+	defer stopMonitoring()
+	s.ethTxManager.ProcessPendingMonitoredTxs(monitorCtx, ethTxManagerOwner, func(result ethtxmanager.MonitoredTxResult, dbTx pgx.Tx) {
+		if result.Status == ethtxmanager.MonitoredTxStatusFailed && failedErr == nil {
 			retry = true
-			resultLog := log.WithFields("owner", ethTxManagerOwner, "id", result.ID)
-			resultLog.Error("failed to send sequence, TODO: review this fatal and define what to do in this case")
+			if failedErr = s.handleFailedSequence(ctx, result, dbTx); failedErr != nil {
+				failedTxID = result.ID
+				stopMonitoring()
+			}
 		}
 	}, nil)
 
+	if errors.Is(failedErr, errUnrecoverableSequence) {
+		if s.halt(ctx, failedErr) {
+			s.retriedFailedTxID = failedTxID
+		}
+	} else if failedErr != nil {
+		log.Errorf("failed to handle failed monitored tx %s, retrying later. Err: %v", failedTxID, failedErr)
+		s.waitTick(ctx, ticker)
+	}
 	if retry {
 		return
 	}
 
+	paused, forced := s.control.startAttempt()
+	if paused && !forced {
+		log.Info("sequence sender is paused")
+		s.waitTick(ctx, ticker)
+		return
+	}
+
 	// Check if synchronizer is up to date
 	if !s.isSynced(ctx) {
 		log.Info("wait for synchronizer to sync last batch")
-		waitTick(ctx, ticker)
+		s.waitTick(ctx, ticker)
 		return
 	}
 
 	// Check if should send sequence to L1
 	log.Infof("getting sequences to send")
-	sequences, err := s.getSequencesToSend(ctx)
+	sequences, checkCost, err := s.getSequencesToSend(ctx, forced)
 	if err != nil || len(sequences) == 0 {
 		if err != nil {
 			log.Errorf("error getting sequences: %v", err)
 		} else {
 			log.Info("waiting for sequences to be worth sending to L1")
 		}
-		waitTick(ctx, ticker)
+		s.waitTick(ctx, ticker)
+		return
+	}
+
+	if s.cfg.DryRun && sequences[len(sequences)-1].BatchNumber == s.dryRunToBatch {
+		log.Info("dry run: waiting for new batches, the sequence was already built")
+		s.waitTick(ctx, ticker)
 		return
 	}
 
@@ -100,63 +154,145 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context, ticker *time.Tic
 		return
 	}
 
-	// Send sequences to L1
-	sequenceCount := len(sequences)
-	log.Infof(
-		"sending sequences to L1. From batch %d to batch %d",
-		lastVirtualBatchNum+1, lastVirtualBatchNum+uint64(sequenceCount),
-	)
-	metrics.SequencesSentToL1(float64(sequenceCount))
+	// Signatures of already virtualized batches are not needed anymore
+	err = s.state.DeleteSignedSequencesUntilBatch(ctx, lastVirtualBatchNum, nil)
+	if err != nil {
+		log.Warnf("failed to delete signed sequences until batch %d, err: %v", lastVirtualBatchNum, err)
+	}
 
-	// add sequence to be monitored
-	signaturesAndAddrs, err := s.getSignaturesAndAddrsFromDataCommittee(ctx, sequences)
+	firstSequence := sequences[0]
+	lastSequence := sequences[len(sequences)-1]
+	if checkCost {
+		// The data committee only signs the sequence once it's worth sending
+		cost, err := s.estimateUnsignedSequenceCost(ctx, sequences)
+		if err != nil {
+			log.Errorf("error estimating the cost of the sequence: %v", err)
+			return
+		}
+		if worth, reason := s.isWorthSending(cost); !worth {
+			s.holdSequence(ctx, ticker, lastSequence.BatchNumber, reason, cost)
+			return
+		}
+	}
+
+	signaturesAndAddrs, err := s.daBackend.PostSequence(ctx, sequences)
 	if err != nil {
-		log.Error("error getting signatures and addresses from the data committee: ", err)
+		log.Error("error posting sequences to the data availability backend: ", err)
 		return
 	}
+
+	if checkCost {
+		cost, err := s.estimateSequenceCost(ctx, sequences, signaturesAndAddrs)
+		if err != nil {
+			log.Errorf("error estimating the cost of the sequence: %v", err)
+			return
+		}
+		if worth, reason := s.isWorthSending(cost); !worth {
+			// The sequence will grow, so the signatures won't be reused
+			err = s.state.DeleteSignedSequence(ctx, firstSequence.BatchNumber, nil)
+			if err != nil {
+				log.Warnf("failed to delete signed sequence starting at batch %d, err: %v", firstSequence.BatchNumber, err)
+			}
+			s.holdSequence(ctx, ticker, lastSequence.BatchNumber, reason, cost)
+			return
+		}
+		log.Infof("sequence is worth sending to L1. Cost: %s", cost.String())
+	}
+	s.heldSequenceToBatch = 0
+
 	to, data, err := s.etherman.BuildSequenceBatchesTxData(s.cfg.SenderAddress, sequences, s.cfg.L2Coinbase, signaturesAndAddrs)
-	log.Warnf("to %s, data: %s", to, common.Bytes2Hex(data))
 	if err != nil {
 		log.Error("error estimating new sequenceBatches to add to eth tx manager: ", err)
 		return
 	}
-	firstSequence := sequences[0]
-	lastSequence := sequences[len(sequences)-1]
+	if s.cfg.DryRun {
+		s.storeDryRun(ctx, sequences, to, data, signaturesAndAddrs)
+		s.control.clearCap(lastSequence.BatchNumber)
+		s.waitTick(ctx, ticker)
+		return
+	}
+
+	// Send sequences to L1
+	sequenceCount := len(sequences)
+	log.Infof(
+		"sending sequences to L1. From batch %d to batch %d",
+		lastVirtualBatchNum+1, lastVirtualBatchNum+uint64(sequenceCount),
+	)
+	metrics.SequencesSentToL1(float64(sequenceCount))
+	log.Warnf("to %s, data: %s", to, common.Bytes2Hex(data))
+
+	// add sequence to be monitored
 	monitoredTxID := fmt.Sprintf(monitoredIDFormat, firstSequence.BatchNumber, lastSequence.BatchNumber)
-	err = s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to, nil, data, nil)
+	err = s.addMonitoredTx(ctx, monitoredTxID, to, data)
 	if err != nil {
 		log.Error("error to add sequences tx to eth tx manager: ", err)
 		return
 	}
+	s.control.clearCap(lastSequence.BatchNumber)
+}
+
+// holdSequence waits for more batches to be added to the sequence ending at toBatch, as it's not worth sending yet
+func (s *SequenceSender) holdSequence(ctx context.Context, ticker *time.Ticker, toBatch uint64, reason string, cost sequenceCost) {
+	log.Infof("waiting for more batches to send the sequence to L1, %s. Cost: %s", reason, cost.String())
+	s.heldSequenceToBatch = toBatch
+	s.waitTick(ctx, ticker)
+}
+
+// addMonitoredTx adds the sequence tx to the eth tx manager. A sequence rebuilt after a failed tx
+// can cover the same batches, so a suffix is added to the id when it's already taken
+func (s *SequenceSender) addMonitoredTx(ctx context.Context, monitoredTxID string, to *common.Address, data []byte) error {
+	id := monitoredTxID
+	for attempt := 1; ; attempt++ {
+		err := s.ethTxManager.Add(ctx, ethTxManagerOwner, id, s.cfg.SenderAddress, to, nil, data, nil)
+		if !errors.Is(err, ethtxmanager.ErrAlreadyExists) || attempt > maxMonitoredTxIDAttempts {
+			return err
+		}
+		id = fmt.Sprintf("%s-retry-%d", monitoredTxID, attempt)
+	}
 }
 
 // getSequencesToSend generates an array of sequences to be send to L1.
 // If the array is empty, it doesn't necessarily mean that there are no sequences to be sent,
-// it could be that it's not worth it to do so yet.
-func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequence, error) {
+// it could be that it's not worth it to do so yet. When checkCost is true, the sequences
+// should only be sent if their cost per batch is under the configured limits. When forced is true,
+// the sequences found are sent without waiting for more batches
+func (s *SequenceSender) getSequencesToSend(ctx context.Context, forced bool) (sequences []types.Sequence, checkCost bool, err error) {
 	lastVirtualBatchNum, err := s.state.GetLastVirtualBatchNum(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last virtual batch num, err: %w", err)
+		return nil, false, fmt.Errorf("failed to get last virtual batch num, err: %w", err)
 	}
 
 	currentBatchNumToSequence := lastVirtualBatchNum + 1
-	sequences := []types.Sequence{}
-	// var estimatedGas uint64
+	sequences = []types.Sequence{}
 
-	// var tx *ethTypes.Transaction
+	capToBatch := s.control.getCap()
+	if capToBatch != 0 && capToBatch < currentBatchNumToSequence {
+		log.Infof("ignoring the cap to batch %d, as it is already virtualized", capToBatch)
+		s.control.clearCap(capToBatch)
+		capToBatch = 0
+	}
 
-	// Add sequences until too big for a single L1 tx or last batch is reached
+	// If the data committee already signed a sequence starting at this batch, build the same
+	// sequence again so the stored signatures can be reused
+	var signedToBatchNum uint64
+	signedSequence, err := s.state.GetSignedSequence(ctx, currentBatchNumToSequence, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return nil, false, fmt.Errorf("failed to get signed sequence, err: %w", err)
+	} else if signedSequence != nil {
+		signedToBatchNum = signedSequence.ToBatchNumber
+	}
+	// Add sequences until too big for a single L1 tx, or last batch or cap is reached
 	for {
 		//Check if the next batch belongs to a new forkid, in this case we need to stop sequencing as we need to
 		//wait the upgrade of forkid is completed and s.cfg.NumBatchForkIdUpgrade is disabled (=0) again
 		if (s.cfg.ForkUpgradeBatchNumber != 0) && (currentBatchNumToSequence == (s.cfg.ForkUpgradeBatchNumber + 1)) {
-			return nil, fmt.Errorf("aborting sequencing process as we reached the batch %d where a new forkid is applied (upgrade)", s.cfg.ForkUpgradeBatchNumber+1)
+			return nil, false, fmt.Errorf("aborting sequencing process as we reached the batch %d where a new forkid is applied (upgrade)", s.cfg.ForkUpgradeBatchNumber+1)
 		}
 
 		// Check if batch is closed
 		isClosed, err := s.state.IsBatchClosed(ctx, currentBatchNumToSequence, nil)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		if !isClosed {
 			// Reached current (WIP) batch
@@ -165,7 +301,7 @@ func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequen
 		// Add new sequence
 		batch, err := s.state.GetBatchByNumber(ctx, currentBatchNumToSequence, nil)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		seq := types.Sequence{
@@ -178,18 +314,27 @@ func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequen
 		if batch.ForcedBatchNum != nil {
 			forcedBatch, err := s.state.GetForcedBatch(ctx, *batch.ForcedBatchNum, nil)
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 			seq.ForcedBatchTimestamp = forcedBatch.ForcedAt.Unix()
 		}
 
 		sequences = append(sequences, seq)
+		if currentBatchNumToSequence == capToBatch {
+			// the cap only limits the sequence, when to send it is decided as usual
+			log.Infof("no more batches added to the sequence, because the cap to batch %d has been reached", capToBatch)
+			break
+		}
+		if signedToBatchNum != 0 && currentBatchNumToSequence == signedToBatchNum {
+			log.Infof("sequence should be sent to L1, because it was already signed by the data committee up to batch %d", signedToBatchNum)
+			return sequences, false, nil
+		}
 		if len(sequences) == int(s.cfg.MaxBatchesForL1) {
 			log.Info(
 				"sequence should be sent to L1, because MaxBatchesForL1 (%d) has been reached",
 				s.cfg.MaxBatchesForL1,
 			)
-			return sequences, nil
+			return sequences, false, nil
 		}
 
 		// Increase batch num for next iteration
@@ -199,30 +344,44 @@ func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequen
 	// Reached latest batch. Decide if it's worth to send the sequence, or wait for new batches
 	if len(sequences) == 0 {
 		log.Info("no batches to be sequenced")
-		return nil, nil
+		return nil, false, nil
+	}
+
+	if forced {
+		log.Info("sequence should be sent to L1, because a send attempt was triggered")
+		return sequences, false, nil
 	}
 
 	lastBatchVirtualizationTime, err := s.state.GetTimeForLatestBatchVirtualization(ctx, nil)
 	if err != nil && !errors.Is(err, state.ErrNotFound) {
 		log.Warnf("failed to get last l1 interaction time, err: %v. Sending sequences as a conservative approach", err)
-		return sequences, nil
+		return sequences, false, nil
 	}
 	if lastBatchVirtualizationTime.Before(time.Now().Add(-s.cfg.LastBatchVirtualizationTimeMaxWaitPeriod.Duration)) {
-		// TODO: implement check profitability
-		// if s.checker.IsSendSequencesProfitable(new(big.Int).SetUint64(estimatedGas), sequences) {
 		log.Info("sequence should be sent to L1, because too long since didn't send anything to L1")
-		return sequences, nil
-		//}
+		return sequences, false, nil
+	}
+
+	if s.costLimitsEnabled() {
+		if sequences[len(sequences)-1].BatchNumber == s.heldSequenceToBatch {
+			log.Info("waiting for new batches to reduce the cost per batch of the sequence")
+			return nil, false, nil
+		}
+		log.Info("sequence could be sent to L1 before the deadline, if its cost per batch is low enough")
+		return sequences, true, nil
 	}
 
 	log.Info("not enough time has passed since last batch was virtualized, and the sequence could be bigger")
-	return nil, nil
+	return nil, false, nil
 }
 
-func waitTick(ctx context.Context, ticker *time.Ticker) {
+// waitTick waits for the next tick, unless a send attempt is triggered in the meantime
+func (s *SequenceSender) waitTick(ctx context.Context, ticker *time.Ticker) {
 	select {
 	case <-ticker.C:
 		// nothing
+	case <-s.control.trigger:
+		// nothing
 	case <-ctx.Done():
 		return
 	}
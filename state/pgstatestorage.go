@@ -2388,6 +2388,44 @@ func (p *PostgresStorage) DeleteUngeneratedProofs(ctx context.Context, dbTx pgx.
 	return err
 }
 
+// GetProofs returns all the proofs in the storage, generated or being generated,
+// ordered by batch number
+func (p *PostgresStorage) GetProofs(ctx context.Context, dbTx pgx.Tx) ([]*Proof, error) {
+	const getProofsSQL = `
+		SELECT 
+			batch_num, 
+			batch_num_final,
+			proof,
+			proof_id,
+			input_prover,
+			prover,
+			prover_id,
+			generating_since,
+			created_at,
+			updated_at
+		FROM state.proof
+		ORDER BY batch_num ASC, batch_num_final ASC
+		`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getProofsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	proofs := make([]*Proof, 0, len(rows.RawValues()))
+	for rows.Next() {
+		proof := &Proof{}
+		err := rows.Scan(&proof.BatchNumber, &proof.BatchNumberFinal, &proof.Proof, &proof.ProofID, &proof.InputProver, &proof.Prover, &proof.ProverID, &proof.GeneratingSince, &proof.CreatedAt, &proof.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, proof)
+	}
+	return proofs, rows.Err()
+}
+
 // GetLastClosedBatch returns the latest closed batch
 func (p *PostgresStorage) GetLastClosedBatch(ctx context.Context, dbTx pgx.Tx) (*Batch, error) {
 	const getLastClosedBatchSQL = `
@@ -2448,6 +2486,141 @@ func (p *PostgresStorage) AddTrustedReorg(ctx context.Context, reorg *TrustedReo
 	return err
 }
 
+// AddTrustedDivergence stores a divergence detected in the trusted state
+func (p *PostgresStorage) AddTrustedDivergence(ctx context.Context, divergence *TrustedDivergence, dbTx pgx.Tx) error {
+	const addTrustedDivergenceSQL = `
+		INSERT INTO state.trusted_divergence (batch_num, kind, halted, expected_state_root, actual_state_root, expected_ger, actual_ger,
+			expected_timestamp, actual_timestamp, expected_txs_hash, actual_txs_hash, reorged_txs, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	reorgedTxs := make([]string, 0, len(divergence.ReorgedTxs))
+	for _, txHash := range divergence.ReorgedTxs {
+		reorgedTxs = append(reorgedTxs, txHash.String())
+	}
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addTrustedDivergenceSQL, divergence.BatchNumber, string(divergence.Kind), divergence.Halted,
+		divergence.ExpectedStateRoot.String(), divergence.ActualStateRoot.String(), divergence.ExpectedGER.String(), divergence.ActualGER.String(),
+		divergence.ExpectedTimestamp.UTC(), divergence.ActualTimestamp.UTC(), divergence.ExpectedTxsHash.String(), divergence.ActualTxsHash.String(),
+		reorgedTxs, divergence.Reason)
+	return err
+}
+
+// GetTrustedDivergences returns the divergences detected in the trusted state for the batches
+// in the given range, in the order they were detected
+func (p *PostgresStorage) GetTrustedDivergences(ctx context.Context, fromBatchNumber, toBatchNumber uint64, dbTx pgx.Tx) ([]TrustedDivergence, error) {
+	const getTrustedDivergencesSQL = `
+		SELECT id, timestamp, batch_num, kind, halted, expected_state_root, actual_state_root, expected_ger, actual_ger,
+		       expected_timestamp, actual_timestamp, expected_txs_hash, actual_txs_hash, reorged_txs, reason
+		  FROM state.trusted_divergence
+		 WHERE batch_num >= $1 AND batch_num <= $2
+		 ORDER BY id ASC`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getTrustedDivergencesSQL, fromBatchNumber, toBatchNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	divergences := make([]TrustedDivergence, 0)
+	for rows.Next() {
+		var (
+			divergence                         TrustedDivergence
+			kind                               string
+			expectedStateRoot, actualStateRoot string
+			expectedGER, actualGER             string
+			expectedTxsHash, actualTxsHash     string
+			reorgedTxs                         []string
+		)
+		err := rows.Scan(&divergence.ID, &divergence.Timestamp, &divergence.BatchNumber, &kind, &divergence.Halted,
+			&expectedStateRoot, &actualStateRoot, &expectedGER, &actualGER, &divergence.ExpectedTimestamp, &divergence.ActualTimestamp,
+			&expectedTxsHash, &actualTxsHash, &reorgedTxs, &divergence.Reason)
+		if err != nil {
+			return nil, err
+		}
+		divergence.Kind = TrustedDivergenceKind(kind)
+		divergence.ExpectedStateRoot = common.HexToHash(expectedStateRoot)
+		divergence.ActualStateRoot = common.HexToHash(actualStateRoot)
+		divergence.ExpectedGER = common.HexToHash(expectedGER)
+		divergence.ActualGER = common.HexToHash(actualGER)
+		divergence.ExpectedTxsHash = common.HexToHash(expectedTxsHash)
+		divergence.ActualTxsHash = common.HexToHash(actualTxsHash)
+		divergence.ReorgedTxs = make([]common.Hash, 0, len(reorgedTxs))
+		for _, txHash := range reorgedTxs {
+			divergence.ReorgedTxs = append(divergence.ReorgedTxs, common.HexToHash(txHash))
+		}
+		divergences = append(divergences, divergence)
+	}
+	return divergences, rows.Err()
+}
+
+// AddSignedSequence stores a sequence signed by the data committee, replacing
+// any previous signed sequence starting at the same batch
+func (p *PostgresStorage) AddSignedSequence(ctx context.Context, signedSequence *SignedSequence, dbTx pgx.Tx) error {
+	const addSignedSequenceSQL = `
+		INSERT INTO state.signed_sequence (from_batch_num, to_batch_num, old_acc_input_hash, signed_hash, signatures_and_addrs, committee_hash)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (from_batch_num) DO UPDATE SET
+			to_batch_num = EXCLUDED.to_batch_num,
+			old_acc_input_hash = EXCLUDED.old_acc_input_hash,
+			signed_hash = EXCLUDED.signed_hash,
+			signatures_and_addrs = EXCLUDED.signatures_and_addrs,
+			committee_hash = EXCLUDED.committee_hash,
+			created_at = NOW()`
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addSignedSequenceSQL, signedSequence.FromBatchNumber, signedSequence.ToBatchNumber,
+		signedSequence.OldAccInputHash.String(), signedSequence.SignedHash.String(), signedSequence.SignaturesAndAddrs,
+		signedSequence.CommitteeHash.String())
+	return err
+}
+
+// GetSignedSequence returns the signed sequence starting at the given batch
+func (p *PostgresStorage) GetSignedSequence(ctx context.Context, fromBatchNumber uint64, dbTx pgx.Tx) (*SignedSequence, error) {
+	const getSignedSequenceSQL = `
+		SELECT from_batch_num, to_batch_num, old_acc_input_hash, signed_hash, signatures_and_addrs, committee_hash
+		  FROM state.signed_sequence
+		 WHERE from_batch_num = $1`
+
+	var (
+		signedSequence  SignedSequence
+		oldAccInputHash string
+		signedHash      string
+		committeeHash   string
+	)
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getSignedSequenceSQL, fromBatchNumber).Scan(&signedSequence.FromBatchNumber, &signedSequence.ToBatchNumber,
+		&oldAccInputHash, &signedHash, &signedSequence.SignaturesAndAddrs, &committeeHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	signedSequence.OldAccInputHash = common.HexToHash(oldAccInputHash)
+	signedSequence.SignedHash = common.HexToHash(signedHash)
+	signedSequence.CommitteeHash = common.HexToHash(committeeHash)
+	return &signedSequence, nil
+}
+
+// DeleteSignedSequence deletes the signed sequence starting at the given batch
+func (p *PostgresStorage) DeleteSignedSequence(ctx context.Context, fromBatchNumber uint64, dbTx pgx.Tx) error {
+	const deleteSignedSequenceSQL = "DELETE FROM state.signed_sequence WHERE from_batch_num = $1"
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, deleteSignedSequenceSQL, fromBatchNumber)
+	return err
+}
+
+// DeleteSignedSequencesUntilBatch deletes the signed sequences whose batches are all
+// lower or equal than the given batch number
+func (p *PostgresStorage) DeleteSignedSequencesUntilBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	const deleteSignedSequencesSQL = "DELETE FROM state.signed_sequence WHERE to_batch_num <= $1"
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, deleteSignedSequencesSQL, batchNumber)
+	return err
+}
+
 // CountReorgs returns the number of reorgs
 func (p *PostgresStorage) CountReorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	const countReorgsSQL = "SELECT COUNT(*) FROM state.trusted_reorg"
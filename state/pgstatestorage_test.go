@@ -398,6 +398,48 @@ func TestCleanupLockedProofs(t *testing.T) {
 	assert.Contains(proofs, newerProof)
 }
 
+func TestGetProofs(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	batchNumber := uint64(42)
+	_, err = testState.PostgresStorage.Exec(ctx, "INSERT INTO state.batch (batch_num) VALUES ($1), ($2)", batchNumber, batchNumber+1)
+	require.NoError(t, err)
+
+	proofs, err := testState.GetProofs(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, proofs)
+
+	now := time.Now().Round(time.Microsecond)
+	proverName, proverID := "prover", "prover-1"
+	generatingProof := &state.Proof{
+		BatchNumber:      batchNumber + 1,
+		BatchNumberFinal: batchNumber + 1,
+		Prover:           &proverName,
+		ProverID:         &proverID,
+		GeneratingSince:  &now,
+	}
+	generatedProofID := "generatedProofID"
+	generatedProof := &state.Proof{
+		BatchNumber:      batchNumber,
+		BatchNumberFinal: batchNumber,
+		Proof:            "proof",
+		ProofID:          &generatedProofID,
+	}
+	require.NoError(t, testState.AddGeneratedProof(ctx, generatingProof, nil))
+	require.NoError(t, testState.AddGeneratedProof(ctx, generatedProof, nil))
+
+	proofs, err = testState.GetProofs(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, proofs, 2)
+	assert.Equal(t, batchNumber, proofs[0].BatchNumber)
+	assert.Equal(t, "proof", proofs[0].Proof)
+	assert.Nil(t, proofs[0].GeneratingSince)
+	assert.Equal(t, batchNumber+1, proofs[1].BatchNumber)
+	assert.Equal(t, proverID, *proofs[1].ProverID)
+	require.NotNil(t, proofs[1].GeneratingSince)
+	assert.True(t, now.Equal(*proofs[1].GeneratingSince))
+}
+
 func TestVirtualBatch(t *testing.T) {
 	initOrResetDB()
 
@@ -653,3 +695,97 @@ func TestGetFinalizedL2BlockNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestSignedSequence(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	_, err = testState.GetSignedSequence(ctx, 1, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	signedSequence := state.SignedSequence{
+		FromBatchNumber:    1,
+		ToBatchNumber:      3,
+		OldAccInputHash:    common.HexToHash("0x1"),
+		SignedHash:         common.HexToHash("0x2"),
+		SignaturesAndAddrs: []byte{1, 2, 3},
+		CommitteeHash:      common.HexToHash("0x3"),
+	}
+	require.NoError(t, testState.AddSignedSequence(ctx, &signedSequence, dbTx))
+	actual, err := testState.GetSignedSequence(ctx, 1, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, signedSequence, *actual)
+
+	// Signing again the sequence starting at the same batch replaces it
+	signedSequence.ToBatchNumber = 4
+	signedSequence.SignaturesAndAddrs = []byte{4, 5, 6}
+	require.NoError(t, testState.AddSignedSequence(ctx, &signedSequence, dbTx))
+	actual, err = testState.GetSignedSequence(ctx, 1, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, signedSequence, *actual)
+
+	require.NoError(t, testState.DeleteSignedSequencesUntilBatch(ctx, 3, dbTx))
+	_, err = testState.GetSignedSequence(ctx, 1, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, testState.DeleteSignedSequencesUntilBatch(ctx, 4, dbTx))
+	_, err = testState.GetSignedSequence(ctx, 1, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+}
+
+func TestTrustedDivergences(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	divergences, err := testState.GetTrustedDivergences(ctx, 0, 10, dbTx)
+	require.NoError(t, err)
+	assert.Empty(t, divergences)
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	divergence := state.TrustedDivergence{
+		BatchNumber:       5,
+		Kind:              state.TrustedDivergenceL1,
+		ExpectedStateRoot: common.HexToHash("0x1"),
+		ActualStateRoot:   common.HexToHash("0x2"),
+		ExpectedGER:       common.HexToHash("0x3"),
+		ActualGER:         common.HexToHash("0x4"),
+		ExpectedTimestamp: timestamp,
+		ActualTimestamp:   timestamp.Add(time.Second),
+		ExpectedTxsHash:   common.HexToHash("0x5"),
+		ActualTxsHash:     common.HexToHash("0x6"),
+		ReorgedTxs:        []common.Hash{common.HexToHash("0x7"), common.HexToHash("0x8")},
+		Reason:            "Different field StateRoot",
+	}
+	require.NoError(t, testState.AddTrustedDivergence(ctx, &divergence, dbTx))
+	halted := divergence
+	halted.BatchNumber = 20
+	halted.Kind = state.TrustedDivergenceStateRoot
+	halted.Halted = true
+	halted.ReorgedTxs = []common.Hash{}
+	require.NoError(t, testState.AddTrustedDivergence(ctx, &halted, dbTx))
+
+	divergences, err = testState.GetTrustedDivergences(ctx, 0, 10, dbTx)
+	require.NoError(t, err)
+	require.Len(t, divergences, 1)
+	assert.NotZero(t, divergences[0].ID)
+	assert.False(t, divergences[0].Timestamp.IsZero())
+	divergence.ID = divergences[0].ID
+	divergence.Timestamp = divergences[0].Timestamp
+	divergences[0].ExpectedTimestamp = divergences[0].ExpectedTimestamp.UTC()
+	divergences[0].ActualTimestamp = divergences[0].ActualTimestamp.UTC()
+	assert.Equal(t, divergence, divergences[0])
+
+	divergences, err = testState.GetTrustedDivergences(ctx, 0, 100, dbTx)
+	require.NoError(t, err)
+	require.Len(t, divergences, 2)
+	assert.Equal(t, uint64(20), divergences[1].BatchNumber)
+	assert.True(t, divergences[1].Halted)
+	assert.Empty(t, divergences[1].ReorgedTxs)
+}
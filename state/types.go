@@ -221,6 +221,54 @@ type TrustedReorg struct {
 	Reason      string
 }
 
+// TrustedDivergenceKind is the kind of divergence detected in the trusted state
+type TrustedDivergenceKind string
+
+const (
+	// TrustedDivergenceL1 is a trusted batch that doesn't match the batch sequenced on L1,
+	// so the trusted state is reset from that batch
+	TrustedDivergenceL1 TrustedDivergenceKind = "l1"
+	// TrustedDivergenceStateRoot is a trusted batch whose state root doesn't match the
+	// state root computed when processing it
+	TrustedDivergenceStateRoot TrustedDivergenceKind = "stateRoot"
+)
+
+// TrustedDivergence is a mismatch detected between the trusted state and the data it was
+// checked against. The expected values are the reference ones, L1 or the trusted sequencer
+// depending on the kind, and the actual values are the ones of the trusted state. The fields that
+// aren't compared for the kind are empty
+type TrustedDivergence struct {
+	ID          uint64
+	Timestamp   time.Time
+	BatchNumber uint64
+	Kind        TrustedDivergenceKind
+	// Halted is true if the synchronizer was halted because of the divergence
+	Halted            bool
+	ExpectedStateRoot common.Hash
+	ActualStateRoot   common.Hash
+	ExpectedGER       common.Hash
+	ActualGER         common.Hash
+	ExpectedTimestamp time.Time
+	ActualTimestamp   time.Time
+	// ExpectedTxsHash and ActualTxsHash are the hashes of the batch L2 data
+	ExpectedTxsHash common.Hash
+	ActualTxsHash   common.Hash
+	// ReorgedTxs are the transactions of the trusted batches discarded because of the divergence
+	ReorgedTxs []common.Hash
+	Reason     string
+}
+
+// SignedSequence is a sequence of batches already signed by the data committee,
+// stored to avoid requesting the signatures again if sending it to L1 fails
+type SignedSequence struct {
+	FromBatchNumber    uint64
+	ToBatchNumber      uint64
+	OldAccInputHash    common.Hash
+	SignedHash         common.Hash
+	SignaturesAndAddrs []byte
+	CommitteeHash      common.Hash
+}
+
 // HexToAddressPtr create an address from a hex and returns its pointer
 func HexToAddressPtr(hex string) *common.Address {
 	a := common.HexToAddress(hex)
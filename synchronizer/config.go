@@ -2,6 +2,7 @@ package synchronizer
 
 import (
 	"github.com/0xPolygon/cdk-validium-node/config/types"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
 )
 
 // Config represents the configuration of the synchronizer
@@ -14,4 +15,33 @@ type Config struct {
 	TrustedSequencerURL string `mapstructure:"TrustedSequencerURL"`
 	// SyncOnlyTrusted option whether sync L1 block or not (for external node use)
 	SyncOnlyTrusted bool `mapstructure:"SyncOnlyTrusted"`
+	// L1ParallelWorkers is the amount of block ranges whose rollup info is fetched from L1 at once,
+	// ahead of the range being processed. 0 or 1 fetches and processes one range at a time
+	L1ParallelWorkers uint64 `mapstructure:"L1ParallelWorkers"`
+	// L1MaxPrefetchedRanges is the max amount of block ranges fetched ahead of the range being processed,
+	// which bounds the memory used to keep their rollup info
+	L1MaxPrefetchedRanges uint64 `mapstructure:"L1MaxPrefetchedRanges"`
+	// L1SyncBlock is the last L1 block processed by the synchronizer: "latest", "safe" or "finalized".
+	// With "safe" or "finalized", the virtual and verified batches are only stored once their L1 block
+	// is unlikely or impossible to be reorged
+	L1SyncBlock string `mapstructure:"L1SyncBlock"`
+	// L1TentativeSync reads the rollup info of the L1 blocks after the safe or finalized block up to the
+	// latest one, tracking the last batches sequenced and verified in them apart from the state. They are
+	// returned by the synchronizer API
+	L1TentativeSync bool `mapstructure:"L1TentativeSync"`
+	// RecordL1Path is the file where the L1 data read by the synchronizer is recorded, so it can be
+	// replayed later. Nothing is recorded if it's empty
+	RecordL1Path string `mapstructure:"RecordL1Path"`
+	// ReplayL1Path is a file recorded using RecordL1Path. When set, the node reads the L1 data from it
+	// instead of connecting to the L1 node, so only the synchronizer component can run
+	ReplayL1Path string `mapstructure:"ReplayL1Path"`
+	// StopOnBatchNum is the batch where the synchronizer stops once it reaches the StopOnBatchState.
+	// The state is not modified anymore, so the RPC serves it frozen at that point. 0 disables it
+	StopOnBatchNum uint64 `mapstructure:"StopOnBatchNum"`
+	// StopOnBatchState is the state StopOnBatchNum must reach to stop: "virtual", "trusted" or "consolidated"
+	StopOnBatchState string `mapstructure:"StopOnBatchState"`
+	// StopOnL1Block is the L1 block where the synchronizer stops once it's processed. 0 disables it
+	StopOnL1Block uint64 `mapstructure:"StopOnL1Block"`
+	// API is the configuration of the admin API of the synchronizer, used to recover it when halted
+	API jsonrpc.AdminAPIConfig `mapstructure:"API"`
 }
@@ -0,0 +1,78 @@
+package synchronizer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const unexpectedHashTemplate = "missmatch on transaction data for batch num %d. Expected hash %s, actual hash: %s"
+
+// processCommitteeUpdate loads the data committee registered at the block of the update, so the
+// data availability backend requests the data of the next batches to the new members
+func (s *ClientSynchronizer) processCommitteeUpdate(update etherman.CommitteeUpdate) {
+	log.Infof("data committee updated at block %d. New committee hash: %s", update.BlockNumber, update.CommitteeHash)
+	if err := s.etherMan.LoadDataCommitteeAt(update.BlockNumber); err != nil {
+		log.Warnf("failed to load the data committee at block %d, the latest one will be read: %v", update.BlockNumber, err)
+	}
+}
+
+func (s *ClientSynchronizer) getBatchL2Data(batchNum uint64, expectedTransactionsHash common.Hash) ([]byte, error) {
+	found := true
+	transactionsData, err := s.state.GetBatchL2DataByNumber(s.ctx, batchNum, nil)
+	if err != nil {
+		if err == state.ErrNotFound {
+			found = false
+		} else {
+			return nil, fmt.Errorf("failed to get batch data from state for batch num %d: %w", batchNum, err)
+		}
+	}
+	actualTransactionsHash := crypto.Keccak256Hash(transactionsData)
+	if !found || expectedTransactionsHash != actualTransactionsHash {
+		if found {
+			log.Warnf(unexpectedHashTemplate, batchNum, expectedTransactionsHash, actualTransactionsHash)
+		}
+
+		if !s.isTrustedSequencer {
+			log.Info("trying to get data from trusted sequencer")
+			data, err := s.getDataFromTrustedSequencer(batchNum, expectedTransactionsHash)
+			if err != nil {
+				log.Error(err)
+			} else {
+				return data, nil
+			}
+		}
+
+		log.Info("trying to get data from the data availability backend")
+		data, err := s.daBackend.GetBatchL2Data(s.ctx, batchNum, expectedTransactionsHash)
+		if err != nil {
+			log.Error(err)
+			if s.isTrustedSequencer {
+				return nil, fmt.Errorf("data not found on the local DB nor on the data availability backend")
+			} else {
+				return nil, fmt.Errorf("data not found on the local DB, nor from the trusted sequencer nor on the data availability backend")
+			}
+		}
+		return data, nil
+	}
+	return transactionsData, nil
+}
+
+func (s *ClientSynchronizer) getDataFromTrustedSequencer(batchNum uint64, expectedTransactionsHash common.Hash) ([]byte, error) {
+	b, err := s.zkEVMClient.BatchByNumberNoFullTxs(s.ctx, big.NewInt(int64(batchNum)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch num %d from trusted sequencer: %w", batchNum, err)
+	}
+	actualTransactionsHash := crypto.Keccak256Hash(b.BatchL2Data)
+	if expectedTransactionsHash != actualTransactionsHash {
+		return nil, fmt.Errorf(
+			unexpectedHashTemplate, batchNum, expectedTransactionsHash, actualTransactionsHash,
+		)
+	}
+	return b.BatchL2Data, nil
+}
@@ -0,0 +1,366 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var forkID uint64 = 5
+
+func TestGetBatchL2Data(t *testing.T) {
+	m := mocks{
+		State:       newStateMock(t),
+		ZKEVMClient: newZkEVMClientMock(t),
+		DABackend:   newBatchDataGetterMock(t),
+	}
+
+	ctx := context.Background()
+
+	trustedSync := ClientSynchronizer{
+		isTrustedSequencer: true,
+		state:              m.State,
+		zkEVMClient:        m.ZKEVMClient,
+		daBackend:          m.DABackend,
+		ctx:                ctx,
+	}
+
+	permissionlessSync := ClientSynchronizer{
+		isTrustedSequencer: false,
+		state:              m.State,
+		zkEVMClient:        m.ZKEVMClient,
+		daBackend:          m.DABackend,
+		ctx:                ctx,
+	}
+
+	const batchNum uint64 = 5
+	batchNumBig := big.NewInt(int64(batchNum))
+	dataFromDB := []byte("i poli tis Kerkyras einai omorfi")
+	dataFromDA := []byte("to nisi tis Kerkyras einai makria")
+	errorHash := state.ZeroHash
+	unexpectedErrFromDB := errors.New("unexpected db")
+	errFromDBTemplate := "failed to get batch data from state for batch num " + strconv.Itoa(int(batchNum)) + ": %s"
+
+	trustedResponse := &types.Batch{Transactions: []types.TransactionOrHash{
+		{Tx: &types.Transaction{Nonce: 4}},
+		{Tx: &types.Transaction{Nonce: 284}},
+	}}
+	txs := []ethTypes.Transaction{}
+	effectivePercentages := []uint8{}
+	for _, transaction := range trustedResponse.Transactions {
+		tx := transaction.Tx.CoreTx()
+		txs = append(txs, *tx)
+		effectivePercentages = append(effectivePercentages, state.MaxEffectivePercentage)
+	}
+	dataFromTrusted, err := state.EncodeTransactions(txs, effectivePercentages, forkID)
+	require.NoError(t, err)
+	trustedResponse.BatchL2Data = dataFromTrusted
+	trustedResponseEmpty := &types.Batch{Transactions: []types.TransactionOrHash{}}
+	txs = []ethTypes.Transaction{}
+	dataFromTrustedEmpty, err := state.EncodeTransactions(txs, effectivePercentages, forkID)
+	require.NoError(t, err)
+
+	unexpectedErrFromTrusted := errors.New("unexpected trusted")
+	unexpectedErrFromDA := errors.New("unexpected data availability backend")
+
+	type testCase struct {
+		Name           string
+		ExpectedResult []byte
+		ExpectedError  error
+		Sync           *ClientSynchronizer
+		SetupMocks     func(m *mocks)
+	}
+
+	testCases := []testCase{
+		// Trusted sync cases
+		{
+			Name:           "Trusted sync fail if unexpected error from DB",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf(errFromDBTemplate, unexpectedErrFromDB),
+			Sync:           &trustedSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, unexpectedErrFromDB).
+					Once()
+			},
+		},
+		{
+			Name:           "Trusted sync fail if data not found on DB",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf("data not found on the local DB nor on the data availability backend"),
+			Sync:           &trustedSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, errorHash).
+					Return(nil, unexpectedErrFromDA).
+					Once()
+			},
+		},
+		{
+			Name:           "Trusted sync fail if hash missmatch on DB",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf("data not found on the local DB nor on the data availability backend"),
+			Sync:           &trustedSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(dataFromDB, nil).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, errorHash).
+					Return(nil, unexpectedErrFromDA).
+					Once()
+			},
+		},
+		{
+			Name:           "Trusted sync succeeds if hash match on DB",
+			ExpectedResult: dataFromDB,
+			ExpectedError:  nil,
+			Sync:           &trustedSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(dataFromDB, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Trusted sync succeeds if data not found on DB but found on the data availability backend",
+			ExpectedResult: dataFromDA,
+			ExpectedError:  nil,
+			Sync:           &trustedSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, crypto.Keccak256Hash(dataFromDA)).
+					Return(dataFromDA, nil).
+					Once()
+			},
+		},
+		// Permissionless sync  cases
+		{
+			Name:           "Permissionless sync succeeds if hash match on DB",
+			ExpectedResult: dataFromDB,
+			ExpectedError:  nil,
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(dataFromDB, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync fail if unexpected error from DB",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf(errFromDBTemplate, unexpectedErrFromDB),
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, unexpectedErrFromDB).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync fail if hash missmatch on the DB and error from trusted",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf("data not found on the local DB, nor from the trusted sequencer nor on the data availability backend"),
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(dataFromDB, nil).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, errorHash).
+					Return(nil, unexpectedErrFromDA).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(nil, unexpectedErrFromTrusted).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync fail if hash missmatch on the DB and from trusted sequencer",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf("data not found on the local DB, nor from the trusted sequencer nor on the data availability backend"),
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(dataFromDB, nil).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, errorHash).
+					Return(nil, unexpectedErrFromDA).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(trustedResponse, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync succeeds if hash missmatch on the DB and match from trusted",
+			ExpectedResult: dataFromTrusted,
+			ExpectedError:  nil,
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(dataFromDB, nil).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(trustedResponse, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync fail if not found on the DB and error from trusted",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf("data not found on the local DB, nor from the trusted sequencer nor on the data availability backend"),
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, errorHash).
+					Return(nil, unexpectedErrFromDA).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(nil, unexpectedErrFromTrusted).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync fail fail if not found on the DB and hash missmatch trusted",
+			ExpectedResult: nil,
+			ExpectedError:  fmt.Errorf("data not found on the local DB, nor from the trusted sequencer nor on the data availability backend"),
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, errorHash).
+					Return(nil, unexpectedErrFromDA).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(trustedResponse, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync succeeds if not found on the DB and match from trusted",
+			ExpectedResult: dataFromTrusted,
+			ExpectedError:  nil,
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(trustedResponse, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync succeeds if not found on the DB and match from trusted empty response",
+			ExpectedResult: dataFromTrustedEmpty,
+			ExpectedError:  nil,
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(trustedResponseEmpty, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Permissionless sync succeeds if not found on the DB, error from trusted and found on the data availability backend",
+			ExpectedResult: dataFromDA,
+			ExpectedError:  nil,
+			Sync:           &permissionlessSync,
+			SetupMocks: func(m *mocks) {
+				m.State.
+					On("GetBatchL2DataByNumber", ctx, batchNum, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+				m.ZKEVMClient.
+					On("BatchByNumberNoFullTxs", ctx, batchNumBig).
+					Return(nil, unexpectedErrFromTrusted).
+					Once()
+				m.DABackend.
+					On("GetBatchL2Data", ctx, batchNum, crypto.Keccak256Hash(dataFromDA)).
+					Return(dataFromDA, nil).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(&m)
+
+			var expectedHash common.Hash
+			if tc.ExpectedError == nil {
+				expectedHash = crypto.Keccak256Hash(tc.ExpectedResult)
+			} else {
+				expectedHash = errorHash
+			}
+
+			res, err := tc.Sync.getBatchL2Data(batchNum, expectedHash)
+			assert.Equal(t, tc.ExpectedResult, res)
+			if tc.ExpectedError != nil {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.ExpectedError.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestProcessCommitteeUpdate(t *testing.T) {
+	ethermanMock := newEthermanMock(t)
+	sync := ClientSynchronizer{etherMan: ethermanMock}
+
+	// The committee is loaded at the block of the update, a failure doesn't stop the synchronization
+	ethermanMock.On("LoadDataCommitteeAt", uint64(10)).Return(nil).Once()
+	sync.processCommitteeUpdate(etherman.CommitteeUpdate{BlockNumber: 10})
+	ethermanMock.On("LoadDataCommitteeAt", uint64(20)).Return(errors.New("missing trie node")).Once()
+	sync.processCommitteeUpdate(etherman.CommitteeUpdate{BlockNumber: 20})
+}
@@ -0,0 +1,70 @@
+package synchronizer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
+)
+
+// newL1Divergence builds the divergence between a trusted batch and the batch sequenced on L1,
+// whose state root is the one computed when processing it
+func newL1Divergence(batch state.Batch, tBatch *state.Batch, newRoot common.Hash, reason string) *state.TrustedDivergence {
+	return &state.TrustedDivergence{
+		BatchNumber:       tBatch.BatchNumber,
+		Kind:              state.TrustedDivergenceL1,
+		ExpectedStateRoot: newRoot,
+		ActualStateRoot:   tBatch.StateRoot,
+		ExpectedGER:       batch.GlobalExitRoot,
+		ActualGER:         tBatch.GlobalExitRoot,
+		ExpectedTimestamp: batch.Timestamp,
+		ActualTimestamp:   tBatch.Timestamp,
+		ExpectedTxsHash:   crypto.Keccak256Hash(batch.BatchL2Data),
+		ActualTxsHash:     crypto.Keccak256Hash(tBatch.BatchL2Data),
+		ReorgedTxs:        []common.Hash{},
+		Reason:            reason,
+	}
+}
+
+// addReorgedTxs adds to the divergence the transactions of the trusted batches that are going to
+// be discarded, from the diverging batch onwards
+func (s *ClientSynchronizer) addReorgedTxs(divergence *state.TrustedDivergence, dbTx pgx.Tx) {
+	txs, err := s.state.GetReorgedTransactions(s.ctx, divergence.BatchNumber, dbTx)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		log.Errorf("error getting the reorged transactions of the trusted divergence in batch %d. Error: %v", divergence.BatchNumber, err)
+		return
+	}
+	for _, tx := range txs {
+		divergence.ReorgedTxs = append(divergence.ReorgedTxs, tx.Hash())
+	}
+}
+
+// storeTrustedDivergence persists a divergence detected in the trusted state. The divergences
+// that halt the synchronizer must be stored without dbTx, as it's never committed
+func (s *ClientSynchronizer) storeTrustedDivergence(divergence *state.TrustedDivergence, dbTx pgx.Tx) {
+	if err := s.state.AddTrustedDivergence(s.ctx, divergence, dbTx); err != nil {
+		log.Errorf("error storing the trusted divergence of batch %d into the db. Error: %v", divergence.BatchNumber, err)
+	}
+}
+
+// haltOnTrustedStateRoot stores the divergence between the state root received from the trusted
+// sequencer and the one computed by the node, and halts the synchronizer. Only the state roots are
+// compared, so the other fields of the divergence are left empty
+func (s *ClientSynchronizer) haltOnTrustedStateRoot(trustedBatch *types.Batch, stateRoot common.Hash) error {
+	divergence := &state.TrustedDivergence{
+		BatchNumber:       uint64(trustedBatch.Number),
+		Kind:              state.TrustedDivergenceStateRoot,
+		Halted:            true,
+		ExpectedStateRoot: trustedBatch.StateRoot,
+		ActualStateRoot:   stateRoot,
+		ReorgedTxs:        []common.Hash{},
+		Reason:            fmt.Sprintf("stateRoot calculated (%s) is different from the stateRoot (%s) received during the trustedState synchronization", stateRoot, trustedBatch.StateRoot),
+	}
+	s.storeTrustedDivergence(divergence, nil)
+	return s.halt(s.ctx, errors.New(divergence.Reason))
+}
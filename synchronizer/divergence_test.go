@@ -0,0 +1,57 @@
+package synchronizer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTrustedStateStoresDivergence(t *testing.T) {
+	ctx := context.Background()
+	st := newStateMock(t)
+	dbTx := newDbTxMock(t)
+	sync := &ClientSynchronizer{state: st, ctx: ctx}
+
+	timestamp := time.Unix(1700000000, 0)
+	batch := state.Batch{
+		BatchNumber:    5,
+		GlobalExitRoot: common.HexToHash("0x1"),
+		Timestamp:      timestamp,
+		BatchL2Data:    []byte{1, 2},
+	}
+	tBatch := &state.Batch{
+		BatchNumber:    5,
+		StateRoot:      common.HexToHash("0x2"),
+		GlobalExitRoot: common.HexToHash("0x1"),
+		Timestamp:      timestamp,
+		BatchL2Data:    []byte{1, 2, 3},
+	}
+	newRoot := common.HexToHash("0x3")
+	reorgedTx := ethTypes.NewTransaction(1, common.HexToAddress("0x4"), big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	st.On("GetReorgedTransactions", ctx, uint64(5), dbTx).Return([]*ethTypes.Transaction{reorgedTx}, nil).Once()
+	st.On("AddTrustedDivergence", ctx, mock.MatchedBy(func(divergence *state.TrustedDivergence) bool {
+		return divergence.BatchNumber == 5 &&
+			divergence.Kind == state.TrustedDivergenceL1 &&
+			!divergence.Halted &&
+			divergence.ExpectedStateRoot == newRoot &&
+			divergence.ActualStateRoot == tBatch.StateRoot &&
+			divergence.ExpectedTxsHash == crypto.Keccak256Hash(batch.BatchL2Data) &&
+			divergence.ActualTxsHash == crypto.Keccak256Hash(tBatch.BatchL2Data) &&
+			len(divergence.ReorgedTxs) == 1 && divergence.ReorgedTxs[0] == reorgedTx.Hash()
+	}), dbTx).Return(nil).Once()
+	st.On("AddTrustedReorg", ctx, mock.Anything, dbTx).Return(nil).Once()
+
+	reorged, err := sync.checkTrustedState(batch, tBatch, newRoot, dbTx)
+	require.NoError(t, err)
+	assert.True(t, reorged)
+}
@@ -0,0 +1,74 @@
+package synchronizer
+
+import (
+	"context"
+
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
+)
+
+// APISynchronizer is the namespace of the synchronizer endpoints
+const APISynchronizer = "synchronizer"
+
+// Endpoints contains the implementation of the "synchronizer" endpoints
+type Endpoints struct {
+	s *ClientSynchronizer
+}
+
+// Services returns the JSON-RPC services exposed by the synchronizer
+func (s *ClientSynchronizer) Services() []jsonrpc.Service {
+	return []jsonrpc.Service{
+		{
+			Name:    APISynchronizer,
+			Service: &Endpoints{s: s},
+		},
+	}
+}
+
+// GetHaltStatus returns the halt reason of the synchronizer and the recovery actions pending
+func (e *Endpoints) GetHaltStatus() (interface{}, types.Error) {
+	return e.s.HaltStatus(), nil
+}
+
+// GetTentativeL1State returns the last batches sequenced and verified in the L1 blocks that are not
+// processed yet because they are not safe or finalized
+func (e *Endpoints) GetTentativeL1State() (interface{}, types.Error) {
+	if !e.s.isFinalityAware() || !e.s.cfg.L1TentativeSync {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "the tentative L1 sync is disabled")
+	}
+	return e.s.TentativeL1State(), nil
+}
+
+// Retry makes the halted synchronizer do again the processing that halted it
+func (e *Endpoints) Retry() (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	if err := e.s.Retry(context.Background()); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return e.s.HaltStatus(), nil
+}
+
+// RollbackToL1Block resets the state to the given L1 block, retrying the synchronizer if halted
+func (e *Endpoints) RollbackToL1Block(blockNumber types.ArgUint64) (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	if err := e.s.RollbackToL1Block(context.Background(), uint64(blockNumber)); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return e.s.HaltStatus(), nil
+}
+
+// SkipForcedBatchCheck skips once the failed forced batch check of the batch. acknowledge must be true,
+// confirming the batch is processed with the data sequenced on L1
+func (e *Endpoints) SkipForcedBatchCheck(batchNumber types.ArgUint64, acknowledge bool) (interface{}, types.Error) {
+	if err := jsonrpc.CheckAdminAuth(e.s.cfg.API); err != nil {
+		return nil, err
+	}
+	if err := e.s.SkipForcedBatchCheck(context.Background(), uint64(batchNumber), acknowledge); err != nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, err.Error())
+	}
+	return e.s.HaltStatus(), nil
+}
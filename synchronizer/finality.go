@@ -0,0 +1,190 @@
+package synchronizer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/synchronizer/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// L1SyncBlockLatest processes the L1 blocks up to the latest one
+	L1SyncBlockLatest = "latest"
+	// L1SyncBlockSafe processes the L1 blocks up to the safe one
+	L1SyncBlockSafe = "safe"
+	// L1SyncBlockFinalized processes the L1 blocks up to the finalized one
+	L1SyncBlockFinalized = "finalized"
+)
+
+// TentativeL1State is the rollup info found in the L1 blocks after the last block the synchronizer
+// is allowed to process. It's not stored in the state, as those blocks can still be reorged
+type TentativeL1State struct {
+	// SyncTarget is the last L1 block the synchronizer is allowed to process
+	SyncTarget uint64 `json:"syncTarget"`
+	// BlockNumber is the latest L1 block checked
+	BlockNumber uint64 `json:"blockNumber"`
+	// LastSequencedBatch is the last batch sequenced in the tentative blocks, 0 if none
+	LastSequencedBatch uint64 `json:"lastSequencedBatch"`
+	// LastVerifiedBatch is the last batch verified in the tentative blocks, 0 if none
+	LastVerifiedBatch uint64 `json:"lastVerifiedBatch"`
+}
+
+// tentativeBlock is the rollup info of a tentative L1 block with sequenced or verified batches
+type tentativeBlock struct {
+	blockNumber        uint64
+	lastSequencedBatch uint64
+	lastVerifiedBatch  uint64
+}
+
+// tentativeTracker keeps the rollup info of the tentative L1 blocks already read, so only the new
+// blocks are read on every iteration
+type tentativeTracker struct {
+	mu    sync.RWMutex
+	state TentativeL1State
+	// lastBlockHash is the hash of the latest L1 block checked, to find out if the tentative blocks were reorged
+	lastBlockHash common.Hash
+	// blocks are the tentative blocks with rollup info, sorted by block number
+	blocks []tentativeBlock
+}
+
+func (t *tentativeTracker) get() TentativeL1State {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.state
+}
+
+func (t *tentativeTracker) set(state TentativeL1State) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state = state
+}
+
+// TentativeL1State returns the rollup info found in the L1 blocks after the last block the synchronizer
+// is allowed to process. It's empty unless L1TentativeSync is enabled
+func (s *ClientSynchronizer) TentativeL1State() TentativeL1State {
+	return s.tentative.get()
+}
+
+func validateL1SyncBlock(l1SyncBlock string) error {
+	switch l1SyncBlock {
+	case "", L1SyncBlockLatest, L1SyncBlockSafe, L1SyncBlockFinalized:
+		return nil
+	default:
+		return fmt.Errorf("unknown L1SyncBlock %q, valid values are %q, %q and %q", l1SyncBlock, L1SyncBlockLatest, L1SyncBlockSafe, L1SyncBlockFinalized)
+	}
+}
+
+// isFinalityAware returns true if the L1 blocks are only processed once they are safe or finalized
+func (s *ClientSynchronizer) isFinalityAware() bool {
+	return s.cfg.L1SyncBlock == L1SyncBlockSafe || s.cfg.L1SyncBlock == L1SyncBlockFinalized
+}
+
+// getL1SyncTarget returns the last L1 block that can be processed according to the configured finality
+func (s *ClientSynchronizer) getL1SyncTarget(latestBlock uint64) (uint64, error) {
+	switch s.cfg.L1SyncBlock {
+	case L1SyncBlockSafe:
+		return s.etherMan.GetSafeBlockNumber(s.ctx)
+	case L1SyncBlockFinalized:
+		return s.etherMan.GetFinalizedBlockNumber(s.ctx)
+	default:
+		return latestBlock, nil
+	}
+}
+
+// syncTentativeState reads the rollup info of the L1 blocks after the sync target up to the latest block,
+// keeping track of the last batches sequenced and verified in them without storing anything in the state.
+// The blocks read in the previous iterations are not read again unless they were reorged
+func (s *ClientSynchronizer) syncTentativeState(syncTarget uint64, latest *ethTypes.Header) {
+	if !s.isFinalityAware() || !s.cfg.L1TentativeSync {
+		return
+	}
+	t := &s.tentative
+	latestBlock := latest.Number.Uint64()
+	fromBlock := syncTarget + 1
+	blocks := t.blocks
+	if lastBlock := t.state.BlockNumber; lastBlock > syncTarget && lastBlock <= latestBlock {
+		lastHeader := latest
+		if lastBlock < latestBlock {
+			var err error
+			lastHeader, err = s.etherMan.HeaderByNumber(s.ctx, new(big.Int).SetUint64(lastBlock))
+			if err != nil {
+				log.Warnf("error getting the header of the tentative block %d. Error: %v", lastBlock, err)
+				return
+			}
+		}
+		if lastHeader.Hash() == t.lastBlockHash {
+			fromBlock = lastBlock + 1
+		} else {
+			log.Infof("Tentative L1 block %d was reorged, reading the tentative blocks again", lastBlock)
+			blocks = nil
+		}
+	} else {
+		blocks = nil
+	}
+
+	// The blocks up to the sync target are processed by now
+	for len(blocks) > 0 && blocks[0].blockNumber <= syncTarget {
+		blocks = blocks[1:]
+	}
+
+	for ; fromBlock <= latestBlock; fromBlock += s.cfg.SyncChunkSize + 1 {
+		toBlock := fromBlock + s.cfg.SyncChunkSize
+		if toBlock > latestBlock {
+			toBlock = latestBlock
+		}
+		rollupInfo, _, err := s.etherMan.GetRollupInfoByBlockRange(s.ctx, fromBlock, &toBlock)
+		if err != nil {
+			log.Warnf("error getting the tentative rollup info from block %d to block %d. Error: %v", fromBlock, toBlock, err)
+			return
+		}
+		for _, block := range rollupInfo {
+			blocks = append(blocks, newTentativeBlock(block))
+		}
+	}
+
+	tentative := TentativeL1State{SyncTarget: syncTarget, BlockNumber: latestBlock}
+	for _, block := range blocks {
+		if block.lastSequencedBatch > tentative.LastSequencedBatch {
+			tentative.LastSequencedBatch = block.lastSequencedBatch
+		}
+		if block.lastVerifiedBatch > tentative.LastVerifiedBatch {
+			tentative.LastVerifiedBatch = block.lastVerifiedBatch
+		}
+	}
+	t.blocks = blocks
+	t.lastBlockHash = latest.Hash()
+	t.set(tentative)
+	metrics.TentativeL1State(tentative.BlockNumber, tentative.LastSequencedBatch, tentative.LastVerifiedBatch)
+	log.Infof("Tentative L1 state up to block %d: lastSequencedBatch: %d, lastVerifiedBatch: %d",
+		tentative.BlockNumber, tentative.LastSequencedBatch, tentative.LastVerifiedBatch)
+}
+
+// newTentativeBlock returns the last batches sequenced and verified in the block
+func newTentativeBlock(block etherman.Block) tentativeBlock {
+	tentative := tentativeBlock{blockNumber: block.BlockNumber}
+	for _, sequences := range block.SequencedBatches {
+		for _, sequence := range sequences {
+			if sequence.BatchNumber > tentative.lastSequencedBatch {
+				tentative.lastSequencedBatch = sequence.BatchNumber
+			}
+		}
+	}
+	for _, sequences := range block.SequencedForceBatches {
+		for _, sequence := range sequences {
+			if sequence.BatchNumber > tentative.lastSequencedBatch {
+				tentative.lastSequencedBatch = sequence.BatchNumber
+			}
+		}
+	}
+	for _, verified := range block.VerifiedBatches {
+		if verified.BatchNumber > tentative.lastVerifiedBatch {
+			tentative.lastVerifiedBatch = verified.BatchNumber
+		}
+	}
+	return tentative
+}
@@ -0,0 +1,124 @@
+package synchronizer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncBlocksFinalityAware(t *testing.T) {
+	ctx := context.Background()
+	ethBlock100 := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(100)})
+	lastEthBlockSynced := &state.Block{BlockNumber: 100, BlockHash: ethBlock100.Hash(), ParentHash: ethBlock100.ParentHash()}
+	latestHeader := &ethTypes.Header{Number: big.NewInt(200)}
+	noOrder := map[common.Hash][]etherman.Order{}
+
+	t.Run("sync up to the finalized block and track the latest blocks as tentative", func(t *testing.T) {
+		m := newEthermanMock(t)
+		sync := &ClientSynchronizer{
+			etherMan: m,
+			ctx:      ctx,
+			cfg:      Config{SyncChunkSize: 100, L1SyncBlock: L1SyncBlockFinalized, L1TentativeSync: true},
+		}
+		m.On("EthBlockByNumber", ctx, uint64(100)).Return(ethBlock100, nil).Once()
+		m.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(latestHeader, nil).Once()
+		m.On("GetFinalizedBlockNumber", ctx).Return(uint64(150), nil).Once()
+		finalizedBlock := uint64(150)
+		m.On("GetRollupInfoByBlockRange", ctx, uint64(101), &finalizedBlock).Return([]etherman.Block{}, noOrder, nil).Once()
+		tentativeBlocks := []etherman.Block{
+			{
+				BlockNumber:      160,
+				SequencedBatches: [][]etherman.SequencedBatch{{{BatchNumber: 6}, {BatchNumber: 7}}},
+			},
+			{
+				BlockNumber:     170,
+				VerifiedBatches: []etherman.VerifiedBatch{{BatchNumber: 5}},
+			},
+		}
+		latestBlock := uint64(200)
+		m.On("GetRollupInfoByBlockRange", ctx, uint64(151), &latestBlock).Return(tentativeBlocks, noOrder, nil).Once()
+
+		block, err := sync.syncBlocks(lastEthBlockSynced)
+		require.NoError(t, err)
+		assert.Equal(t, lastEthBlockSynced, block)
+		assert.True(t, sync.syncedToL1Target)
+		assert.Equal(t, TentativeL1State{SyncTarget: 150, BlockNumber: 200, LastSequencedBatch: 7, LastVerifiedBatch: 5}, sync.TentativeL1State())
+	})
+
+	t.Run("wait for the safe block to reach the next block", func(t *testing.T) {
+		m := newEthermanMock(t)
+		sync := &ClientSynchronizer{
+			etherMan: m,
+			ctx:      ctx,
+			cfg:      Config{SyncChunkSize: 100, L1SyncBlock: L1SyncBlockSafe},
+		}
+		m.On("EthBlockByNumber", ctx, uint64(100)).Return(ethBlock100, nil).Once()
+		m.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(latestHeader, nil).Once()
+		m.On("GetSafeBlockNumber", ctx).Return(uint64(100), nil).Once()
+
+		block, err := sync.syncBlocks(lastEthBlockSynced)
+		require.NoError(t, err)
+		assert.Equal(t, lastEthBlockSynced, block)
+		assert.True(t, sync.syncedToL1Target)
+		assert.Equal(t, TentativeL1State{}, sync.TentativeL1State())
+	})
+}
+
+func TestSyncTentativeState(t *testing.T) {
+	ctx := context.Background()
+	noOrder := map[common.Hash][]etherman.Order{}
+	m := newEthermanMock(t)
+	sync := &ClientSynchronizer{
+		etherMan: m,
+		ctx:      ctx,
+		cfg:      Config{SyncChunkSize: 100, L1SyncBlock: L1SyncBlockFinalized, L1TentativeSync: true},
+	}
+
+	header200 := &ethTypes.Header{Number: big.NewInt(200)}
+	toBlock := uint64(200)
+	m.On("GetRollupInfoByBlockRange", ctx, uint64(151), &toBlock).Return([]etherman.Block{
+		{BlockNumber: 160, SequencedBatches: [][]etherman.SequencedBatch{{{BatchNumber: 6}}}},
+		{BlockNumber: 180, SequencedBatches: [][]etherman.SequencedBatch{{{BatchNumber: 7}}}},
+	}, noOrder, nil).Once()
+	sync.syncTentativeState(150, header200)
+	assert.Equal(t, TentativeL1State{SyncTarget: 150, BlockNumber: 200, LastSequencedBatch: 7}, sync.TentativeL1State())
+
+	// Only the new blocks are read, and the blocks reaching the sync target are dropped
+	header210 := &ethTypes.Header{Number: big.NewInt(210)}
+	m.On("HeaderByNumber", ctx, big.NewInt(200)).Return(header200, nil).Once()
+	toBlock210 := uint64(210)
+	m.On("GetRollupInfoByBlockRange", ctx, uint64(201), &toBlock210).Return([]etherman.Block{
+		{BlockNumber: 205, VerifiedBatches: []etherman.VerifiedBatch{{BatchNumber: 5}}},
+	}, noOrder, nil).Once()
+	sync.syncTentativeState(170, header210)
+	assert.Equal(t, TentativeL1State{SyncTarget: 170, BlockNumber: 210, LastSequencedBatch: 7, LastVerifiedBatch: 5}, sync.TentativeL1State())
+
+	// Nothing is read if there are no new blocks
+	sync.syncTentativeState(190, header210)
+	assert.Equal(t, TentativeL1State{SyncTarget: 190, BlockNumber: 210, LastVerifiedBatch: 5}, sync.TentativeL1State())
+
+	// The tentative blocks are read again if they were reorged
+	header220 := &ethTypes.Header{Number: big.NewInt(220)}
+	reorgedHeader210 := &ethTypes.Header{Number: big.NewInt(210), Extra: []byte("reorged")}
+	m.On("HeaderByNumber", ctx, big.NewInt(210)).Return(reorgedHeader210, nil).Once()
+	toBlock220 := uint64(220)
+	m.On("GetRollupInfoByBlockRange", ctx, uint64(191), &toBlock220).Return([]etherman.Block{
+		{BlockNumber: 215, SequencedBatches: [][]etherman.SequencedBatch{{{BatchNumber: 8}}}},
+	}, noOrder, nil).Once()
+	sync.syncTentativeState(190, header220)
+	assert.Equal(t, TentativeL1State{SyncTarget: 190, BlockNumber: 220, LastSequencedBatch: 8}, sync.TentativeL1State())
+}
+
+func TestValidateL1SyncBlock(t *testing.T) {
+	for _, l1SyncBlock := range []string{"", L1SyncBlockLatest, L1SyncBlockSafe, L1SyncBlockFinalized} {
+		assert.NoError(t, validateL1SyncBlock(l1SyncBlock))
+	}
+	assert.EqualError(t, validateL1SyncBlock("pending"), `unknown L1SyncBlock "pending", valid values are "latest", "safe" and "finalized"`)
+}
@@ -0,0 +1,133 @@
+package synchronizer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/synchronizer/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// rollupInfoRange is the rollup info of an inclusive range of L1 blocks
+type rollupInfoRange struct {
+	fromBlock uint64
+	toBlock   uint64
+	blocks    []etherman.Block
+	order     map[common.Hash][]etherman.Order
+	// emptyBlock is the last block of the range, fetched when the range has no rollup info
+	// and it's not the last range, so it can be stored as the latest synced block
+	emptyBlock *types.Block
+	err        error
+}
+
+// rollupInfoPrefetcher fetches the rollup info of the upcoming block ranges using several
+// workers, delivering the ranges strictly in order. The amount of ranges fetched ahead of
+// the one being processed is bounded, so the memory used is too
+type rollupInfoPrefetcher struct {
+	etherMan       ethermanInterface
+	ctx            context.Context
+	cancel         context.CancelFunc
+	chunkSize      uint64
+	lastKnownBlock uint64
+	// ranges receives a channel per range, in order, where the worker fetching the range
+	// sends its result
+	ranges chan chan rollupInfoRange
+	wg     sync.WaitGroup
+}
+
+// newRollupInfoPrefetcher starts fetching the ranges from fromBlock up to the range including lastKnownBlock
+func newRollupInfoPrefetcher(ctx context.Context, etherMan ethermanInterface, fromBlock, lastKnownBlock, chunkSize, workers, maxPrefetchedRanges uint64) *rollupInfoPrefetcher {
+	ctx, cancel := context.WithCancel(ctx)
+	p := &rollupInfoPrefetcher{
+		etherMan:       etherMan,
+		ctx:            ctx,
+		cancel:         cancel,
+		chunkSize:      chunkSize,
+		lastKnownBlock: lastKnownBlock,
+		ranges:         make(chan chan rollupInfoRange, maxPrefetchedRanges),
+	}
+	p.wg.Add(1)
+	go p.dispatch(fromBlock, workers)
+	return p
+}
+
+// dispatch assigns the ranges to the workers as they become available
+func (p *rollupInfoPrefetcher) dispatch(fromBlock, workers uint64) {
+	defer p.wg.Done()
+	defer close(p.ranges)
+	available := make(chan struct{}, workers)
+	for {
+		toBlock := fromBlock + p.chunkSize
+		if toBlock > p.lastKnownBlock && fromBlock <= p.lastKnownBlock {
+			toBlock = p.lastKnownBlock
+		}
+		select {
+		case available <- struct{}{}:
+		case <-p.ctx.Done():
+			return
+		}
+		result := make(chan rollupInfoRange, 1)
+		select {
+		case p.ranges <- result:
+		case <-p.ctx.Done():
+			return
+		}
+		p.wg.Add(1)
+		go func(fromBlock, toBlock uint64) {
+			defer p.wg.Done()
+			result <- p.fetch(fromBlock, toBlock)
+			<-available
+		}(fromBlock, toBlock)
+
+		if toBlock >= p.lastKnownBlock {
+			return
+		}
+		fromBlock = toBlock + 1
+	}
+}
+
+// fetch gets the rollup info of a range, and its last block if the range has no rollup info
+func (p *rollupInfoPrefetcher) fetch(fromBlock, toBlock uint64) rollupInfoRange {
+	log.Debugf("Prefetching rollup info from block %d to block %d", fromBlock, toBlock)
+	r := rollupInfoRange{fromBlock: fromBlock, toBlock: toBlock}
+	start := time.Now()
+	r.blocks, r.order, r.err = p.etherMan.GetRollupInfoByBlockRange(p.ctx, fromBlock, &toBlock)
+	metrics.ReadL1DataTime(time.Since(start))
+	if r.err != nil || len(r.blocks) > 0 || toBlock >= p.lastKnownBlock {
+		return r
+	}
+	r.emptyBlock, r.err = p.etherMan.EthBlockByNumber(p.ctx, toBlock)
+	return r
+}
+
+// next returns the next range in order, waiting for it to be fetched. It returns false
+// once the last range has been returned or the prefetcher has been stopped
+func (p *rollupInfoPrefetcher) next() (rollupInfoRange, bool) {
+	if p.ctx.Err() != nil {
+		return rollupInfoRange{}, false
+	}
+	select {
+	case result, ok := <-p.ranges:
+		if !ok {
+			return rollupInfoRange{}, false
+		}
+		select {
+		case r := <-result:
+			return r, true
+		case <-p.ctx.Done():
+			return rollupInfoRange{}, false
+		}
+	case <-p.ctx.Done():
+		return rollupInfoRange{}, false
+	}
+}
+
+// stop cancels the pending requests and discards the prefetched ranges
+func (p *rollupInfoPrefetcher) stop() {
+	p.cancel()
+	p.wg.Wait()
+}
@@ -0,0 +1,146 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	log.Init(log.Config{
+		Level:   "debug",
+		Outputs: []string{"stdout"},
+	})
+}
+
+func TestRollupInfoPrefetcherInOrder(t *testing.T) {
+	m := newEthermanMock(t)
+	order := map[common.Hash][]etherman.Order{}
+
+	// Ranges of 10 blocks from 0 to 39. The first ranges are the slowest ones, so they are
+	// fetched after the next ones, but they must be returned first
+	for i := uint64(0); i < 4; i++ {
+		fromBlock := i * 10
+		toBlock := fromBlock + 9
+		if toBlock > 35 {
+			toBlock = 35
+		}
+		blocks := []etherman.Block{{BlockNumber: fromBlock + 1}}
+		if i == 2 {
+			blocks = []etherman.Block{}
+		}
+		m.On("GetRollupInfoByBlockRange", mock.Anything, fromBlock, &toBlock).
+			After(time.Duration(4-i)*10*time.Millisecond).
+			Return(blocks, order, nil).Once()
+	}
+	emptyBlock := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(29)})
+	m.On("EthBlockByNumber", mock.Anything, uint64(29)).Return(emptyBlock, nil).Once()
+
+	p := newRollupInfoPrefetcher(context.Background(), m, 0, 35, 9, 4, 2)
+	defer p.stop()
+
+	for i := uint64(0); i < 4; i++ {
+		r, ok := p.next()
+		require.True(t, ok)
+		require.NoError(t, r.err)
+		assert.Equal(t, i*10, r.fromBlock)
+		if i == 3 {
+			assert.Equal(t, uint64(35), r.toBlock)
+		} else {
+			assert.Equal(t, i*10+9, r.toBlock)
+		}
+		if i == 2 {
+			assert.Empty(t, r.blocks)
+			assert.Equal(t, emptyBlock, r.emptyBlock)
+		} else {
+			require.Len(t, r.blocks, 1)
+			assert.Equal(t, i*10+1, r.blocks[0].BlockNumber)
+			assert.Nil(t, r.emptyBlock)
+		}
+	}
+	_, ok := p.next()
+	assert.False(t, ok)
+}
+
+func TestRollupInfoPrefetcherStop(t *testing.T) {
+	m := newEthermanMock(t)
+	errL1 := errors.New("L1 error")
+	toBlock := uint64(9)
+	m.On("GetRollupInfoByBlockRange", mock.Anything, uint64(0), &toBlock).Return(nil, nil, errL1).Once()
+	// The next ranges may be requested before the prefetcher is stopped, depending on the workers
+	m.On("GetRollupInfoByBlockRange", mock.Anything, mock.Anything, mock.Anything).
+		Return([]etherman.Block{{}}, map[common.Hash][]etherman.Order{}, nil).Maybe()
+
+	p := newRollupInfoPrefetcher(context.Background(), m, 0, 1000, 9, 2, 1)
+	r, ok := p.next()
+	require.True(t, ok)
+	assert.ErrorIs(t, r.err, errL1)
+
+	p.stop()
+	_, ok = p.next()
+	assert.False(t, ok)
+}
+
+func TestSyncBlocksParallelReorg(t *testing.T) {
+	ctx := context.Background()
+	m := mocks{
+		Etherman:     newEthermanMock(t),
+		State:        newStateMock(t),
+		EthTxManager: newEthTxManagerMock(t),
+		DbTx:         newDbTxMock(t),
+	}
+	sync := &ClientSynchronizer{
+		etherMan:                 m.Etherman,
+		state:                    m.State,
+		ethTxManager:             m.EthTxManager,
+		ctx:                      ctx,
+		latestFlushIDIsFulfilled: true,
+		cfg: Config{
+			SyncChunkSize:         9,
+			L1ParallelWorkers:     3,
+			L1MaxPrefetchedRanges: 3,
+		},
+	}
+
+	ethBlock100 := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(100)})
+	lastEthBlockSynced := &state.Block{BlockNumber: 100, BlockHash: ethBlock100.Hash(), ParentHash: ethBlock100.ParentHash()}
+	block105 := etherman.Block{BlockNumber: 105, BlockHash: common.HexToHash("0x105"), ParentHash: common.HexToHash("0x104")}
+
+	toBlock := uint64(110)
+	m.Etherman.On("GetRollupInfoByBlockRange", mock.Anything, uint64(101), &toBlock).
+		Return([]etherman.Block{block105}, map[common.Hash][]etherman.Order{}, nil).Once()
+	// The next ranges are prefetched, but discarded because of the reorg
+	m.Etherman.On("GetRollupInfoByBlockRange", mock.Anything, mock.Anything, mock.Anything).
+		Return([]etherman.Block{}, map[common.Hash][]etherman.Order{}, nil).Maybe()
+
+	// Block 105 is processed
+	m.State.On("BeginStateTransaction", ctx).Return(m.DbTx, nil)
+	m.State.On("AddBlock", ctx, &state.Block{BlockNumber: 105, BlockHash: block105.BlockHash, ParentHash: block105.ParentHash}, m.DbTx).Return(nil).Once()
+	m.DbTx.On("Commit", ctx).Return(nil)
+
+	// Block 105 is not in L1 anymore, so the sync goes back to block 100
+	m.Etherman.On("EthBlockByNumber", ctx, uint64(105)).
+		Return(ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(105)}), nil).Once()
+	m.State.On("GetPreviousBlock", ctx, uint64(1), m.DbTx).Return(lastEthBlockSynced, nil).Once()
+	m.Etherman.On("EthBlockByNumber", ctx, uint64(100)).Return(ethBlock100, nil).Once()
+	m.State.On("Reset", ctx, uint64(100), m.DbTx).Return(nil).Once()
+	m.EthTxManager.On("Reorg", ctx, uint64(101), m.DbTx).Return(nil).Once()
+	// The empty ranges prefetched request their last block
+	m.Etherman.On("EthBlockByNumber", mock.Anything, mock.Anything).
+		Return(ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(120)}), nil).Maybe()
+
+	block, err := sync.syncBlocksParallel(lastEthBlockSynced, 101, 130)
+	require.NoError(t, err)
+	assert.Equal(t, lastEthBlockSynced, block)
+}
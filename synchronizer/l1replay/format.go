@@ -0,0 +1,80 @@
+package l1replay
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// FormatVersion is the version of the recording format. It's written in the first line of the
+// recordings, and recordings with a different version are rejected
+const FormatVersion = 2
+
+// Etherman gathers the L1 methods used by the synchronizer and read by the node when it starts, which
+// are recorded and replayed
+type Etherman interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error)
+	GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, error)
+	EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error)
+	GetLatestBatchNumber() (uint64, error)
+	GetTrustedSequencerURL() (string, error)
+	VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error)
+	GetLatestVerifiedBatchNum() (uint64, error)
+	GetSafeBlockNumber(ctx context.Context) (uint64, error)
+	GetFinalizedBlockNumber(ctx context.Context) (uint64, error)
+	LoadDataCommitteeAt(blockNumber uint64) error
+	GetCurrentDataCommittee() (*etherman.DataCommittee, error)
+	GetLatestDataCommittee() (*etherman.DataCommittee, error)
+	GetL2ChainID() (uint64, error)
+	GetForks(ctx context.Context, genBlockNumber uint64, lastL1BlockSynced uint64) ([]state.ForkIDInterval, error)
+}
+
+type recordType string
+
+const (
+	// recordRollupInfo is the rollup info of a block range
+	recordRollupInfo recordType = "rollupInfo"
+	// recordHeader is the header of a block, or the latest header when Latest is set
+	recordHeader recordType = "header"
+	// recordLatestBatchNumber is the last batch sequenced on L1
+	recordLatestBatchNumber recordType = "latestBatchNumber"
+	// recordLatestVerifiedBatchNumber is the last batch verified on L1
+	recordLatestVerifiedBatchNumber recordType = "latestVerifiedBatchNumber"
+	// recordSafeBlockNumber is the safe L1 block
+	recordSafeBlockNumber recordType = "safeBlockNumber"
+	// recordFinalizedBlockNumber is the finalized L1 block
+	recordFinalizedBlockNumber recordType = "finalizedBlockNumber"
+	// recordTrustedSequencerURL is the trusted sequencer URL set in the smart contract
+	recordTrustedSequencerURL recordType = "trustedSequencerURL"
+	// recordL2ChainID is the L2 chain ID set in the smart contract
+	recordL2ChainID recordType = "l2ChainID"
+	// recordForks are the fork ID intervals from the genesis block (FromBlock) to ToBlock
+	recordForks recordType = "forks"
+	// recordDataCommittee is the data committee loaded at the block FromBlock, or the current committee when
+	// Latest is set
+	recordDataCommittee recordType = "dataCommittee"
+)
+
+// fileHeader is the first line of a recording
+type fileHeader struct {
+	Version int `json:"version"`
+}
+
+// record is a line of a recording, with the answer of L1 to one of the recorded methods
+type record struct {
+	Type      recordType                       `json:"type"`
+	FromBlock uint64                           `json:"fromBlock,omitempty"`
+	ToBlock   uint64                           `json:"toBlock,omitempty"`
+	Blocks    []etherman.Block                 `json:"blocks,omitempty"`
+	Order     map[common.Hash][]etherman.Order `json:"order,omitempty"`
+	Header    *ethTypes.Header                 `json:"header,omitempty"`
+	Latest    bool                             `json:"latest,omitempty"`
+	Value     uint64                           `json:"value,omitempty"`
+	URL       string                           `json:"url,omitempty"`
+	Forks     []state.ForkIDInterval           `json:"forks,omitempty"`
+	Committee *etherman.DataCommittee          `json:"committee,omitempty"`
+}
@@ -0,0 +1,241 @@
+package l1replay
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeL1 is an Etherman with a fixed set of blocks
+type fakeL1 struct {
+	Etherman
+	latest     uint64
+	blocks     []etherman.Block
+	order      map[common.Hash][]etherman.Order
+	committee  *etherman.DataCommittee
+	committees map[uint64]*etherman.DataCommittee
+}
+
+func newHeader(number uint64) *ethTypes.Header {
+	return &ethTypes.Header{
+		ParentHash: common.BigToHash(new(big.Int).SetUint64(number - 1)),
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: big.NewInt(0),
+		Time:       number * 12, //nolint:gomnd
+		Extra:      []byte{},
+	}
+}
+
+func (f *fakeL1) HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	if number == nil {
+		return newHeader(f.latest), nil
+	}
+	return newHeader(number.Uint64()), nil
+}
+
+func (f *fakeL1) EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error) {
+	return ethTypes.NewBlockWithHeader(newHeader(blockNumber)), nil
+}
+
+func (f *fakeL1) GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, error) {
+	blocks := []etherman.Block{}
+	order := map[common.Hash][]etherman.Order{}
+	for _, block := range f.blocks {
+		if block.BlockNumber >= fromBlock && (toBlock == nil || block.BlockNumber <= *toBlock) {
+			blocks = append(blocks, block)
+			order[block.BlockHash] = f.order[block.BlockHash]
+		}
+	}
+	return blocks, order, nil
+}
+
+func (f *fakeL1) GetLatestBatchNumber() (uint64, error) {
+	return 3, nil //nolint:gomnd
+}
+
+func (f *fakeL1) GetL2ChainID() (uint64, error) {
+	return 1001, nil //nolint:gomnd
+}
+
+func (f *fakeL1) GetForks(ctx context.Context, genBlockNumber uint64, lastL1BlockSynced uint64) ([]state.ForkIDInterval, error) {
+	return []state.ForkIDInterval{{FromBatchNumber: 0, ToBatchNumber: 10, ForkId: 5, BlockNumber: genBlockNumber}}, nil //nolint:gomnd
+}
+
+func (f *fakeL1) GetCurrentDataCommittee() (*etherman.DataCommittee, error) {
+	return f.committee, nil
+}
+
+func (f *fakeL1) LoadDataCommitteeAt(blockNumber uint64) error {
+	f.committee = f.committees[blockNumber]
+	return nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "l1.jsonl")
+
+	block105 := etherman.Block{
+		BlockNumber: 105,
+		BlockHash:   newHeader(105).Hash(),
+		ParentHash:  newHeader(105).ParentHash,
+		SequencedBatches: [][]etherman.SequencedBatch{{
+			{BatchNumber: 2, SequencerAddr: common.HexToAddress("0x1"), TxHash: common.HexToHash("0x2"), Nonce: 1},
+		}},
+		ReceivedAt: time.Unix(105*12, 0).UTC(),
+	}
+	block112 := etherman.Block{
+		BlockNumber:     112,
+		BlockHash:       newHeader(112).Hash(),
+		ParentHash:      newHeader(112).ParentHash,
+		VerifiedBatches: []etherman.VerifiedBatch{{BlockNumber: 112, BatchNumber: 2}},
+		ReceivedAt:      time.Unix(112*12, 0).UTC(),
+	}
+	l1 := &fakeL1{
+		latest: 120,
+		blocks: []etherman.Block{block105, block112},
+		order: map[common.Hash][]etherman.Order{
+			block105.BlockHash: {{Name: etherman.SequenceBatchesOrder, Pos: 0}},
+			block112.BlockHash: {{Name: etherman.TrustedVerifyBatchOrder, Pos: 0}},
+		},
+	}
+
+	recorder, err := NewRecorder(path, l1)
+	require.NoError(t, err)
+	latest, err := recorder.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	for _, r := range []blockRange{{from: 101, to: 110}, {from: 111, to: 120}} {
+		toBlock := r.to
+		_, _, err = recorder.GetRollupInfoByBlockRange(ctx, r.from, &toBlock)
+		require.NoError(t, err)
+	}
+	_, err = recorder.EthBlockByNumber(ctx, 110)
+	require.NoError(t, err)
+	_, err = recorder.GetLatestBatchNumber()
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	// Appending to an existing recording keeps a single version header
+	recorder, err = NewRecorder(path, l1)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	replayer, err := NewReplayer(path)
+	require.NoError(t, err)
+
+	header, err := replayer.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, latest.Hash(), header.Hash())
+
+	// The replayed ranges don't need to match the recorded ones
+	toBlock := uint64(115)
+	blocks, order, err := replayer.GetRollupInfoByBlockRange(ctx, 104, &toBlock)
+	require.NoError(t, err)
+	assert.Equal(t, []etherman.Block{block105, block112}, blocks)
+	assert.Equal(t, l1.order, order)
+
+	// The headers of the blocks with rollup info are recorded, so the reorg checks match
+	ethBlock, err := replayer.EthBlockByNumber(ctx, 105)
+	require.NoError(t, err)
+	assert.Equal(t, block105.BlockHash, ethBlock.Hash())
+	ethBlock, err = replayer.EthBlockByNumber(ctx, 110)
+	require.NoError(t, err)
+	assert.Equal(t, newHeader(110).Hash(), ethBlock.Hash())
+
+	batchNumber, err := replayer.GetLatestBatchNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), batchNumber)
+
+	toBlock = 130
+	_, _, err = replayer.GetRollupInfoByBlockRange(ctx, 90, &toBlock)
+	assert.ErrorIs(t, err, ErrNotRecorded)
+	blocks, _, err = replayer.GetRollupInfoByBlockRange(ctx, 121, &toBlock)
+	require.NoError(t, err)
+	assert.Empty(t, blocks)
+}
+
+func TestReplayLastRecordedData(t *testing.T) {
+	r := &Replayer{
+		blocks:  map[uint64]etherman.Block{},
+		order:   map[common.Hash][]etherman.Order{},
+		headers: map[uint64]*ethTypes.Header{},
+	}
+	reorged := etherman.Block{BlockNumber: 5, BlockHash: common.HexToHash("0x5a")}
+	require.NoError(t, r.load(record{Type: recordRollupInfo, FromBlock: 1, ToBlock: 10, Blocks: []etherman.Block{reorged}}))
+	canonical := etherman.Block{BlockNumber: 6, BlockHash: common.HexToHash("0x6b")}
+	require.NoError(t, r.load(record{Type: recordRollupInfo, FromBlock: 5, ToBlock: 20, Blocks: []etherman.Block{canonical}}))
+	assert.Equal(t, []blockRange{{from: 1, to: 20}}, r.covered)
+
+	blocks, _, err := r.GetRollupInfoByBlockRange(context.Background(), 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []etherman.Block{canonical}, blocks)
+}
+
+func TestRecordAndReplayStartData(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "l1.jsonl")
+
+	first := &etherman.DataCommittee{AddressesHash: common.HexToHash("0x1"), RequiredSignatures: 1}
+	second := &etherman.DataCommittee{AddressesHash: common.HexToHash("0x2"), RequiredSignatures: 2}
+	l1 := &fakeL1{committee: first, committees: map[uint64]*etherman.DataCommittee{150: second}}
+
+	recorder, err := NewRecorder(path, l1)
+	require.NoError(t, err)
+	chainID, err := recorder.GetL2ChainID()
+	require.NoError(t, err)
+	forks, err := recorder.GetForks(ctx, 100, 100)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = recorder.GetCurrentDataCommittee()
+		require.NoError(t, err)
+	}
+	require.NoError(t, recorder.LoadDataCommitteeAt(150))
+	_, err = recorder.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	// The committee is only recorded when it changes
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), string(recordDataCommittee)))
+
+	replayer, err := NewReplayer(path)
+	require.NoError(t, err)
+	replayedChainID, err := replayer.GetL2ChainID()
+	require.NoError(t, err)
+	assert.Equal(t, chainID, replayedChainID)
+	replayedForks, err := replayer.GetForks(ctx, 100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, forks, replayedForks)
+	_, err = replayer.GetForks(ctx, 100, 200)
+	assert.ErrorIs(t, err, ErrNotRecorded)
+
+	// The replay starts with the first committee, until the committee of the block is loaded
+	committee, err := replayer.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, first, committee)
+	assert.ErrorIs(t, replayer.LoadDataCommitteeAt(140), ErrNotRecorded)
+	require.NoError(t, replayer.LoadDataCommitteeAt(150))
+	committee, err = replayer.GetCurrentDataCommittee()
+	require.NoError(t, err)
+	assert.Equal(t, second, committee)
+}
+
+func TestReplayUnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "l1.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"version\":1}\n"), 0600))
+	_, err := NewReplayer(path)
+	assert.ErrorContains(t, err, "unsupported recording version 1, expected 2")
+	_, err = NewRecorder(path, &fakeL1{})
+	assert.ErrorContains(t, err, "unsupported recording version 1, expected 2")
+}
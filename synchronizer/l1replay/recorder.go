@@ -0,0 +1,222 @@
+package l1replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Recorder is an Etherman that records the answers of the wrapped Etherman to a file, so they can be
+// replayed later by a Replayer. The headers of the blocks with rollup info are recorded too, so the
+// reorg checks of the synchronizer can be replayed
+type Recorder struct {
+	Etherman
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+	// committeeHash is the hash of the last data committee recorded
+	committeeHash common.Hash
+}
+
+// NewRecorder creates a Recorder appending the answers of l1 to the file at path. A new file is
+// created if it doesn't exist, otherwise its version must match the current format
+func NewRecorder(path string, l1 Etherman) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644) //nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{Etherman: l1, file: file, enc: json.NewEncoder(file)}
+
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if errors.Is(err, io.EOF) && len(line) == 0 {
+		if err := r.enc.Encode(fileHeader{Version: FormatVersion}); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		return r, nil
+	} else if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to read the header of the recording %s: %w", path, err)
+	}
+	if err := checkVersion(line); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("can't append to the recording %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Close closes the recording file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+func (r *Recorder) write(rec record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(rec); err != nil {
+		return fmt.Errorf("failed to record the L1 %s: %w", rec.Type, err)
+	}
+	return nil
+}
+
+// HeaderByNumber records the header of the block, or the latest header if number is nil
+func (r *Recorder) HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	header, err := r.Etherman.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return header, r.write(record{Type: recordHeader, Header: header, Latest: number == nil})
+}
+
+// GetRollupInfoByBlockRange records the rollup info of the range, and the headers of its blocks
+func (r *Recorder) GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, error) {
+	blocks, order, err := r.Etherman.GetRollupInfoByBlockRange(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+	rec := record{Type: recordRollupInfo, FromBlock: fromBlock, Blocks: blocks, Order: order}
+	if toBlock != nil {
+		rec.ToBlock = *toBlock
+	} else {
+		// The range ends at the latest block, which is the last block with rollup info at most
+		header, err := r.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		rec.ToBlock = header.Number.Uint64()
+	}
+	for _, block := range blocks {
+		if _, err := r.HeaderByNumber(ctx, new(big.Int).SetUint64(block.BlockNumber)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return blocks, order, r.write(rec)
+}
+
+// EthBlockByNumber records the header of the block
+func (r *Recorder) EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error) {
+	block, err := r.Etherman.EthBlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return block, r.write(record{Type: recordHeader, Header: block.Header()})
+}
+
+// GetLatestBatchNumber records the last batch sequenced on L1
+func (r *Recorder) GetLatestBatchNumber() (uint64, error) {
+	value, err := r.Etherman.GetLatestBatchNumber()
+	if err != nil {
+		return 0, err
+	}
+	return value, r.write(record{Type: recordLatestBatchNumber, Value: value})
+}
+
+// GetLatestVerifiedBatchNum records the last batch verified on L1
+func (r *Recorder) GetLatestVerifiedBatchNum() (uint64, error) {
+	value, err := r.Etherman.GetLatestVerifiedBatchNum()
+	if err != nil {
+		return 0, err
+	}
+	return value, r.write(record{Type: recordLatestVerifiedBatchNumber, Value: value})
+}
+
+// GetSafeBlockNumber records the safe L1 block
+func (r *Recorder) GetSafeBlockNumber(ctx context.Context) (uint64, error) {
+	value, err := r.Etherman.GetSafeBlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return value, r.write(record{Type: recordSafeBlockNumber, Value: value})
+}
+
+// GetFinalizedBlockNumber records the finalized L1 block
+func (r *Recorder) GetFinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	value, err := r.Etherman.GetFinalizedBlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return value, r.write(record{Type: recordFinalizedBlockNumber, Value: value})
+}
+
+// GetTrustedSequencerURL records the trusted sequencer URL
+func (r *Recorder) GetTrustedSequencerURL() (string, error) {
+	url, err := r.Etherman.GetTrustedSequencerURL()
+	if err != nil {
+		return "", err
+	}
+	return url, r.write(record{Type: recordTrustedSequencerURL, URL: url})
+}
+
+// GetL2ChainID records the L2 chain ID
+func (r *Recorder) GetL2ChainID() (uint64, error) {
+	value, err := r.Etherman.GetL2ChainID()
+	if err != nil {
+		return 0, err
+	}
+	return value, r.write(record{Type: recordL2ChainID, Value: value})
+}
+
+// GetForks records the fork ID intervals of the block range
+func (r *Recorder) GetForks(ctx context.Context, genBlockNumber uint64, lastL1BlockSynced uint64) ([]state.ForkIDInterval, error) {
+	forks, err := r.Etherman.GetForks(ctx, genBlockNumber, lastL1BlockSynced)
+	if err != nil {
+		return nil, err
+	}
+	return forks, r.write(record{Type: recordForks, FromBlock: genBlockNumber, ToBlock: lastL1BlockSynced, Forks: forks})
+}
+
+// GetCurrentDataCommittee records the current data committee. As it's read on every retrieval of
+// the batch data, it's only recorded when it changes
+func (r *Recorder) GetCurrentDataCommittee() (*etherman.DataCommittee, error) {
+	committee, err := r.Etherman.GetCurrentDataCommittee()
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	changed := r.committeeHash != committee.AddressesHash
+	r.committeeHash = committee.AddressesHash
+	r.mu.Unlock()
+	if !changed {
+		return committee, nil
+	}
+	return committee, r.write(record{Type: recordDataCommittee, Committee: committee, Latest: true})
+}
+
+// LoadDataCommitteeAt records the data committee loaded at the block
+func (r *Recorder) LoadDataCommitteeAt(blockNumber uint64) error {
+	if err := r.Etherman.LoadDataCommitteeAt(blockNumber); err != nil {
+		return err
+	}
+	committee, err := r.Etherman.GetCurrentDataCommittee()
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.committeeHash = committee.AddressesHash
+	r.mu.Unlock()
+	return r.write(record{Type: recordDataCommittee, FromBlock: blockNumber, Committee: committee})
+}
+
+func checkVersion(line []byte) error {
+	var header fileHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return fmt.Errorf("invalid recording header: %w", err)
+	}
+	if header.Version != FormatVersion {
+		return fmt.Errorf("unsupported recording version %d, expected %d", header.Version, FormatVersion)
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package l1replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const maxRecordSize = 64 * 1024 * 1024
+
+// ErrNotRecorded is returned when the requested L1 data is not in the recording
+var ErrNotRecorded = errors.New("not recorded")
+
+// blockRange is an inclusive range of L1 blocks
+type blockRange struct {
+	from uint64
+	to   uint64
+}
+
+// Replayer is an Etherman answering with the L1 data of a recording, so the synchronizer can run
+// without an L1 node. When the same blocks were recorded several times, for instance before and
+// after a reorg, the last recorded data is replayed. The methods returning the latest L1 values
+// answer with the last recorded value. The replay starts with the first recorded data committee, which
+// is replaced by the committee recorded at a block when it's loaded
+type Replayer struct {
+	// covered are the sorted and merged block ranges whose rollup info was recorded
+	covered []blockRange
+	blocks  map[uint64]etherman.Block
+	order   map[common.Hash][]etherman.Order
+	headers map[uint64]*ethTypes.Header
+
+	latestHeader              *ethTypes.Header
+	latestBatchNumber         uint64
+	latestVerifiedBatchNumber uint64
+	safeBlockNumber           uint64
+	finalizedBlockNumber      uint64
+	trustedSequencerURL       string
+	l2ChainID                 uint64
+	forks                     map[blockRange][]state.ForkIDInterval
+	committees                map[uint64]*etherman.DataCommittee
+
+	// committee is the current data committee, replaced while replaying
+	committeeMu sync.RWMutex
+	committee   *etherman.DataCommittee
+}
+
+// NewReplayer loads the recording at path
+func NewReplayer(path string) (*Replayer, error) {
+	file, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := &Replayer{
+		blocks:     map[uint64]etherman.Block{},
+		order:      map[common.Hash][]etherman.Order{},
+		headers:    map[uint64]*ethTypes.Header{},
+		forks:      map[blockRange][]state.ForkIDInterval{},
+		committees: map[uint64]*etherman.DataCommittee{},
+	}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxRecordSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("empty recording %s", path)
+	}
+	if err := checkVersion(scanner.Bytes()); err != nil {
+		return nil, fmt.Errorf("can't replay the recording %s: %w", path, err)
+	}
+	for line := 2; scanner.Scan(); line++ {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("invalid record at line %d of %s: %w", line, path, err)
+		}
+		if err := r.load(rec); err != nil {
+			return nil, fmt.Errorf("invalid record at line %d of %s: %w", line, path, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Replayer) load(rec record) error {
+	switch rec.Type {
+	case recordRollupInfo:
+		if rec.ToBlock < rec.FromBlock {
+			return fmt.Errorf("invalid block range from %d to %d", rec.FromBlock, rec.ToBlock)
+		}
+		// The last recorded data of the range replaces the previous one
+		for number, block := range r.blocks {
+			if number >= rec.FromBlock && number <= rec.ToBlock {
+				delete(r.order, block.BlockHash)
+				delete(r.blocks, number)
+			}
+		}
+		for _, block := range rec.Blocks {
+			r.blocks[block.BlockNumber] = block
+			r.order[block.BlockHash] = rec.Order[block.BlockHash]
+		}
+		r.cover(blockRange{from: rec.FromBlock, to: rec.ToBlock})
+	case recordHeader:
+		if rec.Header == nil || rec.Header.Number == nil {
+			return errors.New("missing header")
+		}
+		r.headers[rec.Header.Number.Uint64()] = rec.Header
+		if rec.Latest {
+			r.latestHeader = rec.Header
+		}
+	case recordLatestBatchNumber:
+		r.latestBatchNumber = rec.Value
+	case recordLatestVerifiedBatchNumber:
+		r.latestVerifiedBatchNumber = rec.Value
+	case recordSafeBlockNumber:
+		r.safeBlockNumber = rec.Value
+	case recordFinalizedBlockNumber:
+		r.finalizedBlockNumber = rec.Value
+	case recordTrustedSequencerURL:
+		r.trustedSequencerURL = rec.URL
+	case recordL2ChainID:
+		r.l2ChainID = rec.Value
+	case recordForks:
+		r.forks[blockRange{from: rec.FromBlock, to: rec.ToBlock}] = rec.Forks
+	case recordDataCommittee:
+		if rec.Committee == nil {
+			return errors.New("missing data committee")
+		}
+		if !rec.Latest {
+			r.committees[rec.FromBlock] = rec.Committee
+		} else if r.committee == nil {
+			r.committee = rec.Committee
+		}
+	default:
+		return fmt.Errorf("unknown record type %q", rec.Type)
+	}
+	return nil
+}
+
+// cover adds a range to the covered ranges, merging it with the ranges it overlaps or follows
+func (r *Replayer) cover(newRange blockRange) {
+	ranges := append(r.covered, newRange) //nolint:gocritic
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].from < ranges[j].from })
+	merged := ranges[:1]
+	for _, br := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if br.from <= last.to+1 {
+			if br.to > last.to {
+				last.to = br.to
+			}
+			continue
+		}
+		merged = append(merged, br)
+	}
+	r.covered = merged
+}
+
+func (r *Replayer) isCovered(fromBlock, toBlock uint64) bool {
+	for _, br := range r.covered {
+		if fromBlock >= br.from && toBlock <= br.to {
+			return true
+		}
+	}
+	return false
+}
+
+// latestBlockNumber is the latest L1 block of the recording
+func (r *Replayer) latestBlockNumber() uint64 {
+	var latest uint64
+	if r.latestHeader != nil {
+		latest = r.latestHeader.Number.Uint64()
+	}
+	if len(r.covered) > 0 && r.covered[len(r.covered)-1].to > latest {
+		latest = r.covered[len(r.covered)-1].to
+	}
+	return latest
+}
+
+// header returns the recorded header of the block. Blocks without rollup info whose header wasn't
+// recorded get a deterministic header, so the reorg checks of the replay are consistent
+func (r *Replayer) header(number uint64) (*ethTypes.Header, error) {
+	if header, found := r.headers[number]; found {
+		return header, nil
+	}
+	if number > r.latestBlockNumber() {
+		return nil, fmt.Errorf("block %d: %w", number, ErrNotRecorded)
+	}
+	if _, found := r.blocks[number]; found {
+		return nil, fmt.Errorf("header of block %d: %w", number, ErrNotRecorded)
+	}
+	return &ethTypes.Header{Number: new(big.Int).SetUint64(number), Difficulty: big.NewInt(0)}, nil
+}
+
+// HeaderByNumber returns the recorded header of the block, or the latest recorded header if number is nil
+func (r *Replayer) HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	if number == nil {
+		return r.header(r.latestBlockNumber())
+	}
+	return r.header(number.Uint64())
+}
+
+// GetRollupInfoByBlockRange returns the recorded rollup info of the range, which must be fully covered by the recording
+func (r *Replayer) GetRollupInfoByBlockRange(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]etherman.Block, map[common.Hash][]etherman.Order, error) {
+	to := r.latestBlockNumber()
+	if toBlock != nil && *toBlock < to {
+		to = *toBlock
+	}
+	if to < fromBlock {
+		// The range is after the latest recorded block, like a range after the latest L1 block
+		return []etherman.Block{}, map[common.Hash][]etherman.Order{}, nil
+	}
+	if !r.isCovered(fromBlock, to) {
+		return nil, nil, fmt.Errorf("rollup info from block %d to block %d: %w", fromBlock, to, ErrNotRecorded)
+	}
+	numbers := make([]uint64, 0)
+	for number := range r.blocks {
+		if number >= fromBlock && number <= to {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	blocks := make([]etherman.Block, 0, len(numbers))
+	order := make(map[common.Hash][]etherman.Order, len(numbers))
+	for _, number := range numbers {
+		block := r.blocks[number]
+		blocks = append(blocks, block)
+		order[block.BlockHash] = r.order[block.BlockHash]
+	}
+	return blocks, order, nil
+}
+
+// EthBlockByNumber returns a block with the recorded header of the block
+func (r *Replayer) EthBlockByNumber(ctx context.Context, blockNumber uint64) (*ethTypes.Block, error) {
+	header, err := r.header(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return ethTypes.NewBlockWithHeader(header), nil
+}
+
+// GetLatestBatchNumber returns the last recorded batch sequenced on L1
+func (r *Replayer) GetLatestBatchNumber() (uint64, error) {
+	return r.latestBatchNumber, nil
+}
+
+// GetLatestVerifiedBatchNum returns the last recorded batch verified on L1
+func (r *Replayer) GetLatestVerifiedBatchNum() (uint64, error) {
+	return r.latestVerifiedBatchNumber, nil
+}
+
+// GetSafeBlockNumber returns the last recorded safe L1 block
+func (r *Replayer) GetSafeBlockNumber(ctx context.Context) (uint64, error) {
+	return r.safeBlockNumber, nil
+}
+
+// GetFinalizedBlockNumber returns the last recorded finalized L1 block
+func (r *Replayer) GetFinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	return r.finalizedBlockNumber, nil
+}
+
+// GetTrustedSequencerURL returns the recorded trusted sequencer URL
+func (r *Replayer) GetTrustedSequencerURL() (string, error) {
+	return r.trustedSequencerURL, nil
+}
+
+// VerifyGenBlockNumber accepts the genesis block, as the recording comes from a synchronizer that already checked it
+func (r *Replayer) VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error) {
+	return true, nil
+}
+
+// GetL2ChainID returns the recorded L2 chain ID
+func (r *Replayer) GetL2ChainID() (uint64, error) {
+	if r.l2ChainID == 0 {
+		return 0, fmt.Errorf("L2 chain ID: %w", ErrNotRecorded)
+	}
+	return r.l2ChainID, nil
+}
+
+// GetForks returns the fork ID intervals recorded for the same block range
+func (r *Replayer) GetForks(ctx context.Context, genBlockNumber uint64, lastL1BlockSynced uint64) ([]state.ForkIDInterval, error) {
+	forks, found := r.forks[blockRange{from: genBlockNumber, to: lastL1BlockSynced}]
+	if !found {
+		return nil, fmt.Errorf("forks from block %d to block %d: %w", genBlockNumber, lastL1BlockSynced, ErrNotRecorded)
+	}
+	return forks, nil
+}
+
+// GetCurrentDataCommittee returns the data committee recorded first, or the last one loaded at a block
+func (r *Replayer) GetCurrentDataCommittee() (*etherman.DataCommittee, error) {
+	r.committeeMu.RLock()
+	defer r.committeeMu.RUnlock()
+	if r.committee == nil {
+		return nil, fmt.Errorf("data committee: %w", ErrNotRecorded)
+	}
+	return r.committee, nil
+}
+
+// GetLatestDataCommittee returns the current data committee, as there is no L1 to read the latest one from
+func (r *Replayer) GetLatestDataCommittee() (*etherman.DataCommittee, error) {
+	return r.GetCurrentDataCommittee()
+}
+
+// LoadDataCommitteeAt replaces the current data committee with the one recorded at the block
+func (r *Replayer) LoadDataCommitteeAt(blockNumber uint64) error {
+	committee, found := r.committees[blockNumber]
+	if !found {
+		return fmt.Errorf("data committee at block %d: %w", blockNumber, ErrNotRecorded)
+	}
+	r.committeeMu.Lock()
+	defer r.committeeMu.Unlock()
+	r.committee = committee
+	return nil
+}
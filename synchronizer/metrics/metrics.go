@@ -37,6 +37,15 @@ const (
 
 	// ProcessTrustedBatchTimeName is the name of the label to process trusted batch.
 	ProcessTrustedBatchTimeName = Prefix + "process_trusted_batch_time"
+
+	// TentativeBlockNumberName is the name of the metric with the latest L1 block checked for tentative rollup info.
+	TentativeBlockNumberName = Prefix + "tentative_block_number"
+
+	// TentativeLastSequencedBatchName is the name of the metric with the last batch sequenced in the tentative L1 blocks.
+	TentativeLastSequencedBatchName = Prefix + "tentative_last_sequenced_batch"
+
+	// TentativeLastVerifiedBatchName is the name of the metric with the last batch verified in the tentative L1 blocks.
+	TentativeLastVerifiedBatchName = Prefix + "tentative_last_verified_batch"
 )
 
 // Register the metrics for the synchronizer package.
@@ -80,7 +89,23 @@ func Register() {
 		},
 	}
 
+	gauges := []prometheus.GaugeOpts{
+		{
+			Name: TentativeBlockNumberName,
+			Help: "[SYNCHRONIZER] latest L1 block checked for tentative rollup info",
+		},
+		{
+			Name: TentativeLastSequencedBatchName,
+			Help: "[SYNCHRONIZER] last batch sequenced in the tentative L1 blocks",
+		},
+		{
+			Name: TentativeLastVerifiedBatchName,
+			Help: "[SYNCHRONIZER] last batch verified in the tentative L1 blocks",
+		},
+	}
+
 	metrics.RegisterHistograms(histograms...)
+	metrics.RegisterGauges(gauges...)
 }
 
 // InitializationTime observes the time initializing the synchronizer on the histogram.
@@ -136,3 +161,10 @@ func ProcessTrustedBatchTime(lastProcessTime time.Duration) {
 	execTimeInSeconds := float64(lastProcessTime) / float64(time.Second)
 	metrics.HistogramObserve(ProcessTrustedBatchTimeName, execTimeInSeconds)
 }
+
+// TentativeL1State sets the rollup info found in the L1 blocks that are not processed yet because of their finality.
+func TentativeL1State(blockNumber, lastSequencedBatch, lastVerifiedBatch uint64) {
+	metrics.GaugeSet(TentativeBlockNumberName, float64(blockNumber))
+	metrics.GaugeSet(TentativeLastSequencedBatchName, float64(lastSequencedBatch))
+	metrics.GaugeSet(TentativeLastVerifiedBatchName, float64(lastVerifiedBatch))
+}
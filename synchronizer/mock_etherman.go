@@ -48,25 +48,25 @@ func (_m *ethermanMock) EthBlockByNumber(ctx context.Context, blockNumber uint64
 	return r0, r1
 }
 
-// GetCurrentDataCommittee provides a mock function with given fields:
-func (_m *ethermanMock) GetCurrentDataCommittee() (*etherman.DataCommittee, error) {
-	ret := _m.Called()
+// GetForks provides a mock function with given fields: ctx, genBlockNumber
+func (_m *ethermanMock) GetForks(ctx context.Context, genBlockNumber uint64) ([]state.ForkIDInterval, error) {
+	ret := _m.Called(ctx, genBlockNumber)
 
-	var r0 *etherman.DataCommittee
+	var r0 []state.ForkIDInterval
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*etherman.DataCommittee, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]state.ForkIDInterval, error)); ok {
+		return rf(ctx, genBlockNumber)
 	}
-	if rf, ok := ret.Get(0).(func() *etherman.DataCommittee); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []state.ForkIDInterval); ok {
+		r0 = rf(ctx, genBlockNumber)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*etherman.DataCommittee)
+			r0 = ret.Get(0).([]state.ForkIDInterval)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, genBlockNumber)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,25 +74,23 @@ func (_m *ethermanMock) GetCurrentDataCommittee() (*etherman.DataCommittee, erro
 	return r0, r1
 }
 
-// GetForks provides a mock function with given fields: ctx, genBlockNumber
-func (_m *ethermanMock) GetForks(ctx context.Context, genBlockNumber uint64) ([]state.ForkIDInterval, error) {
-	ret := _m.Called(ctx, genBlockNumber)
+// GetFinalizedBlockNumber provides a mock function with given fields: ctx
+func (_m *ethermanMock) GetFinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []state.ForkIDInterval
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]state.ForkIDInterval, error)); ok {
-		return rf(ctx, genBlockNumber)
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) []state.ForkIDInterval); ok {
-		r0 = rf(ctx, genBlockNumber)
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]state.ForkIDInterval)
-		}
+		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = rf(ctx, genBlockNumber)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -183,6 +181,30 @@ func (_m *ethermanMock) GetRollupInfoByBlockRange(ctx context.Context, fromBlock
 	return r0, r1, r2
 }
 
+// GetSafeBlockNumber provides a mock function with given fields: ctx
+func (_m *ethermanMock) GetSafeBlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTrustedSequencerURL provides a mock function with given fields:
 func (_m *ethermanMock) GetTrustedSequencerURL() (string, error) {
 	ret := _m.Called()
@@ -233,6 +255,20 @@ func (_m *ethermanMock) HeaderByNumber(ctx context.Context, number *big.Int) (*t
 	return r0, r1
 }
 
+// LoadDataCommitteeAt provides a mock function with given fields: blockNumber
+func (_m *ethermanMock) LoadDataCommitteeAt(blockNumber uint64) error {
+	ret := _m.Called(blockNumber)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(blockNumber)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VerifyGenBlockNumber provides a mock function with given fields: ctx, genBlockNumber
 func (_m *ethermanMock) VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error) {
 	ret := _m.Called(ctx, genBlockNumber)
@@ -109,6 +109,20 @@ func (_m *stateMock) AddSequence(ctx context.Context, sequence state.Sequence, d
 	return r0
 }
 
+// AddTrustedDivergence provides a mock function with given fields: ctx, divergence, dbTx
+func (_m *stateMock) AddTrustedDivergence(ctx context.Context, divergence *state.TrustedDivergence, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, divergence, dbTx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *state.TrustedDivergence, pgx.Tx) error); ok {
+		r0 = rf(ctx, divergence, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddTrustedReorg provides a mock function with given fields: ctx, trustedReorg, dbTx
 func (_m *stateMock) AddTrustedReorg(ctx context.Context, trustedReorg *state.TrustedReorg, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, trustedReorg, dbTx)
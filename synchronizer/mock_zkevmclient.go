@@ -66,9 +66,30 @@ func (_m *zkEVMClientMock) BatchNumber(ctx context.Context) (uint64, error) {
 	return r0, r1
 }
 
+// BatchByNumberNoFullTxs provides a mock function with given fields: ctx, number
 func (_m *zkEVMClientMock) BatchByNumberNoFullTxs(ctx context.Context, number *big.Int) (*types.Batch, error) {
+	ret := _m.Called(ctx, number)
 
-	return nil, nil
+	var r0 *types.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) (*types.Batch, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) *types.Batch); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTnewZkEVMClientMock interface {
@@ -0,0 +1,229 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/halt"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+)
+
+var (
+	// errHaltRetried is returned by halt when the operator asks the synchronizer to retry, so the
+	// processing that halted is rolled back and done again
+	errHaltRetried = errors.New("synchronizer halt retried by the operator")
+	// ErrNotHalted is returned when retrying a synchronizer that is not halted
+	ErrNotHalted = errors.New("synchronizer is not halted")
+	// ErrForcedBatchCheckNotFailed is returned when skipping the forced batch check of a batch whose check didn't fail
+	ErrForcedBatchCheckNotFailed = errors.New("the forced batch check of the batch didn't fail")
+	// ErrForcedBatchCheckNotAcknowledged is returned when skipping a forced batch check without acknowledging it
+	ErrForcedBatchCheckNotAcknowledged = errors.New("skipping a forced batch check must be acknowledged, the batch is processed with the data sequenced on L1 instead of the forced batch stored")
+)
+
+// haltControl holds the halt state of the synchronizer and the recovery actions requested by
+// the operator through the admin API
+type haltControl struct {
+	*halt.Control
+	mu sync.Mutex
+	// rollbackToBlock is the L1 block the synchronizer rolls back to on its next iteration, nil if none
+	rollbackToBlock *uint64
+	// forcedBatchMismatch is the last batch whose forced batch check failed, 0 if none
+	forcedBatchMismatch       uint64
+	forcedBatchMismatchReason string
+	// skipForcedBatchCheck are the batches whose forced batch check is skipped the next time
+	skipForcedBatchCheck map[uint64]bool
+}
+
+func newHaltControl() *haltControl {
+	return &haltControl{
+		Control:              halt.NewControl(),
+		skipForcedBatchCheck: map[uint64]bool{},
+	}
+}
+
+// HaltStatus is the halt state of the synchronizer and the recovery actions pending
+type HaltStatus struct {
+	halt.Status
+	// PendingRollbackToBlock is the L1 block the synchronizer is going to roll back to
+	PendingRollbackToBlock *uint64 `json:"pendingRollbackToBlock,omitempty"`
+	// ForcedBatchMismatch is the last batch whose forced batch check failed
+	ForcedBatchMismatch       uint64 `json:"forcedBatchMismatch,omitempty"`
+	ForcedBatchMismatchReason string `json:"forcedBatchMismatchReason,omitempty"`
+	// SkippedForcedBatchChecks are the batches whose forced batch check is going to be skipped
+	SkippedForcedBatchChecks []uint64 `json:"skippedForcedBatchChecks"`
+}
+
+func (c *haltControl) status() HaltStatus {
+	status := HaltStatus{Status: c.Status()}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status.ForcedBatchMismatch = c.forcedBatchMismatch
+	status.ForcedBatchMismatchReason = c.forcedBatchMismatchReason
+	status.SkippedForcedBatchChecks = make([]uint64, 0, len(c.skipForcedBatchCheck))
+	if c.rollbackToBlock != nil {
+		blockNumber := *c.rollbackToBlock
+		status.PendingRollbackToBlock = &blockNumber
+	}
+	for batchNumber := range c.skipForcedBatchCheck {
+		status.SkippedForcedBatchChecks = append(status.SkippedForcedBatchChecks, batchNumber)
+	}
+	sort.Slice(status.SkippedForcedBatchChecks, func(i, j int) bool {
+		return status.SkippedForcedBatchChecks[i] < status.SkippedForcedBatchChecks[j]
+	})
+	return status
+}
+
+func (c *haltControl) setRollback(blockNumber uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rollbackToBlock = &blockNumber
+}
+
+// takeRollback returns the L1 block to roll back to, if any, removing the request
+func (c *haltControl) takeRollback() (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rollbackToBlock == nil {
+		return 0, false
+	}
+	blockNumber := *c.rollbackToBlock
+	c.rollbackToBlock = nil
+	return blockNumber, true
+}
+
+func (c *haltControl) setForcedBatchMismatch(batchNumber uint64, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.forcedBatchMismatch = batchNumber
+	c.forcedBatchMismatchReason = reason
+}
+
+// acknowledgeForcedBatchMismatch makes the next forced batch check of the batch be skipped
+func (c *haltControl) acknowledgeForcedBatchMismatch(batchNumber uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.forcedBatchMismatch == 0 || c.forcedBatchMismatch != batchNumber {
+		return ErrForcedBatchCheckNotFailed
+	}
+	c.skipForcedBatchCheck[batchNumber] = true
+	return nil
+}
+
+// consumeForcedBatchSkip returns true if the forced batch check of the batch must be skipped,
+// removing the skip so it only applies once
+func (c *haltControl) consumeForcedBatchSkip(batchNumber uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.skipForcedBatchCheck[batchNumber] {
+		return false
+	}
+	delete(c.skipForcedBatchCheck, batchNumber)
+	if c.forcedBatchMismatch == batchNumber {
+		c.forcedBatchMismatch = 0
+		c.forcedBatchMismatchReason = ""
+	}
+	return true
+}
+
+// HaltStatus returns the halt state of the synchronizer and the recovery actions pending
+func (s *ClientSynchronizer) HaltStatus() HaltStatus {
+	return s.halts.status()
+}
+
+// Retry makes the halted synchronizer roll back the processing that halted it and do it again
+func (s *ClientSynchronizer) Retry(ctx context.Context) error {
+	if err := s.halts.WakeUp(); errors.Is(err, halt.ErrNotHalted) {
+		return ErrNotHalted
+	} else if err != nil {
+		return err
+	}
+	s.logRecoveryEvent(ctx, event.EventID_SynchronizerHaltRetried, "synchronizer halt retried by the operator")
+	return nil
+}
+
+// RollbackToL1Block makes the synchronizer reset the state to the given L1 block on its next
+// iteration, the same way it does when a L1 reorg is detected. A halted synchronizer is retried
+func (s *ClientSynchronizer) RollbackToL1Block(ctx context.Context, blockNumber uint64) error {
+	if blockNumber < s.genesis.GenesisBlockNum {
+		return fmt.Errorf("can't roll back to block %d, before the genesis block %d", blockNumber, s.genesis.GenesisBlockNum)
+	}
+	lastBlock, err := s.state.GetLastBlock(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if blockNumber > lastBlock.BlockNumber {
+		return fmt.Errorf("can't roll back to block %d, after the last block synced %d", blockNumber, lastBlock.BlockNumber)
+	}
+	s.halts.setRollback(blockNumber)
+	// The rollback is applied on the next iteration, also when the synchronizer isn't halted
+	_ = s.halts.WakeUp()
+	s.logRecoveryEvent(ctx, event.EventID_SynchronizerRollbackRequested, fmt.Sprintf("synchronizer rollback to L1 block %d requested by the operator", blockNumber))
+	return nil
+}
+
+// SkipForcedBatchCheck skips once the failed forced batch check of the batch, processing it with the data
+// sequenced on L1. The operator must acknowledge it, as the forced batch stored doesn't match that data
+func (s *ClientSynchronizer) SkipForcedBatchCheck(ctx context.Context, batchNumber uint64, acknowledge bool) error {
+	if !acknowledge {
+		return ErrForcedBatchCheckNotAcknowledged
+	}
+	if err := s.halts.acknowledgeForcedBatchMismatch(batchNumber); err != nil {
+		return err
+	}
+	s.logRecoveryEvent(ctx, event.EventID_SynchronizerForcedBatchCheckSkipped, fmt.Sprintf("forced batch check of batch %d skipped by the operator", batchNumber))
+	return nil
+}
+
+// forcedBatchMismatch records the failed forced batch check of the batch, returning true if the operator
+// acknowledged skipping it
+func (s *ClientSynchronizer) forcedBatchMismatch(batchNumber uint64, reason string) bool {
+	if s.halts.consumeForcedBatchSkip(batchNumber) {
+		log.Warnf("forced batch check of batch %d skipped by the operator: %s", batchNumber, reason)
+		return true
+	}
+	s.halts.setForcedBatchMismatch(batchNumber, reason)
+	return false
+}
+
+// applyRollback resets the state to the L1 block requested by the operator, if any, returning
+// the last block synced after it
+func (s *ClientSynchronizer) applyRollback(lastEthBlockSynced *state.Block) (*state.Block, error) {
+	blockNumber, found := s.halts.takeRollback()
+	if !found {
+		return lastEthBlockSynced, nil
+	}
+	if err := s.resetState(blockNumber); err != nil {
+		// The rollback is done again on the next iteration
+		s.halts.setRollback(blockNumber)
+		return lastEthBlockSynced, err
+	}
+	s.trustedState.lastTrustedBatches = nil
+	s.trustedState.lastStateRoot = nil
+	s.halts.setForcedBatchMismatch(0, "")
+	s.logRecoveryEvent(s.ctx, event.EventID_SynchronizerRolledBack, fmt.Sprintf("synchronizer rolled back to L1 block %d", blockNumber))
+	return s.state.GetLastBlock(s.ctx, nil)
+}
+
+func (s *ClientSynchronizer) logRecoveryEvent(ctx context.Context, eventID event.EventID, description string) {
+	log.Warn(description)
+	if s.eventLog == nil {
+		return
+	}
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Synchronizer,
+		Level:       event.Level_Warning,
+		EventID:     eventID,
+		Description: description,
+	}
+	if err := s.eventLog.LogEvent(ctx, ev); err != nil {
+		log.Errorf("error storing synchronizer recovery event: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/event/nileventstorage"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHaltRetry(t *testing.T) {
+	ctx := context.Background()
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	sync := &ClientSynchronizer{ctx: ctx, eventLog: event.NewEventLog(event.Config{}, eventStorage), halts: newHaltControl()}
+
+	assert.ErrorIs(t, sync.Retry(ctx), ErrNotHalted)
+
+	haltErr := make(chan error)
+	go func() {
+		haltErr <- sync.halt(ctx, errors.New("state root mismatch"))
+	}()
+	require.Eventually(t, func() bool { return sync.HaltStatus().Halted }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "state root mismatch", sync.HaltStatus().Reason)
+
+	require.NoError(t, sync.Retry(ctx))
+	select {
+	case err := <-haltErr:
+		assert.ErrorIs(t, err, errHaltRetried)
+	case <-time.After(time.Second):
+		t.Fatal("the halted synchronizer was not retried")
+	}
+	assert.False(t, sync.HaltStatus().Halted)
+}
+
+func TestRollbackToL1Block(t *testing.T) {
+	ctx := context.Background()
+	st := newStateMock(t)
+	ethTxManager := newEthTxManagerMock(t)
+	dbTx := newDbTxMock(t)
+	sync := &ClientSynchronizer{
+		ctx:          ctx,
+		state:        st,
+		ethTxManager: ethTxManager,
+		genesis:      state.Genesis{GenesisBlockNum: 10},
+		halts:        newHaltControl(),
+	}
+	sync.trustedState.lastTrustedBatches = []*state.Batch{{BatchNumber: 3}}
+
+	st.On("GetLastBlock", ctx, nil).Return(&state.Block{BlockNumber: 100}, nil).Twice()
+	assert.ErrorContains(t, sync.RollbackToL1Block(ctx, 9), "before the genesis block 10")
+	assert.ErrorContains(t, sync.RollbackToL1Block(ctx, 101), "after the last block synced 100")
+	require.NoError(t, sync.RollbackToL1Block(ctx, 50))
+	require.NotNil(t, sync.HaltStatus().PendingRollbackToBlock)
+	assert.Equal(t, uint64(50), *sync.HaltStatus().PendingRollbackToBlock)
+
+	st.On("BeginStateTransaction", ctx).Return(dbTx, nil).Once()
+	st.On("Reset", ctx, uint64(50), dbTx).Return(nil).Once()
+	ethTxManager.On("Reorg", ctx, uint64(51), dbTx).Return(nil).Once()
+	dbTx.On("Commit", ctx).Return(nil).Once()
+	st.On("GetLastBlock", ctx, nil).Return(&state.Block{BlockNumber: 50}, nil).Once()
+
+	lastBlock, err := sync.applyRollback(&state.Block{BlockNumber: 100})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), lastBlock.BlockNumber)
+	assert.Nil(t, sync.trustedState.lastTrustedBatches)
+	assert.Nil(t, sync.HaltStatus().PendingRollbackToBlock)
+
+	// Without a rollback requested the last block synced is kept
+	lastBlock, err = sync.applyRollback(lastBlock)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), lastBlock.BlockNumber)
+}
+
+func TestSkipForcedBatchCheck(t *testing.T) {
+	ctx := context.Background()
+	sync := &ClientSynchronizer{ctx: ctx, halts: newHaltControl()}
+
+	assert.ErrorIs(t, sync.SkipForcedBatchCheck(ctx, 7, true), ErrForcedBatchCheckNotFailed)
+	assert.False(t, sync.forcedBatchMismatch(7, "forced batch txs mismatch"))
+	assert.Equal(t, uint64(7), sync.HaltStatus().ForcedBatchMismatch)
+
+	assert.ErrorIs(t, sync.SkipForcedBatchCheck(ctx, 7, false), ErrForcedBatchCheckNotAcknowledged)
+	assert.ErrorIs(t, sync.SkipForcedBatchCheck(ctx, 8, true), ErrForcedBatchCheckNotFailed)
+	require.NoError(t, sync.SkipForcedBatchCheck(ctx, 7, true))
+	assert.Equal(t, []uint64{7}, sync.HaltStatus().SkippedForcedBatchChecks)
+
+	// The skip only applies once
+	assert.True(t, sync.forcedBatchMismatch(7, "forced batch txs mismatch"))
+	assert.Equal(t, uint64(0), sync.HaltStatus().ForcedBatchMismatch)
+	assert.Empty(t, sync.HaltStatus().SkippedForcedBatchChecks)
+	assert.False(t, sync.forcedBatchMismatch(7, "forced batch txs mismatch"))
+}
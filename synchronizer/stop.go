@@ -0,0 +1,169 @@
+package synchronizer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/event"
+	"github.com/0xPolygon/cdk-validium-node/log"
+	"github.com/0xPolygon/cdk-validium-node/state"
+)
+
+const (
+	// BatchStateVirtual stops the synchronizer once the batch is virtualized
+	BatchStateVirtual = "virtual"
+	// BatchStateTrusted stops the synchronizer once the batch is closed in the trusted state
+	BatchStateTrusted = "trusted"
+	// BatchStateConsolidated stops the synchronizer once the batch is verified
+	BatchStateConsolidated = "consolidated"
+)
+
+func validateStopOnBatchState(batchState string) error {
+	switch batchState {
+	case "", BatchStateVirtual, BatchStateTrusted, BatchStateConsolidated:
+		return nil
+	default:
+		return fmt.Errorf("unknown StopOnBatchState %q, valid values are %q, %q and %q", batchState, BatchStateVirtual, BatchStateTrusted, BatchStateConsolidated)
+	}
+}
+
+// stopOnBatchState returns the state the stop batch must reach, virtual by default
+func (s *ClientSynchronizer) stopOnBatchState() string {
+	if s.cfg.StopOnBatchState == "" {
+		return BatchStateVirtual
+	}
+	return s.cfg.StopOnBatchState
+}
+
+// hasStopTarget returns true if the synchronizer is configured to stop at a batch or L1 block
+func (s *ClientSynchronizer) hasStopTarget() bool {
+	return s.cfg.StopOnBatchNum != 0 || s.cfg.StopOnL1Block != 0
+}
+
+// setStopReason sets the reason to stop the synchronizer, keeping the first one
+func (s *ClientSynchronizer) setStopReason(reason string) {
+	if s.stopReason == "" {
+		s.stopReason = reason
+	}
+}
+
+// checkStopBlock sets the stop reason if the processed L1 block reaches the stop L1 block, or
+// contains the event that makes the stop batch reach its configured state
+func (s *ClientSynchronizer) checkStopBlock(block etherman.Block) {
+	s.checkStopL1Block(block.BlockNumber)
+	if s.stopReason != "" || s.cfg.StopOnBatchNum == 0 {
+		return
+	}
+	var lastBatchNumber uint64
+	switch s.stopOnBatchState() {
+	case BatchStateVirtual, BatchStateTrusted:
+		// A virtual batch is also part of the trusted state
+		for _, sequences := range block.SequencedBatches {
+			for _, sequence := range sequences {
+				if sequence.BatchNumber > lastBatchNumber {
+					lastBatchNumber = sequence.BatchNumber
+				}
+			}
+		}
+		for _, sequences := range block.SequencedForceBatches {
+			for _, sequence := range sequences {
+				if sequence.BatchNumber > lastBatchNumber {
+					lastBatchNumber = sequence.BatchNumber
+				}
+			}
+		}
+	case BatchStateConsolidated:
+		for _, verified := range block.VerifiedBatches {
+			if verified.BatchNumber > lastBatchNumber {
+				lastBatchNumber = verified.BatchNumber
+			}
+		}
+	}
+	if lastBatchNumber >= s.cfg.StopOnBatchNum {
+		s.setStopReason(fmt.Sprintf("synchronizer reached stop batch %d in %s state, at L1 block %d",
+			s.cfg.StopOnBatchNum, s.stopOnBatchState(), block.BlockNumber))
+	}
+}
+
+// checkStopL1Block sets the stop reason once the blocks up to the stop L1 block have been synced,
+// as the last ones might have no rollup info
+func (s *ClientSynchronizer) checkStopL1Block(lastSyncedBlock uint64) {
+	if s.cfg.StopOnL1Block != 0 && lastSyncedBlock >= s.cfg.StopOnL1Block {
+		s.setStopReason(fmt.Sprintf("synchronizer reached stop L1 block %d", s.cfg.StopOnL1Block))
+	}
+}
+
+// checkStopTrustedBatch sets the stop reason if the trusted batch processed is the closed stop batch
+func (s *ClientSynchronizer) checkStopTrustedBatch(batchNumber uint64, closed bool) {
+	if s.cfg.StopOnBatchNum != 0 && s.stopOnBatchState() == BatchStateTrusted && batchNumber >= s.cfg.StopOnBatchNum && closed {
+		s.setStopReason(fmt.Sprintf("synchronizer reached stop batch %d in %s state", s.cfg.StopOnBatchNum, BatchStateTrusted))
+	}
+}
+
+// checkStopState sets the stop reason if the stored state already reached the stop target, like
+// when the synchronizer is restarted after stopping
+func (s *ClientSynchronizer) checkStopState(lastEthBlockSynced *state.Block) error {
+	if s.cfg.StopOnL1Block != 0 && lastEthBlockSynced.BlockNumber >= s.cfg.StopOnL1Block {
+		s.setStopReason(fmt.Sprintf("synchronizer reached stop L1 block %d", s.cfg.StopOnL1Block))
+		return nil
+	}
+	if s.cfg.StopOnBatchNum == 0 {
+		return nil
+	}
+	var lastBatchNumber uint64
+	switch s.stopOnBatchState() {
+	case BatchStateVirtual:
+		lastVirtualBatchNum, err := s.state.GetLastVirtualBatchNum(s.ctx, nil)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return err
+		}
+		lastBatchNumber = lastVirtualBatchNum
+	case BatchStateTrusted:
+		// The last batch can still be open, so the stop batch is only reached if a later batch exists
+		lastBatch, err := s.state.GetLastBatchNumber(s.ctx, nil)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return err
+		}
+		lastVirtualBatchNum, err := s.state.GetLastVirtualBatchNum(s.ctx, nil)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return err
+		}
+		if lastBatch > s.cfg.StopOnBatchNum || lastVirtualBatchNum >= s.cfg.StopOnBatchNum {
+			lastBatchNumber = s.cfg.StopOnBatchNum
+		}
+	case BatchStateConsolidated:
+		lastVerifiedBatch, err := s.state.GetLastVerifiedBatch(s.ctx, nil)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return err
+		}
+		if lastVerifiedBatch != nil {
+			lastBatchNumber = lastVerifiedBatch.BatchNumber
+		}
+	}
+	if lastBatchNumber >= s.cfg.StopOnBatchNum {
+		s.setStopReason(fmt.Sprintf("synchronizer reached stop batch %d in %s state", s.cfg.StopOnBatchNum, s.stopOnBatchState()))
+	}
+	return nil
+}
+
+// stop logs the event of the synchronizer reaching its stop target. The state is not
+// modified by the synchronizer anymore, so the other components serve it frozen
+func (s *ClientSynchronizer) stop() {
+	log.Infof("stopping the synchronizer: %s", s.stopReason)
+	if s.eventLog == nil {
+		return
+	}
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Synchronizer,
+		Level:       event.Level_Notice,
+		EventID:     event.EventID_SynchronizerStopped,
+		Description: s.stopReason,
+	}
+	if err := s.eventLog.LogEvent(s.ctx, ev); err != nil {
+		log.Errorf("error storing Synchronizer stop event: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package synchronizer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/cdk-validium-node/etherman"
+	"github.com/0xPolygon/cdk-validium-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStopBlock(t *testing.T) {
+	block := etherman.Block{
+		BlockNumber:      150,
+		SequencedBatches: [][]etherman.SequencedBatch{{{BatchNumber: 9}, {BatchNumber: 10}}},
+		VerifiedBatches:  []etherman.VerifiedBatch{{BatchNumber: 7}},
+	}
+	testCases := []struct {
+		name       string
+		cfg        Config
+		stopReason string
+	}{
+		{
+			name:       "stop batch virtualized",
+			cfg:        Config{StopOnBatchNum: 10},
+			stopReason: "synchronizer reached stop batch 10 in virtual state, at L1 block 150",
+		},
+		{
+			name: "stop batch not virtualized yet",
+			cfg:  Config{StopOnBatchNum: 11, StopOnBatchState: BatchStateVirtual},
+		},
+		{
+			name:       "stop batch consolidated",
+			cfg:        Config{StopOnBatchNum: 7, StopOnBatchState: BatchStateConsolidated},
+			stopReason: "synchronizer reached stop batch 7 in consolidated state, at L1 block 150",
+		},
+		{
+			name: "stop batch virtualized but not consolidated",
+			cfg:  Config{StopOnBatchNum: 9, StopOnBatchState: BatchStateConsolidated},
+		},
+		{
+			name:       "stop L1 block reached",
+			cfg:        Config{StopOnL1Block: 150, StopOnBatchNum: 20},
+			stopReason: "synchronizer reached stop L1 block 150",
+		},
+		{
+			name: "no stop target",
+			cfg:  Config{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sync := &ClientSynchronizer{cfg: tc.cfg}
+			sync.checkStopBlock(block)
+			assert.Equal(t, tc.stopReason, sync.stopReason)
+		})
+	}
+}
+
+func TestCheckStopTrustedBatch(t *testing.T) {
+	sync := &ClientSynchronizer{cfg: Config{StopOnBatchNum: 5, StopOnBatchState: BatchStateTrusted}}
+	sync.checkStopTrustedBatch(5, false)
+	assert.Empty(t, sync.stopReason)
+	sync.checkStopTrustedBatch(5, true)
+	assert.Equal(t, "synchronizer reached stop batch 5 in trusted state", sync.stopReason)
+
+	sync = &ClientSynchronizer{cfg: Config{StopOnBatchNum: 5, StopOnBatchState: BatchStateVirtual}}
+	sync.checkStopTrustedBatch(5, true)
+	assert.Empty(t, sync.stopReason)
+}
+
+func TestCheckStopState(t *testing.T) {
+	ctx := context.Background()
+	lastEthBlockSynced := &state.Block{BlockNumber: 100}
+
+	t.Run("stop L1 block already synced", func(t *testing.T) {
+		sync := &ClientSynchronizer{ctx: ctx, cfg: Config{StopOnL1Block: 90}}
+		require.NoError(t, sync.checkStopState(lastEthBlockSynced))
+		assert.Equal(t, "synchronizer reached stop L1 block 90", sync.stopReason)
+	})
+
+	t.Run("stop batch already virtualized", func(t *testing.T) {
+		st := newStateMock(t)
+		sync := &ClientSynchronizer{state: st, ctx: ctx, cfg: Config{StopOnBatchNum: 10, StopOnBatchState: BatchStateVirtual}}
+		st.On("GetLastVirtualBatchNum", ctx, nil).Return(uint64(12), nil).Once()
+		require.NoError(t, sync.checkStopState(lastEthBlockSynced))
+		assert.Equal(t, "synchronizer reached stop batch 10 in virtual state", sync.stopReason)
+	})
+
+	t.Run("stop batch still open in the trusted state", func(t *testing.T) {
+		st := newStateMock(t)
+		sync := &ClientSynchronizer{state: st, ctx: ctx, cfg: Config{StopOnBatchNum: 10, StopOnBatchState: BatchStateTrusted}}
+		st.On("GetLastBatchNumber", ctx, nil).Return(uint64(10), nil).Once()
+		st.On("GetLastVirtualBatchNum", ctx, nil).Return(uint64(8), nil).Once()
+		require.NoError(t, sync.checkStopState(lastEthBlockSynced))
+		assert.Empty(t, sync.stopReason)
+	})
+
+	t.Run("stop batch not consolidated yet", func(t *testing.T) {
+		st := newStateMock(t)
+		sync := &ClientSynchronizer{state: st, ctx: ctx, cfg: Config{StopOnBatchNum: 10, StopOnBatchState: BatchStateConsolidated}}
+		st.On("GetLastVerifiedBatch", ctx, nil).Return(nil, state.ErrNotFound).Once()
+		require.NoError(t, sync.checkStopState(lastEthBlockSynced))
+		assert.Empty(t, sync.stopReason)
+	})
+}
+
+func TestSyncBlocksStopOnL1Block(t *testing.T) {
+	ctx := context.Background()
+	ethBlock100 := ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: big.NewInt(100)})
+	lastEthBlockSynced := &state.Block{BlockNumber: 100, BlockHash: ethBlock100.Hash(), ParentHash: ethBlock100.ParentHash()}
+
+	m := newEthermanMock(t)
+	sync := &ClientSynchronizer{
+		etherMan: m,
+		ctx:      ctx,
+		cfg:      Config{SyncChunkSize: 100, StopOnL1Block: 120},
+	}
+	m.On("EthBlockByNumber", ctx, uint64(100)).Return(ethBlock100, nil).Once()
+	m.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(&ethTypes.Header{Number: big.NewInt(300)}, nil).Once()
+	stopBlock := uint64(120)
+	m.On("GetRollupInfoByBlockRange", ctx, uint64(101), &stopBlock).Return([]etherman.Block{}, map[common.Hash][]etherman.Order{}, nil).Once()
+
+	block, err := sync.syncBlocks(lastEthBlockSynced)
+	require.NoError(t, err)
+	assert.Equal(t, lastEthBlockSynced, block)
+	assert.Equal(t, "synchronizer reached stop L1 block 120", sync.stopReason)
+}
+
+func TestValidateStopOnBatchState(t *testing.T) {
+	for _, batchState := range []string{"", BatchStateVirtual, BatchStateTrusted, BatchStateConsolidated} {
+		assert.NoError(t, validateStopOnBatchState(batchState))
+	}
+	assert.EqualError(t, validateStopOnBatchState("closed"), `unknown StopOnBatchState "closed", valid values are "virtual", "trusted" and "consolidated"`)
+}
@@ -9,10 +9,11 @@ import (
 	"strings"
 	"time"
 
-	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygon/cdk-validium-node/dataavailability"
 	"github.com/0xPolygon/cdk-validium-node/etherman"
 	"github.com/0xPolygon/cdk-validium-node/event"
 	"github.com/0xPolygon/cdk-validium-node/hex"
+	"github.com/0xPolygon/cdk-validium-node/jsonrpc"
 	"github.com/0xPolygon/cdk-validium-node/jsonrpc/types"
 	"github.com/0xPolygon/cdk-validium-node/log"
 	"github.com/0xPolygon/cdk-validium-node/state"
@@ -31,6 +32,7 @@ const (
 type Synchronizer interface {
 	Sync() error
 	Stop()
+	Services() []jsonrpc.Service
 }
 
 // ClientSynchronizer connects L1 and L2
@@ -58,11 +60,18 @@ type ClientSynchronizer struct {
 	// It starts as an empty string and it is filled in the first call
 	// later the value is checked to be the same (in function checkFlushID)
 	proverID string
+	// tentative tracks the rollup info found in the L1 blocks not processed yet because of their finality
+	tentative tentativeTracker
+	// syncedToL1Target is true when the last L1 sync reached the safe or finalized block
+	syncedToL1Target bool
+	// stopReason is set when the synchronizer reaches its stop batch or L1 block
+	stopReason string
+	// halts holds the halt state and the recovery actions requested by the operator
+	halts *haltControl
 	// Previous value returned by state.GetStoredFlushID, is used for decide if write a log or not
-	previousExecutorFlushID    uint64
-	committeeMembers           []etherman.DataCommitteeMember
-	selectedCommitteeMember    int
-	dataCommitteeClientFactory client.ClientFactoryInterface
+	previousExecutorFlushID uint64
+	// daBackend is where the data of the batches is retrieved from when it's not found locally
+	daBackend dataavailability.BatchDataGetter
 }
 
 // NewSynchronizer creates and initializes an instance of Synchronizer
@@ -76,29 +85,35 @@ func NewSynchronizer(
 	eventLog *event.EventLog,
 	genesis state.Genesis,
 	cfg Config,
-	clientFactory client.ClientFactoryInterface,
+	daBackend dataavailability.BatchDataGetter,
 ) (Synchronizer, error) {
+	if err := validateL1SyncBlock(cfg.L1SyncBlock); err != nil {
+		return nil, err
+	}
+	if err := validateStopOnBatchState(cfg.StopOnBatchState); err != nil {
+		return nil, err
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	metrics.Register()
 
 	c := &ClientSynchronizer{
-		isTrustedSequencer:         isTrustedSequencer,
-		state:                      st,
-		etherMan:                   ethMan,
-		pool:                       pool,
-		ctx:                        ctx,
-		cancelCtx:                  cancel,
-		ethTxManager:               ethTxManager,
-		zkEVMClient:                zkEVMClient,
-		eventLog:                   eventLog,
-		genesis:                    genesis,
-		cfg:                        cfg,
-		proverID:                   "",
-		previousExecutorFlushID:    0,
-		dataCommitteeClientFactory: clientFactory,
-	}
-	err := c.loadCommittee()
-	return c, err
+		isTrustedSequencer:      isTrustedSequencer,
+		state:                   st,
+		etherMan:                ethMan,
+		pool:                    pool,
+		ctx:                     ctx,
+		cancelCtx:               cancel,
+		ethTxManager:            ethTxManager,
+		zkEVMClient:             zkEVMClient,
+		eventLog:                eventLog,
+		genesis:                 genesis,
+		cfg:                     cfg,
+		proverID:                "",
+		previousExecutorFlushID: 0,
+		daBackend:               daBackend,
+		halts:                   newHaltControl(),
+	}
+	return c, nil
 }
 
 var waitDuration = time.Duration(0)
@@ -231,6 +246,21 @@ func (s *ClientSynchronizer) Sync() error {
 		case <-s.ctx.Done():
 			return nil
 		case <-time.After(waitDuration):
+			lastEthBlockSynced, err = s.applyRollback(lastEthBlockSynced)
+			if err != nil {
+				log.Warn("error rolling back the synchronizer to the L1 block requested. Error: ", err)
+				continue
+			}
+			if s.hasStopTarget() {
+				if err := s.checkStopState(lastEthBlockSynced); err != nil {
+					log.Warn("error checking if the synchronizer reached its stop target. Error: ", err)
+					continue
+				}
+				if s.stopReason != "" {
+					s.stop()
+					return nil
+				}
+			}
 			start := time.Now()
 			latestSequencedBatchNumber, err := s.etherMan.GetLatestBatchNumber()
 			if err != nil {
@@ -257,7 +287,9 @@ func (s *ClientSynchronizer) Sync() error {
 
 			if !s.cfg.SyncOnlyTrusted {
 				// Sync trusted state
-				if latestSyncedBatch >= latestSequencedBatchNumber {
+				// When only safe or finalized blocks are processed, the virtual batches are behind the ones
+				// sequenced on L1, so the trusted state is synced once the L1 sync reaches its target
+				if latestSyncedBatch >= latestSequencedBatchNumber || (s.isFinalityAware() && s.syncedToL1Target) {
 					startTrusted := time.Now()
 					log.Info("Syncing trusted state")
 					err = s.syncTrustedState(latestSyncedBatch)
@@ -266,6 +298,10 @@ func (s *ClientSynchronizer) Sync() error {
 						log.Warn("error syncing trusted state. Error: ", err)
 						continue
 					}
+					if s.stopReason != "" {
+						s.stop()
+						return nil
+					}
 					waitDuration = s.cfg.SyncInterval.Duration
 				}
 				//Sync L1Blocks
@@ -282,6 +318,10 @@ func (s *ClientSynchronizer) Sync() error {
 						continue
 					}
 				}
+				if s.stopReason != "" {
+					s.stop()
+					return nil
+				}
 			} else {
 				// Sync trusted state
 				startTrusted := time.Now()
@@ -292,6 +332,10 @@ func (s *ClientSynchronizer) Sync() error {
 					log.Warn("error syncing trusted state. Error: ", err)
 					continue
 				}
+				if s.stopReason != "" {
+					s.stop()
+					return nil
+				}
 				waitDuration = s.cfg.SyncInterval.Duration
 			}
 			metrics.FullSyncIterationTime(time.Since(start))
@@ -323,14 +367,52 @@ func (s *ClientSynchronizer) syncBlocks(lastEthBlockSynced *state.Block) (*state
 		return lastEthBlockSynced, err
 	}
 	lastKnownBlock := header.Number
+	latestBlock := header.Number.Uint64()
 
 	var fromBlock uint64
 	if lastEthBlockSynced.BlockNumber > 0 {
 		fromBlock = lastEthBlockSynced.BlockNumber + 1
 	}
 
+	s.syncedToL1Target = false
+	if s.isFinalityAware() {
+		// Only the blocks up to the safe or finalized one are processed
+		syncTarget, err := s.getL1SyncTarget(latestBlock)
+		if err != nil {
+			return lastEthBlockSynced, err
+		}
+		if fromBlock > syncTarget {
+			log.Infof("Waiting for the %s block to reach block %d. Current %s block: %d", s.cfg.L1SyncBlock, fromBlock, s.cfg.L1SyncBlock, syncTarget)
+			s.syncTentativeState(syncTarget, header)
+			s.syncedToL1Target = true
+			waitDuration = s.cfg.SyncInterval.Duration
+			return lastEthBlockSynced, nil
+		}
+		lastKnownBlock = new(big.Int).SetUint64(syncTarget)
+	}
+	clampToLastKnownBlock := s.isFinalityAware()
+	if s.cfg.StopOnL1Block != 0 && lastKnownBlock.Uint64() >= s.cfg.StopOnL1Block {
+		// The blocks after the stop L1 block are not processed
+		lastKnownBlock = new(big.Int).SetUint64(s.cfg.StopOnL1Block)
+		clampToLastKnownBlock = true
+	}
+
+	if s.cfg.L1ParallelWorkers > 1 {
+		lastEthBlockSynced, err = s.syncBlocksParallel(lastEthBlockSynced, fromBlock, lastKnownBlock.Uint64())
+		if err != nil || s.stopReason != "" {
+			return lastEthBlockSynced, err
+		}
+		s.checkStopL1Block(lastKnownBlock.Uint64())
+		s.syncTentativeState(lastKnownBlock.Uint64(), header)
+		s.syncedToL1Target = true
+		return lastEthBlockSynced, nil
+	}
+
 	for {
 		toBlock := fromBlock + s.cfg.SyncChunkSize
+		if clampToLastKnownBlock && toBlock > lastKnownBlock.Uint64() {
+			toBlock = lastKnownBlock.Uint64()
+		}
 		log.Infof("Syncing block %d of %d", fromBlock, lastKnownBlock.Uint64())
 		log.Infof("Getting rollup info from block %d to block %d", fromBlock, toBlock)
 		// This function returns the rollup information contained in the ethereum blocks and an extra param called order.
@@ -361,6 +443,9 @@ func (s *ClientSynchronizer) syncBlocks(lastEthBlockSynced *state.Block) (*state
 				log.Debug("Position: ", i, ". BlockNumber: ", blocks[i].BlockNumber, ". BlockHash: ", blocks[i].BlockHash)
 			}
 		}
+		if s.stopReason != "" {
+			return lastEthBlockSynced, nil
+		}
 		fromBlock = toBlock + 1
 
 		if lastKnownBlock.Cmp(new(big.Int).SetUint64(toBlock)) < 1 {
@@ -373,30 +458,113 @@ func (s *ClientSynchronizer) syncBlocks(lastEthBlockSynced *state.Block) (*state
 			if err != nil {
 				return lastEthBlockSynced, err
 			}
-			b := etherman.Block{
-				BlockNumber: fb.NumberU64(),
-				BlockHash:   fb.Hash(),
-				ParentHash:  fb.ParentHash(),
-				ReceivedAt:  time.Unix(int64(fb.Time()), 0),
-			}
-			err = s.processBlockRange([]etherman.Block{b}, order)
+			block, err := s.processEmptyBlock(fb, order)
 			if err != nil {
 				return lastEthBlockSynced, err
 			}
-			block := state.Block{
-				BlockNumber: fb.NumberU64(),
-				BlockHash:   fb.Hash(),
-				ParentHash:  fb.ParentHash(),
-				ReceivedAt:  time.Unix(int64(fb.Time()), 0),
-			}
-			lastEthBlockSynced = &block
-			log.Debug("Storing empty block. BlockNumber: ", b.BlockNumber, ". BlockHash: ", b.BlockHash)
+			lastEthBlockSynced = block
 		}
 	}
 
+	s.checkStopL1Block(lastKnownBlock.Uint64())
+	s.syncTentativeState(lastKnownBlock.Uint64(), header)
+	s.syncedToL1Target = true
 	return lastEthBlockSynced, nil
 }
 
+// syncBlocksParallel syncs the blocks from fromBlock to lastKnownBlock like syncBlocks, but the rollup
+// info of the upcoming ranges is fetched by several workers while the current range is processed.
+// Before processing a prefetched range, the last synced block is checked against L1, so the
+// prefetched ranges are discarded if a reorg is found
+func (s *ClientSynchronizer) syncBlocksParallel(lastEthBlockSynced *state.Block, fromBlock, lastKnownBlock uint64) (*state.Block, error) {
+	prefetcher := newRollupInfoPrefetcher(s.ctx, s.etherMan, fromBlock, lastKnownBlock, s.cfg.SyncChunkSize, s.cfg.L1ParallelWorkers, s.cfg.L1MaxPrefetchedRanges)
+	defer prefetcher.stop()
+
+	firstRange := true
+	for {
+		r, ok := prefetcher.next()
+		if !ok {
+			return lastEthBlockSynced, s.ctx.Err()
+		}
+		if r.err != nil {
+			return lastEthBlockSynced, r.err
+		}
+		if !firstRange {
+			block, err := s.checkReorg(lastEthBlockSynced)
+			if err != nil {
+				log.Errorf("error checking reorgs before processing the prefetched rollup info. Retrying... Err: %v", err)
+				return lastEthBlockSynced, fmt.Errorf("error checking reorgs")
+			}
+			if block != nil {
+				log.Infof("discarding the prefetched rollup info from block %d, because of a reorg", r.fromBlock)
+				prefetcher.stop()
+				err = s.resetState(block.BlockNumber)
+				if err != nil {
+					log.Errorf("error resetting the state to a previous block. Retrying... Err: %v", err)
+					return lastEthBlockSynced, fmt.Errorf("error resetting the state to a previous block")
+				}
+				return block, nil
+			}
+		}
+		firstRange = false
+
+		log.Infof("Syncing block %d of %d", r.fromBlock, lastKnownBlock)
+		log.Infof("Processing rollup info from block %d to block %d", r.fromBlock, r.toBlock)
+		start := time.Now()
+		err := s.processBlockRange(r.blocks, r.order)
+		metrics.ProcessL1DataTime(time.Since(start))
+		if err != nil {
+			return lastEthBlockSynced, err
+		}
+		if len(r.blocks) > 0 {
+			lastBlock := r.blocks[len(r.blocks)-1]
+			lastEthBlockSynced = &state.Block{
+				BlockNumber: lastBlock.BlockNumber,
+				BlockHash:   lastBlock.BlockHash,
+				ParentHash:  lastBlock.ParentHash,
+				ReceivedAt:  lastBlock.ReceivedAt,
+			}
+		}
+		if s.stopReason != "" {
+			return lastEthBlockSynced, nil
+		}
+
+		if r.toBlock >= lastKnownBlock {
+			waitDuration = s.cfg.SyncInterval.Duration
+			return lastEthBlockSynced, nil
+		}
+		if r.emptyBlock != nil {
+			block, err := s.processEmptyBlock(r.emptyBlock, r.order)
+			if err != nil {
+				return lastEthBlockSynced, err
+			}
+			lastEthBlockSynced = block
+		}
+	}
+}
+
+// processEmptyBlock stores the last block of a range without rollup info, so the sync resumes after it
+func (s *ClientSynchronizer) processEmptyBlock(fb *ethTypes.Block, order map[common.Hash][]etherman.Order) (*state.Block, error) {
+	b := etherman.Block{
+		BlockNumber: fb.NumberU64(),
+		BlockHash:   fb.Hash(),
+		ParentHash:  fb.ParentHash(),
+		ReceivedAt:  time.Unix(int64(fb.Time()), 0),
+	}
+	err := s.processBlockRange([]etherman.Block{b}, order)
+	if err != nil {
+		return nil, err
+	}
+	block := state.Block{
+		BlockNumber: fb.NumberU64(),
+		BlockHash:   fb.Hash(),
+		ParentHash:  fb.ParentHash(),
+		ReceivedAt:  time.Unix(int64(fb.Time()), 0),
+	}
+	log.Debug("Storing empty block. BlockNumber: ", b.BlockNumber, ". BlockHash: ", b.BlockHash)
+	return &block, nil
+}
+
 // syncTrustedState synchronizes information from the trusted sequencer
 // related to the trusted state when the node has all the information from
 // l1 synchronized
@@ -414,6 +582,11 @@ func (s *ClientSynchronizer) syncTrustedState(latestSyncedBatch uint64) error {
 		return err
 	}
 
+	if s.cfg.StopOnBatchNum != 0 && lastTrustedStateBatchNumber > s.cfg.StopOnBatchNum {
+		// The trusted batches after the stop batch are not processed
+		lastTrustedStateBatchNumber = s.cfg.StopOnBatchNum
+	}
+
 	log.Debug("lastTrustedStateBatchNumber ", lastTrustedStateBatchNumber)
 	log.Debug("latestSyncedBatch ", latestSyncedBatch)
 	if lastTrustedStateBatchNumber < latestSyncedBatch {
@@ -469,6 +642,10 @@ func (s *ClientSynchronizer) syncTrustedState(latestSyncedBatch uint64) error {
 		}
 		s.trustedState.lastTrustedBatches = cbatches
 		s.trustedState.lastStateRoot = lastStateRoot
+		s.checkStopTrustedBatch(batchNumberToSync, batchToSync.Closed)
+		if s.stopReason != "" {
+			return nil
+		}
 		batchNumberToSync++
 	}
 
@@ -534,6 +711,8 @@ func (s *ClientSynchronizer) processBlockRange(blocks []etherman.Block, order ma
 				if err != nil {
 					return err
 				}
+			case etherman.CommitteeUpdatedOrder:
+				s.processCommitteeUpdate(blocks[i].CommitteeUpdates[element.Pos])
 			}
 		}
 		log.Debug("Checking FlushID to commit L1 data to db")
@@ -557,6 +736,12 @@ func (s *ClientSynchronizer) processBlockRange(blocks []etherman.Block, order ma
 			}
 			return err
 		}
+		if s.hasStopTarget() {
+			s.checkStopBlock(blocks[i])
+			if s.stopReason != "" {
+				return nil
+			}
+		}
 	}
 	return nil
 }
@@ -677,7 +862,7 @@ func (s *ClientSynchronizer) Stop() {
 	s.cancelCtx()
 }
 
-func (s *ClientSynchronizer) checkTrustedState(batch state.Batch, tBatch *state.Batch, newRoot common.Hash, dbTx pgx.Tx) bool {
+func (s *ClientSynchronizer) checkTrustedState(batch state.Batch, tBatch *state.Batch, newRoot common.Hash, dbTx pgx.Tx) (bool, error) {
 	//Compare virtual state with trusted state
 	var reorgReasons strings.Builder
 	if newRoot != tBatch.StateRoot {
@@ -704,9 +889,14 @@ func (s *ClientSynchronizer) checkTrustedState(batch state.Batch, tBatch *state.
 	if reorgReasons.Len() > 0 {
 		reason := reorgReasons.String()
 		log.Warnf("Missmatch in trusted state detected for Batch Number: %d. Reasons: %s", tBatch.BatchNumber, reason)
+		divergence := newL1Divergence(batch, tBatch, newRoot, reason)
 		if s.isTrustedSequencer {
-			s.halt(s.ctx, fmt.Errorf("TRUSTED REORG DETECTED! Batch: %d", batch.BatchNumber))
+			divergence.Halted = true
+			s.storeTrustedDivergence(divergence, nil)
+			return false, s.halt(s.ctx, fmt.Errorf("TRUSTED REORG DETECTED! Batch: %d", batch.BatchNumber))
 		}
+		s.addReorgedTxs(divergence, dbTx)
+		s.storeTrustedDivergence(divergence, dbTx)
 		// Store trusted reorg register
 		tr := state.TrustedReorg{
 			BatchNumber: tBatch.BatchNumber,
@@ -716,9 +906,9 @@ func (s *ClientSynchronizer) checkTrustedState(batch state.Batch, tBatch *state.
 		if err != nil {
 			log.Error("error storing tursted reorg register into the db. Error: ", err)
 		}
-		return true
+		return true, nil
 	}
-	return false
+	return false, nil
 }
 
 func (s *ClientSynchronizer) processForkID(forkID etherman.ForkID, blockNumber uint64, dbTx pgx.Tx) error {
@@ -860,9 +1050,10 @@ func (s *ClientSynchronizer) processSequenceBatches(sequencedBatches []etherman.
 				}
 				return fmt.Errorf("error: empty forcedBatches array read from db. BatchNumber: %d", sbatch.BatchNumber)
 			}
-			if uint64(forcedBatches[0].ForcedAt.Unix()) != sbatch.MinForcedTimestamp ||
+			if (uint64(forcedBatches[0].ForcedAt.Unix()) != sbatch.MinForcedTimestamp ||
 				forcedBatches[0].GlobalExitRoot != sbatch.GlobalExitRoot ||
-				common.Bytes2Hex(forcedBatches[0].RawTxsData) != common.Bytes2Hex(batchL2Data) {
+				common.Bytes2Hex(forcedBatches[0].RawTxsData) != common.Bytes2Hex(batchL2Data)) &&
+				!s.forcedBatchMismatch(sbatch.BatchNumber, fmt.Sprintf("forcedBatch received doesn't match with the next expected forcedBatch stored in db. Expected: %+v, Synced: %+v", forcedBatches, sbatch)) {
 				log.Warnf("ForcedBatch stored: %+v. RawTxsData: %s", forcedBatches, common.Bytes2Hex(forcedBatches[0].RawTxsData))
 				log.Warnf("ForcedBatch sequenced received: %+v. RawTxsData: %s", sbatch, common.Bytes2Hex(batchL2Data))
 				log.Errorf("error: forcedBatch received doesn't match with the next expected forcedBatch stored in db. Expected: %+v, Synced: %+v", forcedBatches, sbatch)
@@ -948,7 +1139,15 @@ func (s *ClientSynchronizer) processSequenceBatches(sequencedBatches []etherman.
 		}
 
 		// Call the check trusted state method to compare trusted and virtual state
-		status := s.checkTrustedState(batch, tBatch, newRoot, dbTx)
+		status, err := s.checkTrustedState(batch, tBatch, newRoot, dbTx)
+		if err != nil {
+			rollbackErr := dbTx.Rollback(s.ctx)
+			if rollbackErr != nil {
+				log.Errorf("error rolling back state. BatchNumber: %d, BlockNumber: %d, rollbackErr: %s, error : %v", tBatch.BatchNumber, blockNumber, rollbackErr.Error(), err)
+				return rollbackErr
+			}
+			return err
+		}
 		if status {
 			// Reorg Pool
 			err := s.reorgPool(dbTx)
@@ -1073,9 +1272,14 @@ func (s *ClientSynchronizer) processSequenceForceBatch(sequenceForceBatch []ethe
 		return fmt.Errorf("error number of forced batches doesn't match")
 	}
 	for i, fbatch := range sequenceForceBatch {
-		if uint64(forcedBatches[i].ForcedAt.Unix()) != fbatch.MinForcedTimestamp ||
+		rawTxsData := forcedBatches[i].RawTxsData
+		mismatch := uint64(forcedBatches[i].ForcedAt.Unix()) != fbatch.MinForcedTimestamp ||
 			forcedBatches[i].GlobalExitRoot != fbatch.GlobalExitRoot ||
-			common.Bytes2Hex(forcedBatches[i].RawTxsData) != common.Bytes2Hex(fbatch.Transactions) {
+			common.Bytes2Hex(forcedBatches[i].RawTxsData) != common.Bytes2Hex(fbatch.Transactions)
+		if mismatch && s.forcedBatchMismatch(fbatch.BatchNumber, fmt.Sprintf("forcedBatch received doesn't match with the next expected forcedBatch stored in db. Expected: %+v, Synced: %+v", forcedBatches[i], fbatch)) {
+			// The operator acknowledged processing the batch with the data sequenced on L1
+			rawTxsData = fbatch.Transactions
+		} else if mismatch {
 			log.Warnf("ForcedBatch stored: %+v", forcedBatches)
 			log.Warnf("ForcedBatch sequenced received: %+v", fbatch)
 			log.Errorf("error: forcedBatch received doesn't match with the next expected forcedBatch stored in db. Expected: %+v, Synced: %+v", forcedBatches[i], fbatch)
@@ -1101,7 +1305,7 @@ func (s *ClientSynchronizer) processSequenceForceBatch(sequenceForceBatch []ethe
 			ForcedBatchNum: &forcedBatches[i].ForcedBatchNumber,
 		}
 		// Process batch
-		_, flushID, proverID, err := s.state.ProcessAndStoreClosedBatch(s.ctx, batch, forcedBatches[i].RawTxsData, dbTx, stateMetrics.SynchronizerCallerLabel)
+		_, flushID, proverID, err := s.state.ProcessAndStoreClosedBatch(s.ctx, batch, rawTxsData, dbTx, stateMetrics.SynchronizerCallerLabel)
 		if err != nil {
 			log.Errorf("error processing batch in processSequenceForceBatch. BatchNumber: %d, BlockNumber: %d, error: %v", batch.BatchNumber, block.BlockNumber, err)
 			rollbackErr := dbTx.Rollback(s.ctx)
@@ -1347,7 +1551,7 @@ func (s *ClientSynchronizer) processTrustedBatch(trustedBatch *types.Batch, dbTx
 				if isBatchClosed {
 					//Sanity check
 					if s.trustedState.lastStateRoot != nil && trustedBatch.StateRoot != *s.trustedState.lastStateRoot {
-						s.halt(s.ctx, fmt.Errorf("stateRoot calculated (%s) is different from the stateRoot (%s) received during the trustedState synchronization", *s.trustedState.lastStateRoot, trustedBatch.StateRoot))
+						return nil, nil, s.haltOnTrustedStateRoot(trustedBatch, *s.trustedState.lastStateRoot)
 					}
 					receipt := state.ProcessingReceipt{
 						BatchNumber:   uint64(trustedBatch.Number),
@@ -1428,7 +1632,7 @@ func (s *ClientSynchronizer) processTrustedBatch(trustedBatch *types.Batch, dbTx
 	if isBatchClosed {
 		//Sanity check
 		if trustedBatch.StateRoot != processBatchResp.NewStateRoot {
-			s.halt(s.ctx, fmt.Errorf("stateRoot calculated (%s) is different from the stateRoot (%s) received during the trustedState synchronization", processBatchResp.NewStateRoot, trustedBatch.StateRoot))
+			return nil, nil, s.haltOnTrustedStateRoot(trustedBatch, processBatchResp.NewStateRoot)
 		}
 		receipt := state.ProcessingReceipt{
 			BatchNumber:   uint64(trustedBatch.Number),
@@ -1681,8 +1885,9 @@ func (s *ClientSynchronizer) checkFlushID(dbTx pgx.Tx) error {
 	return nil
 }
 
-// halt halts the Synchronizer
-func (s *ClientSynchronizer) halt(ctx context.Context, err error) {
+// halt halts the Synchronizer until the operator retries it or rolls it back through the admin API. The
+// returned error must be propagated, so the processing that halted is rolled back and done again
+func (s *ClientSynchronizer) halt(ctx context.Context, err error) error {
 	event := &event.Event{
 		ReceivedAt:  time.Now(),
 		Source:      event.Source_Node,
@@ -1697,9 +1902,18 @@ func (s *ClientSynchronizer) halt(ctx context.Context, err error) {
 		log.Errorf("error storing Synchronizer halt event: %v", eventErr)
 	}
 
+	s.halts.SetHalted(err.Error(), true)
+	defer s.halts.ClearHalted()
 	for {
 		log.Errorf("fatal error: %s", err)
 		log.Error("halting the Synchronizer")
-		time.Sleep(5 * time.Second) //nolint:gomnd
+		select {
+		case <-s.halts.Retry():
+			log.Info("resuming the halted Synchronizer")
+			return fmt.Errorf("%w: %s", errHaltRetried, err)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second): //nolint:gomnd
+		}
 	}
 }
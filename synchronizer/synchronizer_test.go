@@ -34,7 +34,7 @@ type mocks struct {
 	DbTx         *dbTxMock
 	ZKEVMClient  *zkEVMClientMock
 	//EventLog     *eventLogMock
-	DataCommitteeClientFactory *dataCommitteeClientFactoryMock
+	DABackend *batchDataGetterMock
 }
 
 //func Test_Given_StartingSynchronizer_When_CallFirstTimeExecutor_Then_StoreProverID(t *testing.T) {
@@ -45,9 +45,6 @@ type mocks struct {
 //	this Check partially point 2: Use previous batch stored in memory to avoid getting from database
 func Test_Given_PermissionlessNode_When_SyncronizeAgainSameBatch_Then_UseTheOneInMemoryInstaeadOfGettingFromDb(t *testing.T) {
 	genesis, cfg, m := setupGenericTest(t)
-	m.Etherman.
-		On("GetCurrentDataCommittee").
-		Return(&etherman.DataCommittee{}, nil)
 	sync_interface, err := NewSynchronizer(false, m.Etherman, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, nil, *genesis, *cfg, nil)
 	require.NoError(t, err)
 	sync, ok := sync_interface.(*ClientSynchronizer)
@@ -71,9 +68,6 @@ func Test_Given_PermissionlessNode_When_SyncronizeAgainSameBatch_Then_UseTheOneI
 //	this Check partially point 2: Store last batch in memory (CurrentTrustedBatch)
 func Test_Given_PermissionlessNode_When_SyncronizeFirstTimeABatch_Then_StoreItInALocalVar(t *testing.T) {
 	genesis, cfg, m := setupGenericTest(t)
-	m.Etherman.
-		On("GetCurrentDataCommittee").
-		Return(&etherman.DataCommittee{}, nil)
 	sync_interface, err := NewSynchronizer(false, m.Etherman, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, nil, *genesis, *cfg, nil)
 	require.NoError(t, err)
 	sync, ok := sync_interface.(*ClientSynchronizer)
@@ -106,11 +100,8 @@ func TestForcedBatch(t *testing.T) {
 		DbTx:        newDbTxMock(t),
 		ZKEVMClient: newZkEVMClientMock(t),
 	}
-	m.Etherman.
-		On("GetCurrentDataCommittee").
-		Return(nil, nil)
 
-	sync, err := NewSynchronizer(false, m.Etherman, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, nil, genesis, cfg, m.DataCommitteeClientFactory)
+	sync, err := NewSynchronizer(false, m.Etherman, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, nil, genesis, cfg, m.DABackend)
 	require.NoError(t, err)
 
 	// state preparation
@@ -359,11 +350,8 @@ func TestSequenceForcedBatch(t *testing.T) {
 		DbTx:        newDbTxMock(t),
 		ZKEVMClient: newZkEVMClientMock(t),
 	}
-	m.Etherman.
-		On("GetCurrentDataCommittee").
-		Return(nil, nil)
 
-	sync, err := NewSynchronizer(true, m.Etherman, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, nil, genesis, cfg, m.DataCommitteeClientFactory)
+	sync, err := NewSynchronizer(true, m.Etherman, m.State, m.Pool, m.EthTxManager, m.ZKEVMClient, nil, genesis, cfg, m.DABackend)
 	require.NoError(t, err)
 
 	// state preparation